@@ -1,6 +1,7 @@
 package freetype
 
 import (
+	"fmt"
 	"math"
 	"strings"
 	"unsafe"
@@ -77,9 +78,41 @@ type PtrDist = libfreetype.TFT_PtrDist
 type String = libfreetype.TFT_String
 
 // Tag is type for 32-bit tags (as used in the SFNT format).
+// It is a defined type, rather than an alias of libfreetype.TFT_Tag, so that it has a String method,
+// and libfreetype.TFT_Tag values must be converted to it.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-basic_types.html#ft_tag
-type Tag = libfreetype.TFT_Tag
+type Tag libfreetype.TFT_Tag
+
+/*
+MakeTag returns the tag of a string of up to 4 characters, such as "GSUB" or "wght".
+A shorter string is padded with spaces, as SFNT tags are, and any characters after the first 4 are ignored.
+
+https://freetype.org/freetype2/docs/reference/ft2-basic_types.html#ft_image_tag
+*/
+func MakeTag(s string) Tag {
+	var tag Tag
+	for i := range 4 {
+		c := byte(' ')
+		if i < len(s) {
+			c = s[i]
+		}
+		tag = tag<<8 | Tag(c)
+	}
+	return tag
+}
+
+// String returns the 4 characters of the tag, such as "GSUB",
+// or its value in hexadecimal, such as "0x00000000", if any of them are not printable ASCII characters.
+func (tag Tag) String() string {
+	b := []byte{byte(tag >> 24), byte(tag >> 16), byte(tag >> 8), byte(tag)}
+	for _, c := range b {
+		if c < ' ' || c > '~' {
+			return fmt.Sprintf("0x%08X", uint32(tag))
+		}
+	}
+	return string(b)
+}
 
 // For FT_Error see error.go file.
 
@@ -268,9 +301,15 @@ const (
 	PIXEL_MODE_BGRA  = PixelMode(7)
 )
 
-// String returns a formatted representation of the 4 bytes of the PixelMode tag.
+// pixelModeNames are the names of the pixel modes.
+var pixelModeNames = [...]string{"NONE", "MONO", "GRAY", "GRAY2", "GRAY4", "LCD", "LCD_V", "BGRA"}
+
+// String returns the name of the pixel mode, such as "GRAY".
 func (pixelMode PixelMode) String() string {
-	return formatTag(uint32(pixelMode))
+	if int(pixelMode) < len(pixelModeNames) {
+		return pixelModeNames[pixelMode]
+	}
+	return fmt.Sprintf("PixelMode(%d)", pixelMode)
 }
 
 // GlyphFormat is an enumeration type used to describe the format of a given glyph image.
@@ -279,26 +318,15 @@ func (pixelMode PixelMode) String() string {
 type GlyphFormat libfreetype.TFT_Glyph_Format
 
 var (
-	GLYPH_FORMAT_NONE      = GlyphFormat(imageTag(0, 0, 0, 0))
-	GLYPH_FORMAT_COMPOSITE = GlyphFormat(imageTag('c', 'o', 'm', 'p'))
-	GLYPH_FORMAT_BITMAP    = GlyphFormat(imageTag('b', 'i', 't', 's'))
-	GLYPH_FORMAT_OUTLINE   = GlyphFormat(imageTag('o', 'u', 't', 'l'))
-	GLYPH_FORMAT_PLOTTER   = GlyphFormat(imageTag('p', 'l', 'o', 't'))
-	GLYPH_FORMAT_SVG       = GlyphFormat(imageTag('S', 'V', 'G', ' '))
+	GLYPH_FORMAT_NONE      = GlyphFormat(0)
+	GLYPH_FORMAT_COMPOSITE = GlyphFormat(MakeTag("comp"))
+	GLYPH_FORMAT_BITMAP    = GlyphFormat(MakeTag("bits"))
+	GLYPH_FORMAT_OUTLINE   = GlyphFormat(MakeTag("outl"))
+	GLYPH_FORMAT_PLOTTER   = GlyphFormat(MakeTag("plot"))
+	GLYPH_FORMAT_SVG       = GlyphFormat(MakeTag("SVG "))
 )
 
-// String returns a formatted representation of the 4 bytes of the GlyphFormat tag.
+// String returns the 4 characters of the GlyphFormat tag, such as "outl".
 func (glyphFormat GlyphFormat) String() string {
-	return formatTag(uint32(glyphFormat))
-}
-
-// imageTag converts four-letter tags to an unsigned long type.
-//
-// https://freetype.org/freetype2/docs/reference/ft2-basic_types.html#ft_image_tag
-func imageTag(x1, x2, x3, x4 byte) uint32 {
-	return 0 |
-		uint32(x1)<<24 |
-		uint32(x2)<<16 |
-		uint32(x3)<<8 |
-		uint32(x4)<<0
+	return Tag(glyphFormat).String()
 }
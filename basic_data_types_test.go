@@ -0,0 +1,23 @@
+package freetype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeTag(t *testing.T) {
+	assert.Equal(t, Tag(0x47535542), MakeTag("GSUB"))
+	assert.Equal(t, MakeTag("gb  "), MakeTag("gb"))
+	assert.Equal(t, MakeTag("wght"), MakeTag("wghtx"))
+}
+
+func TestTagString(t *testing.T) {
+	assert.Equal(t, "GSUB", MakeTag("GSUB").String())
+	assert.Equal(t, "OS/2", MakeTag("OS/2").String())
+	assert.Equal(t, "0x00000000", Tag(0).String())
+	assert.Equal(t, "outl", GLYPH_FORMAT_OUTLINE.String())
+	assert.Equal(t, "unic", ENCODING_UNICODE.String())
+	assert.Equal(t, "GRAY", PIXEL_MODE_GRAY.String())
+	assert.Equal(t, "PixelMode(99)", PixelMode(99).String())
+}
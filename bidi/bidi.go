@@ -0,0 +1,700 @@
+/*
+Package bidi implements the Unicode Bidirectional Algorithm (UAX #9).
+
+Text is analysed with Analyze, which resolves the embedding level of each character.
+After text has been broken in to lines, the characters of each line can then be
+reordered from logical order in to visual (left to right) order, for glyph lookup and display.
+
+https://www.unicode.org/reports/tr9/
+*/
+package bidi
+
+import "sort"
+
+// Direction is the base direction of the paragraphs of a text.
+type Direction int
+
+const (
+	// Auto determines the direction of each paragraph from its first strong character (rules P2 and P3),
+	// defaulting to left-to-right.
+	Auto Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+// Level is an embedding level. Characters at odd levels are displayed right-to-left.
+type Level uint8
+
+// maxDepth is the maximum explicit embedding level (BD2).
+const maxDepth = 125
+
+// RightToLeft reports whether characters at the level are displayed right-to-left.
+func (level Level) RightToLeft() bool {
+	return level&1 == 1
+}
+
+// Text is the result of applying the Unicode Bidirectional Algorithm to a text.
+// Characters are identified by their index in the text's runes.
+type Text struct {
+	runes           []rune
+	classes         []class
+	levels          []Level
+	paragraphLevels []Level
+}
+
+/*
+Analyze resolves the embedding levels of the characters of text.
+The text is split in to paragraphs at paragraph separators, and direction sets the base
+direction of each paragraph.
+
+https://www.unicode.org/reports/tr9/#Basic_Display_Algorithm
+*/
+func Analyze(text string, direction Direction) *Text {
+	t := &Text{runes: []rune(text)}
+	n := len(t.runes)
+	t.classes = make([]class, n)
+	for i, r := range t.runes {
+		t.classes[i] = classOf(r)
+	}
+	t.levels = make([]Level, n)
+	t.paragraphLevels = make([]Level, n)
+
+	start := 0
+	for i := range n {
+		if t.classes[i] == classB || i == n-1 {
+			t.resolveParagraph(start, i+1, direction)
+			start = i + 1
+		}
+	}
+	return t
+}
+
+// Runes returns the characters of the text.
+func (t *Text) Runes() []rune {
+	return t.runes
+}
+
+// Levels returns the resolved embedding level of each character, before any line-based
+// adjustment (rule L1).
+func (t *Text) Levels() []Level {
+	return t.levels
+}
+
+// ParagraphLevel returns the embedding level of the paragraph that contains the character at index i.
+func (t *Text) ParagraphLevel(i int) Level {
+	return t.paragraphLevels[i]
+}
+
+/*
+LineLevels returns the embedding levels of the characters of a line, the characters from
+index start up to (but not including) end.
+Trailing whitespace, and whitespace before segment and paragraph separators, is reset
+to the paragraph's level.
+
+https://www.unicode.org/reports/tr9/#L1
+*/
+func (t *Text) LineLevels(start, end int) []Level {
+	levels := make([]Level, end-start)
+	copy(levels, t.levels[start:end])
+
+	trailing := true
+	for i := end - 1; i >= start; i-- {
+		c := t.classes[i]
+		switch {
+		case c == classS || c == classB:
+			levels[i-start] = t.paragraphLevels[i]
+			trailing = true
+		case trailing && (c == classWS || isIsolateControl(c) || isRemoved(c)):
+			levels[i-start] = t.paragraphLevels[i]
+		default:
+			trailing = false
+		}
+	}
+	return levels
+}
+
+// Visual returns the indexes of the characters of a line, the characters from
+// index start up to (but not including) end, in visual order.
+func (t *Text) Visual(start, end int) []int {
+	order := VisualOrder(t.LineLevels(start, end))
+	for i := range order {
+		order[i] += start
+	}
+	return order
+}
+
+/*
+VisualOrder returns the order in which to display characters with the given levels,
+as indexes in to levels. Sequences of characters at or above each odd level are reversed,
+from the highest level down.
+
+https://www.unicode.org/reports/tr9/#L2
+*/
+func VisualOrder(levels []Level) []int {
+	order := make([]int, len(levels))
+	current := make([]Level, len(levels))
+	copy(current, levels)
+
+	var highest Level
+	lowestOdd := Level(maxDepth + 2)
+	for i, level := range levels {
+		order[i] = i
+		highest = max(highest, level)
+		if level.RightToLeft() {
+			lowestOdd = min(lowestOdd, level)
+		}
+	}
+
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(current); {
+			if current[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(current) && current[j] >= level {
+				j++
+			}
+			reverse(order[i:j])
+			reverse(current[i:j])
+			i = j
+		}
+	}
+	return order
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// isRemoved reports whether characters of a type are removed by rule X9.
+func isRemoved(c class) bool {
+	switch c {
+	case classRLE, classLRE, classRLO, classLRO, classPDF, classBN:
+		return true
+	}
+	return false
+}
+
+func isIsolateInitiator(c class) bool {
+	return c == classLRI || c == classRLI || c == classFSI
+}
+
+func isIsolateControl(c class) bool {
+	return isIsolateInitiator(c) || c == classPDI
+}
+
+// isNeutral reports whether a type is a neutral or isolate formatting character (NI).
+func isNeutral(c class) bool {
+	switch c {
+	case classB, classS, classWS, classON, classLRI, classRLI, classFSI, classPDI:
+		return true
+	}
+	return false
+}
+
+// strongDirection returns the strong direction of a type, for the resolution of neutrals,
+// in which numbers are treated as R.
+func strongDirection(c class) (class, bool) {
+	switch c {
+	case classL:
+		return classL, true
+	case classR, classAL, classEN, classAN:
+		return classR, true
+	}
+	return classON, false
+}
+
+func directionOfLevel(level Level) class {
+	if level.RightToLeft() {
+		return classR
+	}
+	return classL
+}
+
+// paragraph holds the state of the resolution of a single paragraph.
+type paragraph struct {
+	runes       []rune
+	classes     []class
+	types       []class
+	levels      []Level
+	level       Level
+	matchingPDI []int
+	isMatched   []bool
+}
+
+func (t *Text) resolveParagraph(start, end int, direction Direction) {
+	p := &paragraph{
+		runes:   t.runes[start:end],
+		classes: t.classes[start:end],
+		types:   make([]class, end-start),
+		levels:  t.levels[start:end],
+	}
+	copy(p.types, p.classes)
+	p.matchIsolates()
+
+	switch direction {
+	case LeftToRight:
+		p.level = 0
+	case RightToLeft:
+		p.level = 1
+	default:
+		if p.firstStrong(0, len(p.classes)) == classR {
+			p.level = 1
+		}
+	}
+	for i := start; i < end; i++ {
+		t.paragraphLevels[i] = p.level
+	}
+
+	p.resolveExplicit()
+	for _, sequence := range p.isolatingRunSequences() {
+		p.resolveSequence(sequence)
+	}
+
+	// Removed characters take the level of the preceding character.
+	level := p.level
+	for i, c := range p.classes {
+		if isRemoved(c) {
+			p.levels[i] = level
+		} else {
+			level = p.levels[i]
+		}
+	}
+}
+
+// matchIsolates finds the matching PDI of each isolate initiator (BD9).
+func (p *paragraph) matchIsolates() {
+	p.matchingPDI = make([]int, len(p.classes))
+	p.isMatched = make([]bool, len(p.classes))
+	var open []int
+	for i, c := range p.classes {
+		p.matchingPDI[i] = -1
+		switch {
+		case isIsolateInitiator(c):
+			open = append(open, i)
+		case c == classPDI && len(open) > 0:
+			initiator := open[len(open)-1]
+			open = open[:len(open)-1]
+			p.matchingPDI[initiator] = i
+			p.isMatched[i] = true
+		}
+	}
+}
+
+// firstStrong returns the type of the first strong character from index start up to end,
+// skipping isolates, or ON if there is none (P2).
+func (p *paragraph) firstStrong(start, end int) class {
+	for i := start; i < end; i++ {
+		switch c := p.classes[i]; {
+		case c == classL:
+			return classL
+		case c == classR || c == classAL:
+			return classR
+		case isIsolateInitiator(c):
+			if p.matchingPDI[i] < 0 {
+				return classON
+			}
+			i = p.matchingPDI[i]
+		}
+	}
+	return classON
+}
+
+type directionalStatus struct {
+	level    Level
+	override class
+	isolate  bool
+}
+
+func nextLevel(level Level, rtl bool) Level {
+	if rtl {
+		return (level + 1) | 1
+	}
+	return (level + 2) &^ 1
+}
+
+// resolveExplicit applies rules X1 to X8.
+func (p *paragraph) resolveExplicit() {
+	stack := []directionalStatus{{level: p.level, override: classON}}
+	overflowIsolates, overflowEmbeddings, validIsolates := 0, 0, 0
+
+	for i, c := range p.classes {
+		top := stack[len(stack)-1]
+		switch c {
+		case classRLE, classLRE, classRLO, classLRO:
+			p.levels[i] = top.level
+			level := nextLevel(top.level, c == classRLE || c == classRLO)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				status := directionalStatus{level: level, override: classON}
+				switch c {
+				case classRLO:
+					status.override = classR
+				case classLRO:
+					status.override = classL
+				}
+				stack = append(stack, status)
+			} else if overflowIsolates == 0 {
+				overflowEmbeddings++
+			}
+
+		case classRLI, classLRI, classFSI:
+			p.levels[i] = top.level
+			if top.override != classON {
+				p.types[i] = top.override
+			}
+			rtl := c == classRLI
+			if c == classFSI {
+				end := p.matchingPDI[i]
+				if end < 0 {
+					end = len(p.classes)
+				}
+				rtl = p.firstStrong(i+1, end) == classR
+			}
+			level := nextLevel(top.level, rtl)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				validIsolates++
+				stack = append(stack, directionalStatus{level: level, override: classON, isolate: true})
+			} else {
+				overflowIsolates++
+			}
+
+		case classPDI:
+			if overflowIsolates > 0 {
+				overflowIsolates--
+			} else if validIsolates > 0 {
+				overflowEmbeddings = 0
+				for !stack[len(stack)-1].isolate {
+					stack = stack[:len(stack)-1]
+				}
+				stack = stack[:len(stack)-1]
+				validIsolates--
+			}
+			top = stack[len(stack)-1]
+			p.levels[i] = top.level
+			if top.override != classON {
+				p.types[i] = top.override
+			}
+
+		case classPDF:
+			p.levels[i] = top.level
+			if overflowIsolates > 0 {
+				// Do nothing.
+			} else if overflowEmbeddings > 0 {
+				overflowEmbeddings--
+			} else if !top.isolate && len(stack) >= 2 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case classB:
+			p.levels[i] = p.level
+
+		case classBN:
+			p.levels[i] = top.level
+
+		default:
+			p.levels[i] = top.level
+			if top.override != classON {
+				p.types[i] = top.override
+			}
+		}
+	}
+}
+
+// isolatingRunSequence is a sequence of characters, that are resolved together by rules W1 to I2.
+type isolatingRunSequence struct {
+	indexes  []int
+	level    Level
+	sos, eos class
+}
+
+// isolatingRunSequences divides the paragraph in to level runs,
+// and chains them in to isolating run sequences (BD13, X10).
+func (p *paragraph) isolatingRunSequences() []isolatingRunSequence {
+	var runs [][]int
+	runStartingAt := map[int]int{}
+	previous := -1
+	for i, c := range p.classes {
+		if isRemoved(c) {
+			continue
+		}
+		if previous < 0 || p.levels[i] != p.levels[previous] {
+			runStartingAt[i] = len(runs)
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], i)
+		previous = i
+	}
+
+	var sequences []isolatingRunSequence
+	for _, run := range runs {
+		if p.classes[run[0]] == classPDI && p.isMatched[run[0]] {
+			// A continuation of the sequence containing the matching isolate initiator.
+			continue
+		}
+
+		indexes := append([]int(nil), run...)
+		for {
+			last := indexes[len(indexes)-1]
+			if !isIsolateInitiator(p.classes[last]) || p.matchingPDI[last] < 0 {
+				break
+			}
+			next, ok := runStartingAt[p.matchingPDI[last]]
+			if !ok {
+				break
+			}
+			indexes = append(indexes, runs[next]...)
+		}
+
+		sequence := isolatingRunSequence{indexes: indexes, level: p.levels[indexes[0]]}
+
+		before := p.level
+		for i := indexes[0] - 1; i >= 0; i-- {
+			if !isRemoved(p.classes[i]) {
+				before = p.levels[i]
+				break
+			}
+		}
+		sequence.sos = directionOfLevel(max(before, sequence.level))
+
+		after := p.level
+		last := indexes[len(indexes)-1]
+		if !isIsolateInitiator(p.classes[last]) {
+			for i := last + 1; i < len(p.classes); i++ {
+				if !isRemoved(p.classes[i]) {
+					after = p.levels[i]
+					break
+				}
+			}
+		}
+		sequence.eos = directionOfLevel(max(after, sequence.level))
+
+		sequences = append(sequences, sequence)
+	}
+	return sequences
+}
+
+// resolveSequence applies the rules for weak types (W1 to W7), neutral and isolate
+// formatting types (N0 to N2), and implicit levels (I1 and I2), to an isolating run sequence.
+func (p *paragraph) resolveSequence(sequence isolatingRunSequence) {
+	n := len(sequence.indexes)
+	types := make([]class, n)
+	for k, i := range sequence.indexes {
+		types[k] = p.types[i]
+	}
+	original := append([]class(nil), types...)
+
+	// W1
+	for k := range types {
+		if types[k] != classNSM {
+			continue
+		}
+		switch {
+		case k == 0:
+			types[k] = sequence.sos
+		case isIsolateControl(p.classes[sequence.indexes[k-1]]):
+			types[k] = classON
+		default:
+			types[k] = types[k-1]
+		}
+	}
+
+	// W2 and W3
+	lastStrong := sequence.sos
+	for k, c := range types {
+		switch c {
+		case classL, classR:
+			lastStrong = c
+		case classAL:
+			lastStrong = c
+			types[k] = classR
+		case classEN:
+			if lastStrong == classAL {
+				types[k] = classAN
+			}
+		}
+	}
+
+	// W4
+	for k := 1; k < n-1; k++ {
+		before, after := types[k-1], types[k+1]
+		switch types[k] {
+		case classES:
+			if before == classEN && after == classEN {
+				types[k] = classEN
+			}
+		case classCS:
+			if before == after && (before == classEN || before == classAN) {
+				types[k] = before
+			}
+		}
+	}
+
+	// W5
+	for k := 0; k < n; {
+		if types[k] != classET {
+			k++
+			continue
+		}
+		j := k
+		for j < n && types[j] == classET {
+			j++
+		}
+		if (k > 0 && types[k-1] == classEN) || (j < n && types[j] == classEN) {
+			for m := k; m < j; m++ {
+				types[m] = classEN
+			}
+		}
+		k = j
+	}
+
+	// W6 and W7
+	lastStrong = sequence.sos
+	for k, c := range types {
+		switch c {
+		case classES, classET, classCS:
+			types[k] = classON
+		case classL, classR:
+			lastStrong = c
+		case classEN:
+			if lastStrong == classL {
+				types[k] = classL
+			}
+		}
+	}
+
+	embedding := directionOfLevel(sequence.level)
+
+	// N0
+	for _, pair := range p.bracketPairs(sequence.indexes, types) {
+		opposite := classL
+		if embedding == classL {
+			opposite = classR
+		}
+
+		foundEmbedding, foundOpposite := false, false
+		for k := pair[0] + 1; k < pair[1]; k++ {
+			direction, ok := strongDirection(types[k])
+			if !ok {
+				continue
+			}
+			if direction == embedding {
+				foundEmbedding = true
+				break
+			}
+			foundOpposite = true
+		}
+
+		var resolved class
+		switch {
+		case foundEmbedding:
+			resolved = embedding
+		case foundOpposite:
+			context := sequence.sos
+			for k := pair[0] - 1; k >= 0; k-- {
+				if direction, ok := strongDirection(types[k]); ok {
+					context = direction
+					break
+				}
+			}
+			resolved = embedding
+			if context == opposite {
+				resolved = opposite
+			}
+		default:
+			continue
+		}
+
+		for _, k := range pair {
+			types[k] = resolved
+			for m := k + 1; m < n && original[m] == classNSM; m++ {
+				types[m] = resolved
+			}
+		}
+	}
+
+	// N1 and N2
+	for k := 0; k < n; {
+		if !isNeutral(types[k]) {
+			k++
+			continue
+		}
+		j := k
+		for j < n && isNeutral(types[j]) {
+			j++
+		}
+		before := sequence.sos
+		if k > 0 {
+			before, _ = strongDirection(types[k-1])
+		}
+		after := sequence.eos
+		if j < n {
+			after, _ = strongDirection(types[j])
+		}
+		resolved := embedding
+		if before == after {
+			resolved = before
+		}
+		for m := k; m < j; m++ {
+			types[m] = resolved
+		}
+		k = j
+	}
+
+	// I1 and I2
+	for k, i := range sequence.indexes {
+		level := p.levels[i]
+		switch c := types[k]; {
+		case !level.RightToLeft() && c == classR:
+			p.levels[i] = level + 1
+		case !level.RightToLeft() && (c == classAN || c == classEN):
+			p.levels[i] = level + 2
+		case level.RightToLeft() && (c == classL || c == classAN || c == classEN):
+			p.levels[i] = level + 1
+		}
+	}
+}
+
+// maxBracketDepth is the maximum depth of nested brackets (BD16).
+const maxBracketDepth = 63
+
+// bracketPairs identifies the bracket pairs in an isolating run sequence (BD16).
+// Pairs are returned as positions within the sequence, sorted by their opening brackets.
+func (p *paragraph) bracketPairs(indexes []int, types []class) [][2]int {
+	type opening struct {
+		closing  rune
+		position int
+	}
+	var stack []opening
+	var pairs [][2]int
+
+	for k, i := range indexes {
+		if types[k] != classON {
+			continue
+		}
+		r := canonicalBracket(p.runes[i])
+		paired, isOpening, ok := pairedBracket(r)
+		if !ok {
+			continue
+		}
+		if isOpening {
+			if len(stack) == maxBracketDepth {
+				break
+			}
+			stack = append(stack, opening{closing: canonicalBracket(paired), position: k})
+			continue
+		}
+		for s := len(stack) - 1; s >= 0; s-- {
+			if stack[s].closing == r {
+				pairs = append(pairs, [2]int{stack[s].position, k})
+				stack = stack[:s]
+				break
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return pairs
+}
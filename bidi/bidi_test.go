@@ -0,0 +1,156 @@
+package bidi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// visual returns the characters of text in visual order, omitting formatting characters.
+func visual(text string, direction Direction) string {
+	t := Analyze(text, direction)
+	var runes []rune
+	for _, i := range t.Visual(0, len(t.Runes())) {
+		if !isRemoved(t.classes[i]) && !isIsolateControl(t.classes[i]) {
+			runes = append(runes, t.runes[i])
+		}
+	}
+	return string(runes)
+}
+
+func TestClassOf(t *testing.T) {
+	assert.Equal(t, classL, classOf('a'))
+	assert.Equal(t, classR, classOf('א'))
+	assert.Equal(t, classAL, classOf('ع'))
+	assert.Equal(t, classEN, classOf('7'))
+	assert.Equal(t, classAN, classOf('٣'))
+	assert.Equal(t, classNSM, classOf(0x0301))
+	assert.Equal(t, classNSM, classOf(0x05B4))
+	assert.Equal(t, classON, classOf('!'))
+	assert.Equal(t, classWS, classOf(' '))
+	assert.Equal(t, classB, classOf('\n'))
+	assert.Equal(t, classET, classOf('$'))
+	assert.Equal(t, classBN, classOf(0x200D))
+	assert.Equal(t, classL, classOf('中'))
+	assert.Equal(t, classLRI, classOf(0x2066))
+	// Characters whose types do not follow from their general category or block.
+	assert.Equal(t, classON, classOf(0xFD3E))
+	assert.Equal(t, classEN, classOf(0x1F100))
+	assert.Equal(t, classR, classOf(0x10FFD))
+}
+
+func TestAnalyzeDirection(t *testing.T) {
+	assert.Equal(t, "abc", visual("abc", Auto))
+	assert.Equal(t, "גבא", visual("אבג", Auto))
+	assert.Equal(t, "abc גבא def", visual("abc אבג def", Auto))
+	assert.Equal(t, "def גבא abc", visual("abc אבג def", RightToLeft))
+	assert.Equal(t, "abc גבא", visual("אבג abc", Auto))
+	assert.Equal(t, "גבא abc", visual("אבג abc", LeftToRight))
+	assert.Equal(t, "123", visual("123", Auto))
+
+	text := Analyze("abc\nאבג", Auto)
+	assert.Equal(t, Level(0), text.ParagraphLevel(0))
+	assert.Equal(t, Level(0), text.ParagraphLevel(3))
+	assert.Equal(t, Level(1), text.ParagraphLevel(4))
+	assert.Equal(t, []Level{0, 0, 0, 0, 1, 1, 1}, text.Levels())
+}
+
+func TestAnalyzeNumbers(t *testing.T) {
+	// Numbers keep their left-to-right order in right-to-left text.
+	assert.Equal(t, "123 גבא", visual("אבג 123", Auto))
+	assert.Equal(t, "3.14 גבא", visual("אבג 3.14", Auto))
+	assert.Equal(t, "50% גבא", visual("אבג 50%", Auto))
+
+	// European digits after Arabic letters are Arabic numbers (W2).
+	text := Analyze("ع 12", Auto)
+	assert.Equal(t, []Level{1, 1, 2, 2}, text.Levels())
+}
+
+func TestAnalyzeBrackets(t *testing.T) {
+	// The example for rule N0: AB(CD[&ef]!)gh in a right-to-left paragraph,
+	// with Hebrew letters for the uppercase letters.
+	text := Analyze("אב(גד[&ef]!)gh", RightToLeft)
+	assert.Equal(t, []Level{1, 1, 1, 1, 1, 1, 1, 2, 2, 1, 1, 1, 2, 2}, text.Levels())
+
+	// Brackets that enclose only left-to-right text, after left-to-right text, in a
+	// right-to-left paragraph.
+	text = Analyze("a (b) א", RightToLeft)
+	assert.Equal(t, []Level{2, 2, 2, 2, 2, 1, 1}, text.Levels())
+
+	// The same, with the square brackets with stroke added in Unicode 14.
+	text = Analyze("a \u2E55b\u2E56 א", RightToLeft)
+	assert.Equal(t, []Level{2, 2, 2, 2, 2, 1, 1}, text.Levels())
+
+	// Unmatched brackets are resolved as other neutrals.
+	text = Analyze("אב(c", RightToLeft)
+	assert.Equal(t, []Level{1, 1, 1, 2}, text.Levels())
+}
+
+func TestAnalyzeExplicit(t *testing.T) {
+	assert.Equal(t, "cba", visual("‮abc‬", Auto))
+	assert.Equal(t, "xyz cba", visual("xyz ‮abc‬", Auto))
+	assert.Equal(t, "def גבא abc", visual("‫abc אבג def‬", LeftToRight))
+
+	// An isolate does not affect the direction of the surrounding text.
+	assert.Equal(t, "גבא abc", visual("⁧אבג⁩ abc", Auto))
+	assert.Equal(t, "abc 1 גבא", visual("abc ⁨אבג 1⁩", Auto))
+}
+
+func TestLineLevels(t *testing.T) {
+	text := Analyze("אבג \tדה  ", LeftToRight)
+	assert.Equal(t, []Level{1, 1, 1, 0, 0, 1, 1, 0, 0}, text.LineLevels(0, 9))
+	assert.Equal(t, []Level{1, 1, 1, 0}, text.LineLevels(0, 4))
+}
+
+func TestVisualOrder(t *testing.T) {
+	assert.Equal(t, []int{0, 5, 3, 4, 2, 1, 6}, VisualOrder([]Level{0, 1, 1, 2, 2, 1, 0}))
+	assert.Equal(t, []int{2, 1, 0}, VisualOrder([]Level{1, 1, 1}))
+	assert.Equal(t, []int{}, VisualOrder([]Level{}))
+}
+
+func TestPairedBracket(t *testing.T) {
+	paired, opening, ok := pairedBracket('(')
+	assert.True(t, ok)
+	assert.True(t, opening)
+	assert.Equal(t, ')', paired)
+
+	paired, opening, ok = pairedBracket(0x2E5C)
+	assert.True(t, ok)
+	assert.False(t, opening)
+	assert.Equal(t, rune(0x2E5B), paired)
+
+	_, _, ok = pairedBracket('<')
+	assert.False(t, ok)
+
+	// Each of the pairs of BidiBrackets.txt pairs with its mirroring glyph.
+	pairs := 0
+	for r := range rune(0x30000) {
+		if paired, opening, ok := pairedBracket(r); ok && opening {
+			pairs++
+			mirror, _ := Mirror(r)
+			assert.Equal(t, mirror, paired)
+		}
+	}
+	assert.Equal(t, 64, pairs)
+}
+
+func TestMirror(t *testing.T) {
+	mirror, ok := Mirror('(')
+	assert.True(t, ok)
+	assert.Equal(t, ')', mirror)
+
+	mirror, ok = Mirror('»')
+	assert.True(t, ok)
+	assert.Equal(t, '«', mirror)
+
+	mirror, ok = Mirror('≤')
+	assert.True(t, ok)
+	assert.Equal(t, '≥', mirror)
+
+	mirror, ok = Mirror(0x2E55)
+	assert.True(t, ok)
+	assert.Equal(t, rune(0x2E56), mirror)
+
+	_, ok = Mirror('a')
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package bidi
+
+import ucd "golang.org/x/text/unicode/bidi"
+
+// Bidirectional character types, as assigned to characters by UAX #9.
+
+// class is a bidirectional character type.
+type class uint8
+
+const (
+	classL   class = iota // Left-to-Right
+	classR                // Right-to-Left
+	classAL               // Right-to-Left Arabic
+	classEN               // European Number
+	classES               // European Number Separator
+	classET               // European Number Terminator
+	classAN               // Arabic Number
+	classCS               // Common Number Separator
+	classNSM              // Nonspacing Mark
+	classBN               // Boundary Neutral
+	classB                // Paragraph Separator
+	classS                // Segment Separator
+	classWS               // Whitespace
+	classON               // Other Neutrals
+	classLRE              // Left-to-Right Embedding
+	classLRO              // Left-to-Right Override
+	classRLE              // Right-to-Left Embedding
+	classRLO              // Right-to-Left Override
+	classPDF              // Pop Directional Format
+	classLRI              // Left-to-Right Isolate
+	classRLI              // Right-to-Left Isolate
+	classFSI              // First Strong Isolate
+	classPDI              // Pop Directional Isolate
+)
+
+// classes maps the Bidi_Class values of the Unicode Character Database to the types used here.
+var classes = [...]class{
+	ucd.L:   classL,
+	ucd.R:   classR,
+	ucd.AL:  classAL,
+	ucd.EN:  classEN,
+	ucd.ES:  classES,
+	ucd.ET:  classET,
+	ucd.AN:  classAN,
+	ucd.CS:  classCS,
+	ucd.NSM: classNSM,
+	ucd.BN:  classBN,
+	ucd.B:   classB,
+	ucd.S:   classS,
+	ucd.WS:  classWS,
+	ucd.ON:  classON,
+	ucd.LRE: classLRE,
+	ucd.LRO: classLRO,
+	ucd.RLE: classRLE,
+	ucd.RLO: classRLO,
+	ucd.PDF: classPDF,
+	ucd.LRI: classLRI,
+	ucd.RLI: classRLI,
+	ucd.FSI: classFSI,
+	ucd.PDI: classPDI,
+}
+
+// classOf returns the bidirectional character type of a character,
+// which is its Bidi_Class property in the Unicode Character Database.
+func classOf(r rune) class {
+	props, _ := ucd.LookupRune(r)
+	if c := props.Class(); int(c) < len(classes) {
+		return classes[c]
+	}
+	return classL
+}
@@ -0,0 +1,106 @@
+//go:build ignore
+
+// gen_mirroring generates mirroring_table.go from BidiMirroring.txt of the Unicode Character Database.
+//
+// Usage:
+//
+//	go run gen_mirroring.go [BidiMirroring.txt]
+//
+// The file is downloaded from unicode.org if it is not given.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// unicodeVersion is the version of the Unicode Character Database,
+// which is the version of the bidirectional classes from golang.org/x/text.
+const unicodeVersion = "15.0.0"
+
+var url = "https://www.unicode.org/Public/" + unicodeVersion + "/ucd/BidiMirroring.txt"
+
+func main() {
+	data, err := readUCD()
+	if err != nil {
+		log.Fatal(err)
+	}
+	mirrors, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(mirrors)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("mirroring_table.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readUCD() ([]byte, error) {
+	if len(os.Args) > 1 {
+		return os.ReadFile(os.Args[1])
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s : %s", url, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// parse returns the pairs of characters and their mirroring glyphs, in the order of the file.
+func parse(data []byte) ([][2]rune, error) {
+	var mirrors [][2]rune
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		codePoint, mirror, ok := strings.Cut(text, ";")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing ';'", line)
+		}
+		r, err := strconv.ParseUint(strings.TrimSpace(codePoint), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		m, err := strconv.ParseUint(strings.TrimSpace(mirror), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		mirrors = append(mirrors, [2]rune{rune(r), rune(m)})
+	}
+	return mirrors, scanner.Err()
+}
+
+func generate(mirrors [][2]rune) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_mirroring.go from %s. DO NOT EDIT.\n\n", url)
+	b.WriteString("package bidi\n\n")
+	b.WriteString("// mirroringGlyphs maps characters to their Bidi_Mirroring_Glyph, from the Unicode Character Database.\n")
+	b.WriteString("var mirroringGlyphs = map[rune]rune{\n")
+	for _, mirror := range mirrors {
+		fmt.Fprintf(&b, "\t0x%04X: 0x%04X,\n", mirror[0], mirror[1])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,48 @@
+package bidi
+
+import (
+	"unicode/utf8"
+
+	ucd "golang.org/x/text/unicode/bidi"
+)
+
+// Paired brackets and mirrored characters.
+
+//go:generate go run gen_mirroring.go
+
+// pairedBracket returns the Bidi_Paired_Bracket of a character, and whether it is an opening bracket.
+// It returns false if the character is not a paired bracket.
+func pairedBracket(r rune) (paired rune, opening bool, ok bool) {
+	props, _ := ucd.LookupRune(r)
+	if !props.IsBracket() {
+		return 0, false, false
+	}
+	// ReverseString replaces a bracket with its Bidi_Paired_Bracket.
+	paired, _ = utf8.DecodeRuneInString(ucd.ReverseString(string(r)))
+	return paired, props.IsOpeningBracket(), true
+}
+
+// canonicalBracket maps the angle brackets U+2329 and U+232A to their canonical
+// equivalents U+3008 and U+3009, so that either form can pair with the other (BD16).
+func canonicalBracket(r rune) rune {
+	switch r {
+	case 0x2329:
+		return 0x3008
+	case 0x232A:
+		return 0x3009
+	}
+	return r
+}
+
+/*
+Mirror returns the Bidi_Mirroring_Glyph of a character, the character whose glyph
+is the mirror image of r's.
+Characters at an odd (right-to-left) embedding level should be displayed with the
+mirrored character's glyph, if the font has one.
+
+https://www.unicode.org/reports/tr9/#L4
+*/
+func Mirror(r rune) (rune, bool) {
+	mirror, ok := mirroringGlyphs[r]
+	return mirror, ok
+}
@@ -0,0 +1,168 @@
+package freetype
+
+import (
+	"image"
+	"image/color"
+)
+
+// Conversion of bitmaps to the image types of the standard library's image package.
+
+/*
+Image returns the Bitmap as an image.Image, with its top left pixel at (0, 0).
+
+Bitmaps with a PixelMode of PIXEL_MODE_MONO, PIXEL_MODE_GRAY, PIXEL_MODE_GRAY2, or PIXEL_MODE_GRAY4
+are returned as an *image.Alpha, as described for Alpha.
+Bitmaps with a PixelMode of PIXEL_MODE_BGRA are returned as an *image.RGBA, as described for RGBA.
+Other pixel modes are not supported.
+*/
+func (bm Bitmap) Image() (image.Image, error) {
+	if bm.PixelMode == PIXEL_MODE_BGRA {
+		return bm.RGBA()
+	}
+	return bm.Alpha()
+}
+
+/*
+Alpha returns a grayscale Bitmap as an *image.Alpha, with its top left pixel at (0, 0).
+It is suitable for use as a mask with image/draw.DrawMask.
+
+For PIXEL_MODE_GRAY bitmaps with a positive pitch the image shares the Bitmap's buffer.
+Such an image is only valid until the glyph slot that the bitmap belongs to is next loaded or rendered,
+or its face is discarded.
+If the image is required after that it should be copied.
+
+PIXEL_MODE_MONO, PIXEL_MODE_GRAY2, and PIXEL_MODE_GRAY4 bitmaps, and bitmaps with a negative pitch,
+are converted to a newly allocated image.
+*/
+func (bm Bitmap) Alpha() (*image.Alpha, error) {
+	pix, stride, err := bm.coverage()
+	if err != nil {
+		return nil, err
+	}
+	return &image.Alpha{Pix: pix, Stride: stride, Rect: bm.bounds()}, nil
+}
+
+/*
+Gray returns a grayscale Bitmap as an *image.Gray, with its top left pixel at (0, 0).
+Coverage is represented by lighter pixels, as for BufferVisualization.
+
+The image shares the Bitmap's buffer, or is converted, in the same way as for Alpha.
+*/
+func (bm Bitmap) Gray() (*image.Gray, error) {
+	pix, stride, err := bm.coverage()
+	if err != nil {
+		return nil, err
+	}
+	return &image.Gray{Pix: pix, Stride: stride, Rect: bm.bounds()}, nil
+}
+
+/*
+RGBA returns a PIXEL_MODE_BGRA Bitmap as an *image.RGBA, with its top left pixel at (0, 0).
+
+BGRA bitmaps use premultiplied alpha, as does image.RGBA,
+so only the order of the color components is changed.
+The image is always newly allocated.
+*/
+func (bm Bitmap) RGBA() (*image.RGBA, error) {
+	if bm.PixelMode != PIXEL_MODE_BGRA {
+		return nil, newError(Err_Invalid_Argument, "bitmap pixel mode %d is not BGRA", bm.PixelMode)
+	}
+
+	img := image.NewRGBA(bm.bounds())
+	bm.eachBGRA(func(x, y int, b, g, r, a byte) {
+		pix := img.Pix[img.PixOffset(x, y):]
+		pix[0] = r
+		pix[1] = g
+		pix[2] = b
+		pix[3] = a
+	})
+	return img, nil
+}
+
+/*
+NRGBA returns a PIXEL_MODE_BGRA Bitmap as an *image.NRGBA, with its top left pixel at (0, 0).
+The Bitmap's premultiplied colors are converted to non-premultiplied colors.
+
+The image is always newly allocated.
+*/
+func (bm Bitmap) NRGBA() (*image.NRGBA, error) {
+	if bm.PixelMode != PIXEL_MODE_BGRA {
+		return nil, newError(Err_Invalid_Argument, "bitmap pixel mode %d is not BGRA", bm.PixelMode)
+	}
+
+	img := image.NewNRGBA(bm.bounds())
+	bm.eachBGRA(func(x, y int, b, g, r, a byte) {
+		img.SetNRGBA(x, y, color.NRGBAModel.Convert(color.RGBA{R: r, G: g, B: b, A: a}).(color.NRGBA))
+	})
+	return img, nil
+}
+
+func (bm Bitmap) bounds() image.Rectangle {
+	return image.Rect(0, 0, int(bm.Width), int(bm.Rows))
+}
+
+// row returns the bytes of a row of the bitmap, where row 0 is the top row.
+func (bm Bitmap) row(buffer []byte, y int) []byte {
+	pitch := int(bm.Pitch)
+	if pitch < 0 {
+		// The buffer starts with the bottom row.
+		pitch = -pitch
+		y = int(bm.Rows) - 1 - y
+	}
+	return buffer[y*pitch : (y+1)*pitch]
+}
+
+// coverage returns 8 bit coverage values for a grayscale bitmap, and the stride of its rows.
+func (bm Bitmap) coverage() ([]byte, int, error) {
+	width := int(bm.Width)
+	rows := int(bm.Rows)
+
+	var bitsPerPixel int
+	switch bm.PixelMode {
+	case PIXEL_MODE_MONO:
+		bitsPerPixel = 1
+	case PIXEL_MODE_GRAY2:
+		bitsPerPixel = 2
+	case PIXEL_MODE_GRAY4:
+		bitsPerPixel = 4
+	case PIXEL_MODE_GRAY:
+		if bm.Pitch >= 0 && bm.NumGrays == 256 {
+			return bm.Buffer(), int(bm.Pitch), nil
+		}
+		bitsPerPixel = 8
+	default:
+		return nil, 0, newError(Err_Invalid_Argument, "bitmap pixel mode %d is not a grayscale mode", bm.PixelMode)
+	}
+
+	maxLevel := 1<<bitsPerPixel - 1
+	if bm.PixelMode == PIXEL_MODE_GRAY && bm.NumGrays > 1 {
+		maxLevel = int(bm.NumGrays) - 1
+	}
+	pixelsPerByte := 8 / bitsPerPixel
+	mask := byte(1<<bitsPerPixel - 1)
+
+	buffer := bm.Buffer()
+	pix := make([]byte, width*rows)
+	for y := range rows {
+		src := bm.row(buffer, y)
+		dst := pix[y*width : (y+1)*width]
+		for x := range width {
+			// Pixels are packed starting from the most significant bits of each byte.
+			shift := 8 - bitsPerPixel*(x%pixelsPerByte+1)
+			level := int(src[x/pixelsPerByte] >> shift & mask)
+			dst[x] = byte(min(level, maxLevel) * 0xff / maxLevel)
+		}
+	}
+	return pix, width, nil
+}
+
+// eachBGRA calls a function for each pixel of a PIXEL_MODE_BGRA bitmap.
+func (bm Bitmap) eachBGRA(f func(x, y int, b, g, r, a byte)) {
+	buffer := bm.Buffer()
+	for y := range int(bm.Rows) {
+		src := bm.row(buffer, y)
+		for x := range int(bm.Width) {
+			f(x, y, src[x*4+0], src[x*4+1], src[x*4+2], src[x*4+3])
+		}
+	}
+}
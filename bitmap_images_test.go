@@ -0,0 +1,119 @@
+package freetype
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func testBitmap(mode PixelMode, width, rows, pitch int, buffer []byte) Bitmap {
+	return Bitmap{
+		Rows:      uint32(rows),
+		Width:     uint32(width),
+		Pitch:     int32(pitch),
+		buffer:    unsafe.Pointer(&buffer[0]),
+		NumGrays:  256,
+		PixelMode: mode,
+	}
+}
+
+func TestBitmapAlphaSharesGrayBuffer(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	err := face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadGlyph(face.GetCharIndex('A'), LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+
+	bitmap := face.Rec().Glyph.Rec().Bitmap
+	img, err := bitmap.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, int(bitmap.Width), int(bitmap.Rows)), img.Bounds())
+	assert.Equal(t, &bitmap.Buffer()[0], &img.Pix[0])
+	assert.Equal(t, expectedBitmapForA, img.Pix)
+
+	dst := image.NewRGBA(img.Bounds())
+	draw.DrawMask(dst, dst.Bounds(), image.Black, image.Point{}, img, image.Point{}, draw.Over)
+	assert.Equal(t, color.RGBA{A: 0xff}, dst.RGBAAt(8, 0))
+	assert.Equal(t, color.RGBA{}, dst.RGBAAt(0, 0))
+}
+
+func TestBitmapAlphaMono(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	err := face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadGlyph(face.GetCharIndex('A'), LOAD_TARGET_MONO)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_MONO)
+	assert.Nil(t, err)
+
+	bitmap := face.Rec().Glyph.Rec().Bitmap
+	assert.Equal(t, PIXEL_MODE_MONO, bitmap.PixelMode)
+	img, err := bitmap.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, int(bitmap.Width), img.Stride)
+	for _, a := range img.Pix {
+		assert.Contains(t, []byte{0x00, 0xff}, a)
+	}
+	assert.Contains(t, img.Pix, byte(0xff))
+}
+
+func TestBitmapAlphaPacked(t *testing.T) {
+	mono := testBitmap(PIXEL_MODE_MONO, 3, 2, 1, []byte{0b10100000, 0b01000000})
+	img, err := mono.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xff, 0x00, 0xff, 0x00, 0xff, 0x00}, img.Pix)
+
+	gray2 := testBitmap(PIXEL_MODE_GRAY2, 4, 1, 1, []byte{0b00011011})
+	img, err = gray2.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x00, 0x55, 0xaa, 0xff}, img.Pix)
+
+	gray4 := testBitmap(PIXEL_MODE_GRAY4, 3, 1, 2, []byte{0x0f, 0x80})
+	img, err = gray4.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0x88}, img.Pix)
+}
+
+func TestBitmapAlphaNegativePitch(t *testing.T) {
+	buffer := []byte{1, 2, 0, 3, 4, 0}
+	bitmap := testBitmap(PIXEL_MODE_GRAY, 2, 2, -3, buffer)
+	img, err := bitmap.Alpha()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{3, 4, 1, 2}, img.Pix)
+	assert.NotEqual(t, &buffer[0], &img.Pix[0])
+}
+
+func TestBitmapRGBA(t *testing.T) {
+	buffer := []byte{0x10, 0x20, 0x40, 0x80, 0, 0, 0, 0}
+	bitmap := testBitmap(PIXEL_MODE_BGRA, 1, 2, 4, buffer)
+
+	rgba, err := bitmap.RGBA()
+	assert.Nil(t, err)
+	assert.Equal(t, color.RGBA{R: 0x40, G: 0x20, B: 0x10, A: 0x80}, rgba.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{}, rgba.RGBAAt(0, 1))
+
+	nrgba, err := bitmap.NRGBA()
+	assert.Nil(t, err)
+	assert.Equal(t, color.NRGBA{R: 0x7f, G: 0x3f, B: 0x1f, A: 0x80}, nrgba.NRGBAAt(0, 0))
+
+	img, err := bitmap.Image()
+	assert.Nil(t, err)
+	assert.IsType(t, &image.RGBA{}, img)
+
+	_, err = bitmap.Alpha()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = testBitmap(PIXEL_MODE_GRAY, 1, 1, 1, []byte{0}).RGBA()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
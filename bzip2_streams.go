@@ -0,0 +1,56 @@
+package freetype
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"io"
+	"os"
+)
+
+// Using bzip2-compressed font files.
+
+// bzip2Magic is the header that identifies bzip2-compressed data.
+var bzip2Magic = []byte{'B', 'Z', 'h'}
+
+/*
+decompressBzip2 returns args that open the decompressed content of bzip2-compressed font data,
+or the args unchanged if their data is not compressed with bzip2.
+
+libfreetype is built without bzip2 support, and its FT_Stream_OpenBzip2 always fails,
+so the data is decompressed with compress/bzip2, and opened as memory that the face owns.
+
+https://freetype.org/freetype2/docs/reference/ft2-bzip2.html#ft_stream_openbzip2
+*/
+func (args OpenArgs) decompressBzip2() (OpenArgs, error) {
+	header, err := args.header()
+	if err != nil || !bytes.HasPrefix(header, bzip2Magic) {
+		return args, err
+	}
+
+	var compressed io.Reader
+	if args.Flags&OPEN_MEMORY != 0 {
+		compressed = bytes.NewReader(header)
+	} else {
+		file, err := os.Open(args.Pathname)
+		if err != nil {
+			return args, err
+		}
+		defer file.Close()
+		compressed = file
+	}
+
+	data, err := io.ReadAll(bzip2.NewReader(compressed))
+	if err != nil {
+		return args, newError(Err_Invalid_Stream_Read, "failed to decompress bzip2 font data : %v", err)
+	}
+	return args.withMemory(data), nil
+}
+
+// withMemory returns args that open decompressed font data as memory, instead of their file or memory.
+func (args OpenArgs) withMemory(data []byte) OpenArgs {
+	args.Flags = args.Flags&^OPEN_PATHNAME | OPEN_MEMORY
+	args.Pathname = ""
+	args.MemoryBase = data
+	args.shared = nil
+	return args
+}
@@ -1,6 +1,9 @@
 package freetype
 
 import (
+	"iter"
+	"runtime"
+
 	"modernc.org/libfreetype"
 )
 
@@ -13,11 +16,83 @@ https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_char
 */
 type CharMap uintptr
 
-// Rec returns a pointer to the FaceRec that is referenced by the Face.
+// Rec returns a pointer to the CharMapRec that is referenced by the CharMap.
 func (charmap CharMap) Rec() *CharMapRec {
 	return fromUintptr[CharMapRec](uintptr(charmap))
 }
 
+// Platform returns the platform ID of the charmap, such as PLATFORM_MICROSOFT,
+// or 0 for a zero CharMap.
+func (charmap CharMap) Platform() TT_Platform {
+	rec := charmap.Rec()
+	if rec == nil {
+		return 0
+	}
+	return TT_Platform(rec.PlatformID)
+}
+
+// EncodingID returns the platform specific encoding ID of the charmap, such as MS_ID_SYMBOL_CS or MAC_ID_ROMAN,
+// or 0 for a zero CharMap.
+func (charmap CharMap) EncodingID() UShort {
+	rec := charmap.Rec()
+	if rec == nil {
+		return 0
+	}
+	return rec.EncodingID
+}
+
+// Encoding returns FreeType's encoding tag for the charmap, such as ENCODING_UNICODE,
+// or ENCODING_NONE for a zero CharMap.
+func (charmap CharMap) Encoding() Encoding {
+	rec := charmap.Rec()
+	if rec == nil {
+		return ENCODING_NONE
+	}
+	return rec.Encoding
+}
+
+/*
+CharMapInfo is a charmap of a face, as returned by Face.CharMaps.
+It has the accessors of CharMap, and those that need the face to call into FreeType.
+*/
+type CharMapInfo struct {
+	CharMap
+	face Face
+}
+
+// Format returns the format of the charmap's SFNT ‘cmap’ subtable, such as 4 or 12,
+// or -1 if it is not the charmap of an SFNT face. See GetCMapFormat.
+func (info CharMapInfo) Format() Long {
+	if info.Rec() == nil {
+		return -1
+	}
+	return info.face.GetCMapFormat(info.CharMap)
+}
+
+// Language returns the Macintosh language ID of the charmap's SFNT ‘cmap’ subtable,
+// which is 0 for subtables of other platforms and for subtables that are not language specific.
+// See GetCMapLanguageID.
+func (info CharMapInfo) Language() ULong {
+	if info.Rec() == nil {
+		return 0
+	}
+	return info.face.GetCMapLanguageID(info.CharMap)
+}
+
+// CharMaps returns the charmaps of the face, with accessors for their formats and languages.
+func (face Face) CharMaps() []CharMapInfo {
+	rec := face.Rec()
+	if rec == nil {
+		return nil
+	}
+	charmaps := rec.Charmaps()
+	infos := make([]CharMapInfo, len(charmaps))
+	for i, charmap := range charmaps {
+		infos[i] = CharMapInfo{CharMap: charmap, face: face}
+	}
+	return infos
+}
+
 func init() {
 	assertSameSize(CharMapRec{}, libfreetype.TFT_CharMapRec{})
 }
@@ -42,16 +117,16 @@ https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_enco
 type Encoding libfreetype.TFT_Encoding
 
 var (
-	ENCODING_NONE = Encoding(imageTag(0, 0, 0, 0))
+	ENCODING_NONE = Encoding(0)
 
-	ENCODING_MS_SYMBOL = Encoding(imageTag('s', 'y', 'm', 'b'))
-	ENCODING_UNICODE   = Encoding(imageTag('u', 'n', 'i', 'c'))
+	ENCODING_MS_SYMBOL = Encoding(MakeTag("symb"))
+	ENCODING_UNICODE   = Encoding(MakeTag("unic"))
 
-	ENCODING_SJIS    = Encoding(imageTag('s', 'j', 'i', 's'))
-	ENCODING_PRC     = Encoding(imageTag('g', 'b', ' ', ' '))
-	ENCODING_BIG5    = Encoding(imageTag('b', 'i', 'g', '5'))
-	ENCODING_WANSUNG = Encoding(imageTag('w', 'a', 'n', 's'))
-	ENCODING_JOHAB   = Encoding(imageTag('j', 'o', 'h', 'a'))
+	ENCODING_SJIS    = Encoding(MakeTag("sjis"))
+	ENCODING_PRC     = Encoding(MakeTag("gb"))
+	ENCODING_BIG5    = Encoding(MakeTag("big5"))
+	ENCODING_WANSUNG = Encoding(MakeTag("wans"))
+	ENCODING_JOHAB   = Encoding(MakeTag("joha"))
 
 	/* for backward compatibility */
 	ENCODING_GB2312     = ENCODING_PRC
@@ -61,19 +136,19 @@ var (
 	ENCODING_MS_WANSUNG = ENCODING_WANSUNG
 	ENCODING_MS_JOHAB   = ENCODING_JOHAB
 
-	ENCODING_ADOBE_STANDARD = Encoding(imageTag('A', 'D', 'O', 'B'))
-	ENCODING_ADOBE_EXPERT   = Encoding(imageTag('A', 'D', 'B', 'E'))
-	ENCODING_ADOBE_CUSTOM   = Encoding(imageTag('A', 'D', 'B', 'C'))
-	ENCODING_ADOBE_LATIN_1  = Encoding(imageTag('l', 'a', 't', '1'))
+	ENCODING_ADOBE_STANDARD = Encoding(MakeTag("ADOB"))
+	ENCODING_ADOBE_EXPERT   = Encoding(MakeTag("ADBE"))
+	ENCODING_ADOBE_CUSTOM   = Encoding(MakeTag("ADBC"))
+	ENCODING_ADOBE_LATIN_1  = Encoding(MakeTag("lat1"))
 
-	ENCODING_OLD_LATIN_2 = Encoding(imageTag('l', 'a', 't', '2'))
+	ENCODING_OLD_LATIN_2 = Encoding(MakeTag("lat2"))
 
-	ENCODING_APPLE_ROMAN = Encoding(imageTag('a', 'r', 'm', 'n'))
+	ENCODING_APPLE_ROMAN = Encoding(MakeTag("armn"))
 )
 
-// String returns a formatted representation of the 4 bytes of the Encoding tag.
+// String returns the 4 characters of the Encoding tag, such as "unic".
 func (encoding Encoding) String() string {
-	return formatTag(uint32(encoding))
+	return Tag(encoding).String()
 }
 
 // // FT_ENC_TAG
@@ -84,26 +159,52 @@ SelectCharmap selects a given charmap by its encoding tag.
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_select_charmap
 */
 func (face Face) SelectCharmap(encoding Encoding) error {
-	err := libfreetype.XFT_Select_Charmap(face.tls, face.face, libfreetype.TFT_Encoding(encoding))
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Select_Charmap(face.tls, face.handle(), libfreetype.TFT_Encoding(encoding))
 	return newError(err, "failed to select charmap for encoding %s (0x%04x)", encoding, int32(encoding))
 }
 
 /*
 SetCharmap selects a given charmap for character code to glyph index mapping.
 
+FreeType only accepts the charmaps of the face, so use SelectCharmapBy to select one of them
+by its platform and encoding.
+
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_set_charmap
 */
 func (face Face) SetCharmap(rec CharMapRec) error {
-	err := libfreetype.XFT_Set_Charmap(face.tls, face.face, toUintptr(&rec))
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Set_Charmap(face.tls, face.handle(), toUintptr(&rec))
 	return newError(err, "failed to set charmap")
 }
 
+/*
+SelectCharmapBy selects the face's charmap with a platform and a platform specific encoding ID,
+such as PLATFORM_MICROSOFT and MS_ID_SYMBOL_CS, or PLATFORM_MACINTOSH and MAC_ID_ROMAN.
+It returns an error wrapping ErrInvalidCharMapHandle if the face has no such charmap.
+*/
+func (face Face) SelectCharmapBy(platform TT_Platform, encodingID UShort) error {
+	defer runtime.KeepAlive(face.owner)
+	rec := face.Rec()
+	if rec == nil {
+		return newError(Err_Invalid_Face_Handle, "failed to select charmap")
+	}
+	for _, charmap := range rec.Charmaps() {
+		if charmap.Platform() == platform && charmap.EncodingID() == encodingID {
+			err := libfreetype.XFT_Set_Charmap(face.tls, face.handle(), libfreetype.TFT_CharMap(charmap))
+			return newError(err, "failed to set charmap for platform %d and encoding %d", platform, encodingID)
+		}
+	}
+	return newError(Err_Invalid_CharMap_Handle, "the face has no charmap for platform %d and encoding %d", platform, encodingID)
+}
+
 /*
 GetCharmapIndex retrieves the index of a given charmap.
 
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_get_charmap_index
 */
 func (face Face) GetCharmapIndex(charmap CharMap) Int {
+	defer runtime.KeepAlive(face.owner)
 	return libfreetype.XFT_Get_Charmap_Index(face.tls, libfreetype.TFT_CharMap(charmap))
 }
 
@@ -114,7 +215,26 @@ This function uses the currently selected charmap to do the mapping.
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_get_char_index
 */
 func (face Face) GetCharIndex(charcode rune) UInt {
-	return libfreetype.XFT_Get_Char_Index(face.tls, face.face, libfreetype.TFT_ULong(charcode))
+	defer runtime.KeepAlive(face.owner)
+	return libfreetype.XFT_Get_Char_Index(face.tls, face.handle(), libfreetype.TFT_ULong(charcode))
+}
+
+/*
+GetRuneIndex returns the glyph index of a Unicode character,
+which is converted to the encoding of the current charmap with EncodeChar, as for a Shift JIS or Mac Roman charmap.
+It returns 0 if the encoding does not have the character, or if the face has no current charmap.
+*/
+func (face Face) GetRuneIndex(r rune) UInt {
+	defer runtime.KeepAlive(face.owner)
+	rec := face.Rec()
+	if rec == nil || rec.Charmap == 0 {
+		return 0
+	}
+	code, ok := EncodeChar(rec.Charmap.Encoding(), r)
+	if !ok {
+		return 0
+	}
+	return libfreetype.XFT_Get_Char_Index(face.tls, face.handle(), code)
 }
 
 /*
@@ -123,8 +243,9 @@ GetFirstChar returns the first character code in the current charmap of a given
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_get_first_char
 */
 func (face Face) GetFirstChar() (ULong, UInt) {
+	defer runtime.KeepAlive(face.owner)
 	var gindex UInt
-	charCode := libfreetype.XFT_Get_First_Char(face.tls, face.face, toUintptr(&gindex))
+	charCode := libfreetype.XFT_Get_First_Char(face.tls, face.handle(), toUintptr(&gindex))
 	return charCode, gindex
 }
 
@@ -134,17 +255,42 @@ GetNextChar returns the next character code in the current charmap of a given fa
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_get_next_char
 */
 func (face Face) GetNextChar(charCode ULong) (ULong, UInt) {
+	defer runtime.KeepAlive(face.owner)
 	var gindex UInt
-	nextCharCode := libfreetype.XFT_Get_Next_Char(face.tls, face.face, charCode, toUintptr(&gindex))
+	nextCharCode := libfreetype.XFT_Get_Next_Char(face.tls, face.handle(), charCode, toUintptr(&gindex))
 	return nextCharCode, gindex
 }
 
+/*
+Chars returns an iterator over the character codes of the current charmap of a face, in increasing order,
+and their glyph indexes. It iterates with GetFirstChar and GetNextChar.
+*/
+func (face Face) Chars() iter.Seq2[rune, UInt] {
+	return func(yield func(rune, UInt) bool) {
+		for code, index := face.GetFirstChar(); index != 0; code, index = face.GetNextChar(code) {
+			if !yield(rune(code), index) {
+				return
+			}
+		}
+	}
+}
+
+// Coverage returns the characters of the current charmap of a face, which are those that it has glyphs for.
+func (face Face) Coverage() Coverage {
+	var coverage Coverage
+	for r := range face.Chars() {
+		coverage = coverage.add(Range{Lo: r, Hi: r})
+	}
+	return coverage
+}
+
 /*
 LoadChar loads a glyph into the glyph slot of a face object, accessed by its character code.
 
 https://freetype.org/freetype2/docs/reference/ft2-character_mapping.html#ft_load_char
 */
 func (face Face) LoadChar(charCode rune, loadFlags LoadFlag) error {
-	err := libfreetype.XFT_Load_Char(face.tls, face.face, ULong(charCode), loadFlags)
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Load_Char(face.tls, face.handle(), ULong(charCode), loadFlags)
 	return newError(err, "failed to load char '%s' (0x%04x) with flags 0x%04x", string(charCode), charCode, loadFlags)
 }
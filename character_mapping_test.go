@@ -85,3 +85,130 @@ func TestFaceLoadChar(t *testing.T) {
 	assert.Nil(t, err)
 	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
 }
+
+func TestFaceChars(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+
+	count := 0
+	previous := rune(-1)
+	for r, index := range face.Chars() {
+		assert.Greater(t, r, previous)
+		assert.Equal(t, face.GetCharIndex(r), index)
+		previous = r
+		count++
+	}
+	assert.Equal(t, face.Coverage().Len(), count)
+
+	var first []rune
+	for r := range face.Chars() {
+		first = append(first, r)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []rune{' ', '!', '"'}, first)
+}
+
+func TestFaceCoverage(t *testing.T) {
+	lib, _ := Init()
+	sans, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	roboto, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+
+	coverage := sans.Coverage()
+	assert.True(t, coverage.Has('A'))
+	assert.True(t, coverage.Has('א'))
+	assert.False(t, coverage.Has('日'))
+	assert.Equal(t, []rune{'日', '本'}, coverage.Missing("A日本א"))
+
+	// DejaVu Sans has Hebrew, and Roboto does not.
+	assert.True(t, sans.Coverage().Difference(roboto.Coverage()).Has('א'))
+	assert.True(t, sans.Coverage().Intersection(roboto.Coverage()).Has('A'))
+}
+
+func TestCharMapAccessors(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+
+	charmap := face.Rec().Charmaps()[2]
+	assert.Equal(t, PLATFORM_MACINTOSH, charmap.Platform())
+	assert.Equal(t, MAC_ID_ROMAN, charmap.EncodingID())
+	assert.Equal(t, ENCODING_APPLE_ROMAN, charmap.Encoding())
+	assert.Equal(t, Long(6), face.GetCMapFormat(charmap))
+	assert.Equal(t, ULong(0), face.GetCMapLanguageID(charmap))
+
+	charmap = face.Rec().Charmaps()[4]
+	assert.Equal(t, PLATFORM_MICROSOFT, charmap.Platform())
+	assert.Equal(t, MS_ID_UCS_4, charmap.EncodingID())
+	assert.Equal(t, Long(12), face.GetCMapFormat(charmap))
+
+	charmap = CharMap(0)
+	assert.Equal(t, TT_Platform(0), charmap.Platform())
+	assert.Equal(t, UShort(0), charmap.EncodingID())
+	assert.Equal(t, ENCODING_NONE, charmap.Encoding())
+}
+
+func TestFaceCharMaps(t *testing.T) {
+	lib, _ := Init()
+	defer lib.Done()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+
+	charmaps := face.CharMaps()
+	assert.Len(t, charmaps, len(face.Rec().Charmaps()))
+
+	assert.Equal(t, PLATFORM_MACINTOSH, charmaps[2].Platform())
+	assert.Equal(t, ENCODING_APPLE_ROMAN, charmaps[2].Encoding())
+	assert.Equal(t, Long(6), charmaps[2].Format())
+	assert.Equal(t, ULong(0), charmaps[2].Language())
+
+	assert.Equal(t, MS_ID_UCS_4, charmaps[4].EncodingID())
+	assert.Equal(t, Long(12), charmaps[4].Format())
+
+	assert.Equal(t, Long(-1), CharMapInfo{}.Format())
+	assert.Equal(t, ULong(0), CharMapInfo{}.Language())
+}
+
+func TestFaceSelectCharmapBy(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	unicodeIndex := face.GetCharIndex('é')
+
+	err := face.SelectCharmapBy(PLATFORM_MACINTOSH, MAC_ID_ROMAN)
+	assert.NoError(t, err)
+	assert.Equal(t, ENCODING_APPLE_ROMAN, face.Rec().Charmap.Encoding())
+	// 'é' is 0x8E in Mac Roman.
+	assert.Equal(t, unicodeIndex, face.GetCharIndex(0x8E))
+	assert.Equal(t, unicodeIndex, face.GetRuneIndex('é'))
+	assert.Equal(t, UInt(0), face.GetRuneIndex('א'))
+
+	err = face.SelectCharmapBy(PLATFORM_MICROSOFT, MS_ID_SYMBOL_CS)
+	assert.ErrorIs(t, err, ErrInvalidCharMapHandle)
+	assert.Equal(t, ENCODING_APPLE_ROMAN, face.Rec().Charmap.Encoding())
+}
+
+func TestEncodeChar(t *testing.T) {
+	tests := []struct {
+		encoding Encoding
+		r        rune
+		code     ULong
+		ok       bool
+	}{
+		{ENCODING_UNICODE, '日', '日', true},
+		{ENCODING_APPLE_ROMAN, 'A', 'A', true},
+		{ENCODING_APPLE_ROMAN, 'é', 0x8E, true},
+		{ENCODING_APPLE_ROMAN, '日', 0, false},
+		{ENCODING_SJIS, 'a', 'a', true},
+		{ENCODING_SJIS, 'あ', 0x82A0, true},
+		{ENCODING_SJIS, '한', 0, false},
+		{ENCODING_BIG5, '中', 0xA4A4, true},
+		{ENCODING_WANSUNG, '한', 0xC7D1, true},
+		{ENCODING_PRC, '中', 0xD6D0, true},
+		{ENCODING_MS_SYMBOL, 'A', 0xF041, true},
+		{ENCODING_JOHAB, '한', 0, false},
+	}
+	for _, test := range tests {
+		code, ok := EncodeChar(test.encoding, test.r)
+		assert.Equal(t, test.ok, ok, "%s %c", test.encoding, test.r)
+		assert.Equal(t, test.code, code, "%s %c", test.encoding, test.r)
+	}
+}
@@ -0,0 +1,122 @@
+package freetype
+
+import (
+	"slices"
+	"sort"
+)
+
+// Sets of the characters that faces have glyphs for.
+
+// Coverage is a set of characters, as sorted ranges that neither overlap nor adjoin.
+type Coverage []Range
+
+// Range is a range of characters, from Lo to Hi inclusive.
+type Range struct {
+	Lo, Hi rune
+}
+
+// CoverageOf returns the set of the characters of a text.
+func CoverageOf(text string) Coverage {
+	runes := []rune(text)
+	slices.Sort(runes)
+	var coverage Coverage
+	for _, r := range runes {
+		coverage = coverage.add(Range{Lo: r, Hi: r})
+	}
+	return coverage
+}
+
+// add appends a range that does not start before the coverage's last range, merging it with that range if they
+// overlap or adjoin.
+func (coverage Coverage) add(r Range) Coverage {
+	if n := len(coverage); n > 0 && r.Lo <= coverage[n-1].Hi+1 {
+		coverage[n-1].Hi = max(coverage[n-1].Hi, r.Hi)
+		return coverage
+	}
+	return append(coverage, r)
+}
+
+// Has reports whether a character is in the coverage.
+func (coverage Coverage) Has(r rune) bool {
+	i := sort.Search(len(coverage), func(i int) bool { return coverage[i].Hi >= r })
+	return i < len(coverage) && coverage[i].Lo <= r
+}
+
+// Len returns the number of characters in the coverage.
+func (coverage Coverage) Len() int {
+	n := 0
+	for _, r := range coverage {
+		n += int(r.Hi-r.Lo) + 1
+	}
+	return n
+}
+
+// Union returns the characters that are in either coverage.
+func (coverage Coverage) Union(other Coverage) Coverage {
+	var union Coverage
+	i, j := 0, 0
+	for i < len(coverage) || j < len(other) {
+		if j == len(other) || (i < len(coverage) && coverage[i].Lo <= other[j].Lo) {
+			union = union.add(coverage[i])
+			i++
+		} else {
+			union = union.add(other[j])
+			j++
+		}
+	}
+	return union
+}
+
+// Intersection returns the characters that are in both coverages.
+func (coverage Coverage) Intersection(other Coverage) Coverage {
+	var intersection Coverage
+	i, j := 0, 0
+	for i < len(coverage) && j < len(other) {
+		lo, hi := max(coverage[i].Lo, other[j].Lo), min(coverage[i].Hi, other[j].Hi)
+		if lo <= hi {
+			intersection = append(intersection, Range{Lo: lo, Hi: hi})
+		}
+		if coverage[i].Hi < other[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intersection
+}
+
+// Difference returns the characters that are in the coverage, but not in the other coverage.
+func (coverage Coverage) Difference(other Coverage) Coverage {
+	var difference Coverage
+	j := 0
+	for _, r := range coverage {
+		lo := r.Lo
+		for j < len(other) && other[j].Hi < lo {
+			j++
+		}
+		for k := j; k < len(other) && other[k].Lo <= r.Hi; k++ {
+			if other[k].Lo > lo {
+				difference = append(difference, Range{Lo: lo, Hi: other[k].Lo - 1})
+			}
+			lo = other[k].Hi + 1
+		}
+		if lo <= r.Hi {
+			difference = append(difference, Range{Lo: lo, Hi: r.Hi})
+		}
+	}
+	return difference
+}
+
+/*
+Missing returns the characters of a text that are not in the coverage, in the order of their first occurrences.
+Each character is returned once.
+*/
+func (coverage Coverage) Missing(text string) []rune {
+	var missing []rune
+	for _, r := range text {
+		if !coverage.Has(r) && !slices.Contains(missing, r) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,43 @@
+package freetype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageOf(t *testing.T) {
+	assert.Equal(t, Coverage{{Lo: 'a', Hi: 'c'}, {Lo: 'x', Hi: 'x'}}, CoverageOf("xcabba"))
+	assert.Nil(t, CoverageOf(""))
+}
+
+func TestCoverageHas(t *testing.T) {
+	coverage := Coverage{{Lo: 'a', Hi: 'c'}, {Lo: 'x', Hi: 'z'}}
+	assert.True(t, coverage.Has('a'))
+	assert.True(t, coverage.Has('y'))
+	assert.False(t, coverage.Has('d'))
+	assert.False(t, coverage.Has('~'))
+	assert.Equal(t, 6, coverage.Len())
+}
+
+func TestCoverageSetOperations(t *testing.T) {
+	a := Coverage{{Lo: 10, Hi: 20}, {Lo: 30, Hi: 40}}
+	b := Coverage{{Lo: 15, Hi: 32}, {Lo: 41, Hi: 45}, {Lo: 50, Hi: 50}}
+
+	assert.Equal(t, Coverage{{Lo: 10, Hi: 45}, {Lo: 50, Hi: 50}}, a.Union(b))
+	assert.Equal(t, a.Union(b), b.Union(a))
+	assert.Equal(t, Coverage{{Lo: 15, Hi: 20}, {Lo: 30, Hi: 32}}, a.Intersection(b))
+	assert.Equal(t, a.Intersection(b), b.Intersection(a))
+	assert.Equal(t, Coverage{{Lo: 10, Hi: 14}, {Lo: 33, Hi: 40}}, a.Difference(b))
+	assert.Equal(t, Coverage{{Lo: 21, Hi: 29}, {Lo: 41, Hi: 45}, {Lo: 50, Hi: 50}}, b.Difference(a))
+	assert.Equal(t, a, a.Difference(nil))
+	assert.Nil(t, a.Difference(a))
+	assert.Equal(t, a, a.Union(nil))
+	assert.Nil(t, a.Intersection(nil))
+}
+
+func TestCoverageMissing(t *testing.T) {
+	coverage := CoverageOf("abc ")
+	assert.Equal(t, []rune{'d', '€'}, coverage.Missing("a bad €d"))
+	assert.Nil(t, coverage.Missing("cab"))
+}
@@ -0,0 +1,179 @@
+package freetype
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/pekim/freetype/bidi"
+)
+
+// Drawing of text in to images from the standard library's image package.
+
+// DrawOptions modify the behaviour of DrawString.
+// The zero value is suitable for most purposes.
+type DrawOptions struct {
+	// LoadFlags are passed to LoadGlyph for each glyph.
+	LoadFlags LoadFlag
+	// RenderMode is passed to RenderGlyph for each glyph.
+	RenderMode RenderMode
+	// Op is the Porter-Duff composition operator used to draw glyphs.
+	// The zero value is draw.Over.
+	Op draw.Op
+	// NoKerning disables the kerning of pairs of glyphs.
+	NoKerning bool
+	// Direction is the base direction of the text, used to reorder bidirectional text.
+	// The zero value, bidi.Auto, takes the direction from the text's first strong character.
+	Direction bidi.Direction
+}
+
+/*
+DrawString draws text in to an image, using a face at its current size.
+
+The origin is the position of the start of the baseline in dst's coordinate space,
+in 26.6 fixed-point format (1/64 of a pixel).
+Fractional origins are supported, and each glyph is rendered at its exact subpixel position.
+
+The glyphs' coverage is used as a mask to draw src,
+which is aligned with dst (as for an image.Uniform, src's origin is dst's origin).
+Color glyphs, such as emoji, are drawn with their own colors and src is ignored.
+Drawing is clipped to dst's bounds.
+
+Bidirectional text is reordered with the Unicode Bidirectional Algorithm before its glyphs are looked up.
+Characters such as brackets in right-to-left text are mirrored, where the face has a glyph for the mirrored character.
+The reordered glyphs are drawn from left to right, starting at the origin.
+
+Glyphs are positioned using their advances, adjusted with kerning and hinting corrections
+(GlyphSlotRec LsbDelta and RsbDelta).
+Any transformation set with SetTransform is applied to the glyphs, and it is restored before returning.
+
+The position of the origin following the last glyph is returned.
+This is suitable as the origin for a subsequent call to DrawString, to continue the text.
+*/
+func DrawString(dst draw.Image, face Face, text string, origin Vector, src image.Image, opts DrawOptions) (Vector, error) {
+	matrix, delta := face.transform()
+	defer face.SetTransform(&matrix, &delta)
+
+	kerning := !opts.NoKerning && face.HasKerning()
+	pen := origin
+	var previous UInt
+	var previousRsbDelta Pos
+
+	for _, glyphIndex := range face.visualGlyphs(text, opts.Direction) {
+		if kerning && previous != 0 && glyphIndex != 0 {
+			kern, err := face.GetKerning(previous, glyphIndex, KERNING_DEFAULT)
+			if err != nil {
+				return pen, err
+			}
+			pen.X += kern.X
+		}
+
+		face.setSubpixelTransform(matrix, delta, pen)
+
+		if err := face.LoadGlyph(glyphIndex, opts.LoadFlags); err != nil {
+			return pen, err
+		}
+		glyph := face.Rec().Glyph.Rec()
+
+		// Hinting corrections are always whole pixels, so the fractional part of the pen is unaffected.
+		if previousRsbDelta-glyph.LsbDelta > 32 {
+			pen.X -= 64
+		} else if previousRsbDelta-glyph.LsbDelta < -31 {
+			pen.X += 64
+		}
+		previousRsbDelta = glyph.RsbDelta
+		previous = glyphIndex
+
+		if err := drawGlyph(dst, face, image.Pt(int(pen.X>>6), int(pen.Y>>6)), src, opts); err != nil {
+			return pen, err
+		}
+
+		pen.X += glyph.Advance.X
+		pen.Y -= glyph.Advance.Y
+	}
+
+	return pen, nil
+}
+
+// visualGlyphs returns the glyph indexes of the characters of text, in visual order.
+// Characters displayed right-to-left use the glyph of their mirrored character, if the face has one.
+func (face Face) visualGlyphs(text string, direction bidi.Direction) []UInt {
+	analysed := bidi.Analyze(text, direction)
+	runes := analysed.Runes()
+	levels := analysed.LineLevels(0, len(runes))
+
+	glyphIndexes := make([]UInt, 0, len(runes))
+	for _, i := range bidi.VisualOrder(levels) {
+		glyphIndexes = append(glyphIndexes, face.mirroredCharIndex(runes[i], levels[i]))
+	}
+	return glyphIndexes
+}
+
+// mirroredCharIndex returns the glyph index of a character at an embedding level.
+// At right-to-left levels the glyph of the character's mirror image is used, if the face has one.
+func (face Face) mirroredCharIndex(r rune, level bidi.Level) UInt {
+	if level.RightToLeft() {
+		if mirror, ok := bidi.Mirror(r); ok {
+			if glyphIndex := face.GetCharIndex(mirror); glyphIndex != 0 {
+				return glyphIndex
+			}
+		}
+	}
+	return face.GetCharIndex(r)
+}
+
+/*
+DrawGlyph draws a single glyph in to an image.
+
+The origin is the position of the glyph's origin in dst's coordinate space,
+in 26.6 fixed-point format (1/64 of a pixel).
+The glyph is loaded, rendered, and drawn in the same way as each of the glyphs drawn by DrawString.
+*/
+func DrawGlyph(dst draw.Image, face Face, glyphIndex UInt, origin Vector, src image.Image, opts DrawOptions) error {
+	matrix, delta := face.transform()
+	defer face.SetTransform(&matrix, &delta)
+
+	face.setSubpixelTransform(matrix, delta, origin)
+	if err := face.LoadGlyph(glyphIndex, opts.LoadFlags); err != nil {
+		return err
+	}
+	return drawGlyph(dst, face, image.Pt(int(origin.X>>6), int(origin.Y>>6)), src, opts)
+}
+
+// setSubpixelTransform sets a transform, with the fractional part of a pen position added to its delta.
+//
+// Whole pixels are used to position a glyph's bitmap, and the fractional part is applied to the glyph's outline.
+// The y axis of FreeType's coordinates points up, whereas the image's points down.
+func (face Face) setSubpixelTransform(matrix Matrix, delta Vector, pen Vector) {
+	penDelta := Vector{X: delta.X + pen.X&63, Y: delta.Y - pen.Y&63}
+	face.SetTransform(&matrix, &penDelta)
+}
+
+// drawGlyph renders the glyph in a face's glyph slot, and draws it at a position in dst.
+func drawGlyph(dst draw.Image, face Face, position image.Point, src image.Image, opts DrawOptions) error {
+	glyph := face.Rec().Glyph.Rec()
+	if glyph.Format != GLYPH_FORMAT_BITMAP {
+		if err := face.RenderGlyph(opts.RenderMode); err != nil {
+			return err
+		}
+	}
+	if glyph.Bitmap.Width == 0 || glyph.Bitmap.Rows == 0 {
+		return nil
+	}
+
+	img, err := glyph.Bitmap.Image()
+	if err != nil {
+		return err
+	}
+	offset := position.Add(image.Pt(int(glyph.BitmapLeft), -int(glyph.BitmapTop)))
+	target := img.Bounds().Add(offset).Intersect(dst.Bounds())
+	if target.Empty() {
+		return nil
+	}
+
+	if glyph.Bitmap.PixelMode == PIXEL_MODE_BGRA {
+		draw.Draw(dst, target, img, target.Min.Sub(offset), opts.Op)
+	} else {
+		draw.DrawMask(dst, target, src, target.Min, img, target.Min.Sub(offset), opts.Op)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package freetype
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/bidi"
+	"github.com/pekim/freetype/internal/font"
+)
+
+func newDrawStringFace(t *testing.T) Face {
+	t.Helper()
+	lib, err := Init()
+	assert.Nil(t, err)
+	face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	return face
+}
+
+func countInk(img *image.Alpha) int {
+	ink := 0
+	for _, a := range img.Pix {
+		if a != 0 {
+			ink++
+		}
+	}
+	return ink
+}
+
+func TestDrawString(t *testing.T) {
+	face := newDrawStringFace(t)
+
+	dst := image.NewRGBA(image.Rect(0, 0, 200, 50))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	pen, err := DrawString(dst, face, "FreeType", Vector{X: 10 * 64, Y: 40 * 64}, image.Black, DrawOptions{})
+	assert.Nil(t, err)
+	assert.Greater(t, pen.X, Pos(100*64))
+	assert.Equal(t, Pos(40*64), pen.Y)
+
+	// The stem of the F.
+	assert.Equal(t, color.RGBA{A: 0xff}, dst.RGBAAt(14, 30))
+	// Above the text.
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, dst.RGBAAt(14, 2))
+}
+
+func TestDrawStringKerning(t *testing.T) {
+	face := newDrawStringFace(t)
+	dst := image.NewAlpha(image.Rect(0, 0, 100, 50))
+
+	kerned, err := DrawString(dst, face, "AV", Vector{Y: 40 * 64}, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+	unkerned, err := DrawString(dst, face, "AV", Vector{Y: 40 * 64}, image.Opaque, DrawOptions{NoKerning: true})
+	assert.Nil(t, err)
+	assert.Less(t, kerned.X, unkerned.X)
+}
+
+func TestDrawStringSubpixel(t *testing.T) {
+	face := newDrawStringFace(t)
+	options := DrawOptions{LoadFlags: LOAD_NO_HINTING}
+
+	whole := image.NewAlpha(image.Rect(0, 0, 50, 50))
+	_, err := DrawString(whole, face, "l", Vector{X: 10 * 64, Y: 40 * 64}, image.Opaque, options)
+	assert.Nil(t, err)
+
+	half := image.NewAlpha(image.Rect(0, 0, 50, 50))
+	pen, err := DrawString(half, face, "l", Vector{X: 10*64 + 32, Y: 40 * 64}, image.Opaque, options)
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, whole.Pix, half.Pix)
+	assert.Equal(t, Pos(32), pen.X&63)
+
+	// The transform is restored.
+	matrix, delta := face.transform()
+	assert.Equal(t, Matrix{XX: 0x10000, YY: 0x10000}, matrix)
+	assert.Equal(t, Vector{}, delta)
+}
+
+func TestDrawStringClipped(t *testing.T) {
+	face := newDrawStringFace(t)
+	dst := image.NewAlpha(image.Rect(10, 10, 30, 30))
+
+	assert.NotPanics(t, func() {
+		_, err := DrawString(dst, face, "Clipped", Vector{X: -20 * 64, Y: 40 * 64}, image.Opaque, DrawOptions{})
+		assert.Nil(t, err)
+		_, err = DrawString(dst, face, "Clipped", Vector{X: 25 * 64, Y: 20 * 64}, image.Opaque, DrawOptions{})
+		assert.Nil(t, err)
+		_, err = DrawString(dst, face, "Clipped", Vector{X: 1000 * 64, Y: -1000 * 64}, image.Opaque, DrawOptions{})
+		assert.Nil(t, err)
+	})
+	assert.Greater(t, countInk(dst), 0)
+}
+
+func TestDrawStringBidi(t *testing.T) {
+	face := newDrawStringFace(t)
+	glyphIndexes := func(text string) []UInt {
+		var indexes []UInt
+		for _, r := range text {
+			indexes = append(indexes, face.GetCharIndex(r))
+		}
+		return indexes
+	}
+
+	assert.Equal(t, glyphIndexes("(ג)בא"), face.visualGlyphs("אב(ג)", bidi.Auto))
+	assert.Equal(t, glyphIndexes("ab גבא"), face.visualGlyphs("ab אבג", bidi.Auto))
+	assert.Equal(t, glyphIndexes("גבא ab"), face.visualGlyphs("ab אבג", bidi.RightToLeft))
+
+	dst := image.NewAlpha(image.Rect(0, 0, 200, 50))
+	text := "ab (אבג) cd"
+	pen, err := DrawString(dst, face, text, Vector{Y: 40 * 64}, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+	extents, err := face.MeasureString(text, DrawOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, extents.Advance.X, pen.X)
+}
+
+func TestDrawGlyph(t *testing.T) {
+	face := newDrawStringFace(t)
+
+	fromString := image.NewAlpha(image.Rect(0, 0, 50, 50))
+	_, err := DrawString(fromString, face, "g", Vector{X: 10*64 + 20, Y: 30 * 64}, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+
+	fromGlyph := image.NewAlpha(image.Rect(0, 0, 50, 50))
+	err = DrawGlyph(fromGlyph, face, face.GetCharIndex('g'), Vector{X: 10*64 + 20, Y: 30 * 64}, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, fromString.Pix, fromGlyph.Pix)
+	assert.Greater(t, countInk(fromGlyph), 0)
+}
@@ -1,8 +1,10 @@
 package freetype
 
 import (
+	"errors"
 	"fmt"
 
+	"modernc.org/libc"
 	"modernc.org/libfreetype"
 )
 
@@ -141,6 +143,139 @@ const (
 	Err_Corrupted_Font_Glyphs         = FTError(0xBA)
 )
 
+// Sentinel errors for each FTError value, other than Err_Ok.
+// They may be used with errors.Is to test for an Error with a specific FTError value.
+//
+//	if errors.Is(err, freetype.ErrInvalidGlyphIndex) {
+//		...
+//	}
+var (
+	ErrCannotOpenResource   = Error{ftError: Err_Cannot_Open_Resource}
+	ErrUnknownFileFormat    = Error{ftError: Err_Unknown_File_Format}
+	ErrInvalidFileFormat    = Error{ftError: Err_Invalid_File_Format}
+	ErrInvalidVersion       = Error{ftError: Err_Invalid_Version}
+	ErrLowerModuleVersion   = Error{ftError: Err_Lower_Module_Version}
+	ErrInvalidArgument      = Error{ftError: Err_Invalid_Argument}
+	ErrUnimplementedFeature = Error{ftError: Err_Unimplemented_Feature}
+	ErrInvalidTable         = Error{ftError: Err_Invalid_Table}
+	ErrInvalidOffset        = Error{ftError: Err_Invalid_Offset}
+	ErrArrayTooLarge        = Error{ftError: Err_Array_Too_Large}
+	ErrMissingModule        = Error{ftError: Err_Missing_Module}
+	ErrMissingProperty      = Error{ftError: Err_Missing_Property}
+
+	/* glyph/character errors */
+
+	ErrInvalidGlyphIndex    = Error{ftError: Err_Invalid_Glyph_Index}
+	ErrInvalidCharacterCode = Error{ftError: Err_Invalid_Character_Code}
+	ErrInvalidGlyphFormat   = Error{ftError: Err_Invalid_Glyph_Format}
+	ErrCannotRenderGlyph    = Error{ftError: Err_Cannot_Render_Glyph}
+	ErrInvalidOutline       = Error{ftError: Err_Invalid_Outline}
+	ErrInvalidComposite     = Error{ftError: Err_Invalid_Composite}
+	ErrTooManyHints         = Error{ftError: Err_Too_Many_Hints}
+	ErrInvalidPixelSize     = Error{ftError: Err_Invalid_Pixel_Size}
+	ErrInvalidSVGDocument   = Error{ftError: Err_Invalid_SVG_Document}
+
+	/* handle errors */
+
+	ErrInvalidHandle        = Error{ftError: Err_Invalid_Handle}
+	ErrInvalidLibraryHandle = Error{ftError: Err_Invalid_Library_Handle}
+	ErrInvalidDriverHandle  = Error{ftError: Err_Invalid_Driver_Handle}
+	ErrInvalidFaceHandle    = Error{ftError: Err_Invalid_Face_Handle}
+	ErrInvalidSizeHandle    = Error{ftError: Err_Invalid_Size_Handle}
+	ErrInvalidSlotHandle    = Error{ftError: Err_Invalid_Slot_Handle}
+	ErrInvalidCharMapHandle = Error{ftError: Err_Invalid_CharMap_Handle}
+	ErrInvalidCacheHandle   = Error{ftError: Err_Invalid_Cache_Handle}
+	ErrInvalidStreamHandle  = Error{ftError: Err_Invalid_Stream_Handle}
+
+	/* driver errors */
+
+	ErrTooManyDrivers    = Error{ftError: Err_Too_Many_Drivers}
+	ErrTooManyExtensions = Error{ftError: Err_Too_Many_Extensions}
+
+	/* memory errors */
+
+	ErrOutOfMemory    = Error{ftError: Err_Out_Of_Memory}
+	ErrUnlistedObject = Error{ftError: Err_Unlisted_Object}
+
+	/* stream errors */
+
+	ErrCannotOpenStream       = Error{ftError: Err_Cannot_Open_Stream}
+	ErrInvalidStreamSeek      = Error{ftError: Err_Invalid_Stream_Seek}
+	ErrInvalidStreamSkip      = Error{ftError: Err_Invalid_Stream_Skip}
+	ErrInvalidStreamRead      = Error{ftError: Err_Invalid_Stream_Read}
+	ErrInvalidStreamOperation = Error{ftError: Err_Invalid_Stream_Operation}
+	ErrInvalidFrameOperation  = Error{ftError: Err_Invalid_Frame_Operation}
+	ErrNestedFrameAccess      = Error{ftError: Err_Nested_Frame_Access}
+	ErrInvalidFrameRead       = Error{ftError: Err_Invalid_Frame_Read}
+
+	/* raster errors */
+
+	ErrRasterUninitialized  = Error{ftError: Err_Raster_Uninitialized}
+	ErrRasterCorrupted      = Error{ftError: Err_Raster_Corrupted}
+	ErrRasterOverflow       = Error{ftError: Err_Raster_Overflow}
+	ErrRasterNegativeHeight = Error{ftError: Err_Raster_Negative_Height}
+
+	/* cache errors */
+
+	ErrTooManyCaches = Error{ftError: Err_Too_Many_Caches}
+
+	/* TrueType and SFNT errors */
+
+	ErrInvalidOpcode          = Error{ftError: Err_Invalid_Opcode}
+	ErrTooFewArguments        = Error{ftError: Err_Too_Few_Arguments}
+	ErrStackOverflow          = Error{ftError: Err_Stack_Overflow}
+	ErrCodeOverflow           = Error{ftError: Err_Code_Overflow}
+	ErrBadArgument            = Error{ftError: Err_Bad_Argument}
+	ErrDivideByZero           = Error{ftError: Err_Divide_By_Zero}
+	ErrInvalidReference       = Error{ftError: Err_Invalid_Reference}
+	ErrDebugOpCode            = Error{ftError: Err_Debug_OpCode}
+	ErrENDFInExecStream       = Error{ftError: Err_ENDF_In_Exec_Stream}
+	ErrNestedDEFS             = Error{ftError: Err_Nested_DEFS}
+	ErrInvalidCodeRange       = Error{ftError: Err_Invalid_CodeRange}
+	ErrExecutionTooLong       = Error{ftError: Err_Execution_Too_Long}
+	ErrTooManyFunctionDefs    = Error{ftError: Err_Too_Many_Function_Defs}
+	ErrTooManyInstructionDefs = Error{ftError: Err_Too_Many_Instruction_Defs}
+	ErrTableMissing           = Error{ftError: Err_Table_Missing}
+	ErrHorizHeaderMissing     = Error{ftError: Err_Horiz_Header_Missing}
+	ErrLocationsMissing       = Error{ftError: Err_Locations_Missing}
+	ErrNameTableMissing       = Error{ftError: Err_Name_Table_Missing}
+	ErrCMapTableMissing       = Error{ftError: Err_CMap_Table_Missing}
+	ErrHmtxTableMissing       = Error{ftError: Err_Hmtx_Table_Missing}
+	ErrPostTableMissing       = Error{ftError: Err_Post_Table_Missing}
+	ErrInvalidHorizMetrics    = Error{ftError: Err_Invalid_Horiz_Metrics}
+	ErrInvalidCharMapFormat   = Error{ftError: Err_Invalid_CharMap_Format}
+	ErrInvalidPPem            = Error{ftError: Err_Invalid_PPem}
+	ErrInvalidVertMetrics     = Error{ftError: Err_Invalid_Vert_Metrics}
+	ErrCouldNotFindContext    = Error{ftError: Err_Could_Not_Find_Context}
+	ErrInvalidPostTableFormat = Error{ftError: Err_Invalid_Post_Table_Format}
+	ErrInvalidPostTable       = Error{ftError: Err_Invalid_Post_Table}
+	ErrDEFInGlyfBytecode      = Error{ftError: Err_DEF_In_Glyf_Bytecode}
+	ErrMissingBitmap          = Error{ftError: Err_Missing_Bitmap}
+	ErrMissingSVGHooks        = Error{ftError: Err_Missing_SVG_Hooks}
+
+	/* CFF, CID, and Type 1 errors */
+
+	ErrSyntaxError        = Error{ftError: Err_Syntax_Error}
+	ErrStackUnderflow     = Error{ftError: Err_Stack_Underflow}
+	ErrIgnore             = Error{ftError: Err_Ignore}
+	ErrNoUnicodeGlyphName = Error{ftError: Err_No_Unicode_Glyph_Name}
+	ErrGlyphTooBig        = Error{ftError: Err_Glyph_Too_Big}
+
+	/* BDF errors */
+
+	ErrMissingStartfontField       = Error{ftError: Err_Missing_Startfont_Field}
+	ErrMissingFontField            = Error{ftError: Err_Missing_Font_Field}
+	ErrMissingSizeField            = Error{ftError: Err_Missing_Size_Field}
+	ErrMissingFontboundingboxField = Error{ftError: Err_Missing_Fontboundingbox_Field}
+	ErrMissingCharsField           = Error{ftError: Err_Missing_Chars_Field}
+	ErrMissingStartcharField       = Error{ftError: Err_Missing_Startchar_Field}
+	ErrMissingEncodingField        = Error{ftError: Err_Missing_Encoding_Field}
+	ErrMissingBbxField             = Error{ftError: Err_Missing_Bbx_Field}
+	ErrBbxTooBig                   = Error{ftError: Err_Bbx_Too_Big}
+	ErrCorruptedFontHeader         = Error{ftError: Err_Corrupted_Font_Header}
+	ErrCorruptedFontGlyphs         = Error{ftError: Err_Corrupted_Font_Glyphs}
+)
+
 var errorsText = map[FTError]string{
 	Err_Ok:                            "no error",
 	Err_Cannot_Open_Resource:          "cannot open resource",
@@ -239,10 +374,117 @@ var errorsText = map[FTError]string{
 	Err_Corrupted_Font_Glyphs:         "Font glyphs corrupted or missing fields",
 }
 
+// ErrorString returns the textual representation of an FTError value.
+//
+// FreeType's FT_Error_String is used if FreeType has been built with error strings,
+// otherwise an equivalent built-in text is returned.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-error_enumerations.html#ft_error_string
+func ErrorString(err FTError) string {
+	if cString := libfreetype.XFT_Error_String(nil, err); cString != 0 {
+		return libc.GoString(cString)
+	}
+	if text, ok := errorsText[err]; ok {
+		return text
+	}
+	return "unknown error"
+}
+
+/*
+ErrorClass is a broad category of FTError values.
+It can be used to map errors to user-facing categories.
+
+An ErrorClass is an error, and an Error unwraps to its class.
+So errors.Is can be used to test for a class.
+
+	if errors.Is(err, freetype.ErrorClassFontFormat) {
+		...
+	}
+*/
+type ErrorClass int
+
+const (
+	// ErrorClassGeneric is the class of generic errors, such as an invalid argument.
+	ErrorClassGeneric = ErrorClass(iota)
+	// ErrorClassGlyph is the class of glyph and character errors.
+	ErrorClassGlyph
+	// ErrorClassHandle is the class of invalid handle errors.
+	ErrorClassHandle
+	// ErrorClassDriver is the class of driver and module errors.
+	ErrorClassDriver
+	// ErrorClassMemory is the class of memory errors.
+	ErrorClassMemory
+	// ErrorClassStream is the class of stream and resource errors.
+	ErrorClassStream
+	// ErrorClassRaster is the class of rasterizer errors.
+	ErrorClassRaster
+	// ErrorClassCache is the class of cache errors.
+	ErrorClassCache
+	// ErrorClassFontFormat is the class of errors for unknown, unsupported, or broken font files.
+	// It includes the TrueType and SFNT, CFF, CID, Type 1, and BDF errors.
+	ErrorClassFontFormat
+)
+
+var errorClassesText = map[ErrorClass]string{
+	ErrorClassGeneric:    "generic error",
+	ErrorClassGlyph:      "glyph error",
+	ErrorClassHandle:     "handle error",
+	ErrorClassDriver:     "driver error",
+	ErrorClassMemory:     "memory error",
+	ErrorClassStream:     "stream error",
+	ErrorClassRaster:     "raster error",
+	ErrorClassCache:      "cache error",
+	ErrorClassFontFormat: "font format error",
+}
+
+func (class ErrorClass) Error() string {
+	return errorClassesText[class]
+}
+
+// String returns the name of the class.
+func (class ErrorClass) String() string {
+	return class.Error()
+}
+
+// ErrorClassOf returns the class of an FTError value.
+func ErrorClassOf(err FTError) ErrorClass {
+	switch err {
+	case Err_Cannot_Open_Resource:
+		return ErrorClassStream
+	case Err_Unknown_File_Format, Err_Invalid_File_Format, Err_Invalid_Table, Err_Invalid_Offset:
+		return ErrorClassFontFormat
+	case Err_Array_Too_Large:
+		return ErrorClassMemory
+	}
+
+	switch {
+	case err >= 0x10 && err < 0x20:
+		return ErrorClassGlyph
+	case err >= 0x20 && err < 0x30:
+		return ErrorClassHandle
+	case err >= 0x30 && err < 0x40:
+		return ErrorClassDriver
+	case err >= 0x40 && err < 0x50:
+		return ErrorClassMemory
+	case err >= 0x50 && err < 0x60:
+		return ErrorClassStream
+	case err >= 0x60 && err < 0x70:
+		return ErrorClassRaster
+	case err >= 0x70 && err < 0x80:
+		return ErrorClassCache
+	case err >= 0x80 && err < 0xC0:
+		return ErrorClassFontFormat
+	}
+	return ErrorClassGeneric
+}
+
 /*
 Error is used to represent errors returned from FreeType functions.
 
 Its FTError method can be used to get the FTError value that was returned from the failing function.
+
+An Error matches the sentinel error for its FTError value (such as ErrInvalidGlyphIndex) with errors.Is,
+and unwraps to its ErrorClass.
 */
 type Error struct {
 	ftError FTError
@@ -260,10 +502,42 @@ func newError(err FTError, format string, args ...any) error {
 }
 
 func (err Error) Error() string {
-	return fmt.Sprintf("%s : error %d : %s", err.message, err.ftError, errorsText[err.ftError])
+	if err.message == "" {
+		return fmt.Sprintf("error %d : %s", err.ftError, ErrorString(err.ftError))
+	}
+	return fmt.Sprintf("%s : error %d : %s", err.message, err.ftError, ErrorString(err.ftError))
 }
 
 // FTError returns the FTError value that was returned from the failing function.
 func (err Error) FTError() FTError {
 	return err.ftError
 }
+
+// Class returns the class of the error's FTError value.
+func (err Error) Class() ErrorClass {
+	return ErrorClassOf(err.ftError)
+}
+
+// Is reports whether target is the sentinel error for the error's FTError value.
+func (err Error) Is(target error) bool {
+	sentinel, ok := target.(Error)
+	return ok && sentinel.message == "" && sentinel.ftError == err.ftError
+}
+
+// Unwrap returns the error's ErrorClass.
+func (err Error) Unwrap() error {
+	return err.Class()
+}
+
+// ftErrorOf returns the FTError value to report to FreeType for an error returned by Go code called from FreeType.
+// If err is (or wraps) an Error its FTError value is returned, otherwise fallback is returned.
+func ftErrorOf(err error, fallback FTError) FTError {
+	if err == nil {
+		return Err_Ok
+	}
+	var ftErr Error
+	if errors.As(err, &ftErr) {
+		return ftErr.FTError()
+	}
+	return fallback
+}
@@ -0,0 +1,57 @@
+package freetype
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestErrorString(t *testing.T) {
+	assert.Equal(t, "invalid glyph index", ErrorString(Err_Invalid_Glyph_Index))
+	assert.Equal(t, "unknown error", ErrorString(FTError(0xff)))
+}
+
+func TestErrorIs(t *testing.T) {
+	err := newError(Err_Invalid_Glyph_Index, "failed to load glyph")
+	assert.True(t, errors.Is(err, ErrInvalidGlyphIndex))
+	assert.False(t, errors.Is(err, ErrInvalidCharacterCode))
+	assert.True(t, errors.Is(err, ErrorClassGlyph))
+	assert.False(t, errors.Is(err, ErrorClassHandle))
+
+	wrapped := fmt.Errorf("failed to draw : %w", err)
+	assert.True(t, errors.Is(wrapped, ErrInvalidGlyphIndex))
+	assert.True(t, errors.Is(wrapped, ErrorClassGlyph))
+
+	var class ErrorClass
+	assert.True(t, errors.As(wrapped, &class))
+	assert.Equal(t, ErrorClassGlyph, class)
+
+	lib, _ := Init()
+	_, err = lib.NewFace("bad path", 0)
+	assert.True(t, errors.Is(err, ErrCannotOpenResource))
+	assert.True(t, errors.Is(err, ErrorClassStream))
+
+	_, err = lib.NewMemoryFace(font.DejaVuSansMono[1:], 0)
+	assert.True(t, errors.Is(err, ErrorClassFontFormat))
+}
+
+func TestErrorClassOf(t *testing.T) {
+	assert.Equal(t, ErrorClassGeneric, ErrorClassOf(Err_Invalid_Argument))
+	assert.Equal(t, ErrorClassGlyph, ErrorClassOf(Err_Invalid_Glyph_Index))
+	assert.Equal(t, ErrorClassHandle, ErrorClassOf(Err_Invalid_Face_Handle))
+	assert.Equal(t, ErrorClassMemory, ErrorClassOf(Err_Out_Of_Memory))
+	assert.Equal(t, ErrorClassStream, ErrorClassOf(Err_Invalid_Stream_Read))
+	assert.Equal(t, ErrorClassFontFormat, ErrorClassOf(Err_Unknown_File_Format))
+	assert.Equal(t, ErrorClassFontFormat, ErrorClassOf(Err_Table_Missing))
+	assert.Equal(t, ErrorClassFontFormat, ErrorClassOf(Err_Corrupted_Font_Header))
+}
+
+func TestErrorError(t *testing.T) {
+	err := newError(Err_Invalid_Argument, "failed to do %s", "something")
+	assert.Equal(t, "failed to do something : error 6 : invalid argument", err.Error())
+	assert.Equal(t, "error 6 : invalid argument", ErrInvalidArgument.Error())
+}
@@ -16,6 +16,8 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// The library, and its faces, must be discarded, as they are not discarded when they are garbage collected.
+	defer lib.Done()
 
 	// Load a Face from font data.
 	face, err := lib.NewMemoryFace(font.DejaVuSansMono, 0)
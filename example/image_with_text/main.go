@@ -2,6 +2,7 @@ package main
 
 import (
 	"image"
+	"image/draw"
 	"image/png"
 	"os"
 
@@ -15,6 +16,8 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// The library, and its faces, must be discarded, as they are not discarded when they are garbage collected.
+	defer lib.Done()
 
 	// Load a Face from font data.
 	face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
@@ -28,14 +31,14 @@ func main() {
 		panic(err)
 	}
 
-	image := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
 
-	// Draw glyphs in to image
-	x := 10
-	y := 30
-	for _, r := range "FreeType" {
-		advance := drawGlyphAt(image, face, r, x, y)
-		x += int(advance / 64)
+	// Draw text in to the image.
+	origin := freetype.Vector{X: 10 * 64, Y: 30 * 64}
+	_, err = freetype.DrawString(img, face, "FreeType", origin, image.Black, freetype.DrawOptions{})
+	if err != nil {
+		panic(err)
 	}
 
 	// Write the image to a file
@@ -43,7 +46,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	err = png.Encode(outputFile, image)
+	err = png.Encode(outputFile, img)
 	if err != nil {
 		panic(err)
 	}
@@ -52,42 +55,3 @@ func main() {
 		panic(err)
 	}
 }
-
-func drawGlyphAt(image *image.RGBA, face freetype.Face, codepoint rune, penX int, penY int) freetype.Pos {
-	// Load the glyph.
-	err := face.LoadGlyph(face.GetCharIndex(codepoint), freetype.LOAD_DEFAULT)
-	if err != nil {
-		panic(err)
-	}
-
-	// Render the glyph as a bitmap.
-	err = face.RenderGlyph(freetype.RENDER_MODE_NORMAL)
-	if err != nil {
-		panic(err)
-	}
-	glyph := face.Rec().Glyph.Rec()
-
-	y := penY - int(glyph.BitmapTop)
-	bitmap := glyph.Bitmap
-	for yGlyph := 0; yGlyph < int(bitmap.Rows); yGlyph++ {
-		x := penX + int(glyph.BitmapLeft)
-		for xGlyph := 0; xGlyph < int(bitmap.Width); xGlyph++ {
-			imageIndex := (y * image.Stride) + (4 * x)
-			bitmapIndex := (yGlyph * int(bitmap.Pitch)) + xGlyph
-			alpha := bitmap.Buffer()[bitmapIndex]
-			gray := 0xff - alpha
-			image.Pix[imageIndex+0] = gray // R
-			image.Pix[imageIndex+1] = gray // G
-			image.Pix[imageIndex+2] = gray // B
-			image.Pix[imageIndex+3] = 0xFF // A
-			// image.Pix[imageIndex+0] = 0x00                         // R
-			// image.Pix[imageIndex+1] = 0x00                         // G
-			// image.Pix[imageIndex+2] = 0x00                         // B
-			// image.Pix[imageIndex+3] = bitmap.Buffer()[bitmapIndex] // A
-			x++
-		}
-		y++
-	}
-
-	return glyph.Advance.X
-}
@@ -0,0 +1,309 @@
+package freetype
+
+import "unicode"
+
+// Font fallback, with a chain of faces.
+
+/*
+SizeMatch selects how the sizes of the fallback faces of a FaceChain are matched
+to the size of its first face.
+*/
+type SizeMatch int
+
+const (
+	// MatchEm gives the fallback faces the same em size as the first face.
+	MatchEm SizeMatch = iota
+	// MatchXHeight gives the fallback faces the same x-height as the first face,
+	// so that their lowercase letters are the same height.
+	// A face without an x-height is given the same em size, as for MatchEm.
+	MatchXHeight
+)
+
+/*
+FaceChain is an ordered list of faces, that are used together to display text that no one face covers,
+such as Latin text with CJK characters and emoji.
+Each character is displayed with the first of the faces that has a glyph for it.
+
+The faces share a baseline. Their sizes are set together with the chain's SetCharSize or SetPixelSizes,
+which match the sizes of the fallback faces to the first face.
+*/
+type FaceChain struct {
+	// SizeMatch selects how the sizes of the fallback faces are matched to the size of the first face.
+	SizeMatch SizeMatch
+
+	faces []Face
+	// scales are the factors by which the glyphs of faces with fixed sizes are scaled.
+	scales []Fixed
+}
+
+// NewFaceChain returns a FaceChain of faces, in order of preference. At least one face must be provided.
+func NewFaceChain(faces ...Face) (*FaceChain, error) {
+	if len(faces) == 0 {
+		return nil, newError(Err_Invalid_Argument, "a face chain must have at least one face")
+	}
+	chain := &FaceChain{
+		faces:  append([]Face(nil), faces...),
+		scales: make([]Fixed, len(faces)),
+	}
+	for i := range chain.scales {
+		chain.scales[i] = 0x10000
+	}
+	return chain, nil
+}
+
+// Faces returns the chain's faces, in order of preference.
+func (chain *FaceChain) Faces() []Face {
+	return append([]Face(nil), chain.faces...)
+}
+
+// Coverage returns the characters that any of the chain's faces have glyphs for.
+func (chain *FaceChain) Coverage() Coverage {
+	var coverage Coverage
+	for _, face := range chain.faces {
+		coverage = coverage.Union(face.Coverage())
+	}
+	return coverage
+}
+
+/*
+Scale returns the factor, in 16.16 format, by which the glyphs of the chain's i'th face should be scaled.
+
+It is 1.0 (0x10000) except for a face that only has fixed sizes, such as a bitmap emoji face,
+of which the closest size was selected.
+*/
+func (chain *FaceChain) Scale(i int) Fixed {
+	return chain.scales[i]
+}
+
+// GetCharIndex returns the index of the first face that has a glyph for a character, and the glyph's index.
+// If none of the faces has a glyph for the character, the first face and its missing glyph (0) are returned.
+func (chain *FaceChain) GetCharIndex(r rune) (int, UInt) {
+	for i, face := range chain.faces {
+		if index := face.GetCharIndex(r); index != 0 {
+			return i, index
+		}
+	}
+	return 0, 0
+}
+
+// FaceRun is a run of text that is displayed with one of the faces of a FaceChain.
+type FaceRun struct {
+	Face Face
+	// FaceIndex is the index of the face in the chain.
+	FaceIndex int
+	// Start and End are the byte offsets of the run in the text.
+	Start, End int
+	// Scale is the factor, in 16.16 format, by which the face's glyphs should be scaled, as returned by Scale.
+	Scale Fixed
+}
+
+/*
+Runs splits text in to runs of characters that are displayed with the same face.
+
+Each character is displayed with the first face that has a glyph for it, with some exceptions.
+Marks, joiners, variation selectors, and emoji modifiers stay in the run of the preceding character
+if its face has a glyph for them, or if they are invisible.
+A character followed by the emoji variation selector (U+FE0F) prefers a color face,
+and a character followed by the text variation selector (U+FE0E) prefers a face that is not a color face.
+*/
+func (chain *FaceChain) Runs(text string) []FaceRun {
+	var runs []FaceRun
+	for offset, r := range text {
+		faceIndex := -1
+		if len(runs) > 0 && extendsCluster(r) {
+			current := runs[len(runs)-1].FaceIndex
+			if chain.faces[current].GetCharIndex(r) != 0 || isDefaultIgnorable(r) {
+				faceIndex = current
+			}
+		}
+		if faceIndex < 0 {
+			faceIndex = chain.faceFor(r, variationSelector(text[offset:]))
+		}
+
+		if len(runs) > 0 && runs[len(runs)-1].FaceIndex == faceIndex {
+			runs[len(runs)-1].End = offset + len(string(r))
+			continue
+		}
+		runs = append(runs, FaceRun{
+			Face:      chain.faces[faceIndex],
+			FaceIndex: faceIndex,
+			Start:     offset,
+			End:       offset + len(string(r)),
+			Scale:     chain.scales[faceIndex],
+		})
+	}
+	return runs
+}
+
+// faceFor returns the index of the face to use for a character, followed by a variation selector or 0.
+func (chain *FaceChain) faceFor(r rune, selector rune) int {
+	if selector == 0xFE0E || selector == 0xFE0F {
+		color := selector == 0xFE0F
+		for i, face := range chain.faces {
+			if face.HasColor() == color && face.GetCharIndex(r) != 0 {
+				return i
+			}
+		}
+	}
+	i, _ := chain.GetCharIndex(r)
+	return i
+}
+
+// variationSelector returns the variation selector that follows the first character of text, or 0.
+func variationSelector(text string) rune {
+	for i, r := range text {
+		if i == 0 {
+			continue
+		}
+		if r == 0xFE0E || r == 0xFE0F {
+			return r
+		}
+		break
+	}
+	return 0
+}
+
+// extendsCluster reports whether a character extends the cluster of the preceding character.
+func extendsCluster(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == 0x200D || // zero width joiner
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji modifiers
+		isDefaultIgnorable(r)
+}
+
+// isDefaultIgnorable reports whether a character is a variation selector, joiner, or tag, which is invisible.
+func isDefaultIgnorable(r rune) bool {
+	return r == 0x200C || r == 0x200D ||
+		(r >= 0xFE00 && r <= 0xFE0F) ||
+		(r >= 0xE0020 && r <= 0xE007F) ||
+		(r >= 0xE0100 && r <= 0xE01EF)
+}
+
+/*
+SetCharSize sets the size, in points, of the chain's first face,
+and matches the sizes of the other faces to it.
+
+The arguments are the same as for Face.SetCharSize.
+*/
+func (chain *FaceChain) SetCharSize(
+	charWidth F26Dot6, charHeight F26Dot6,
+	horzResolution UInt, vertResolution UInt,
+) error {
+	if err := chain.faces[0].SetCharSize(charWidth, charHeight, horzResolution, vertResolution); err != nil {
+		return err
+	}
+	return chain.matchSizes()
+}
+
+/*
+SetPixelSizes sets the size, in pixels, of the chain's first face,
+and matches the sizes of the other faces to it.
+
+The arguments are the same as for Face.SetPixelSizes.
+*/
+func (chain *FaceChain) SetPixelSizes(pixelWidth UInt, pixelHeight UInt) error {
+	if err := chain.faces[0].SetPixelSizes(pixelWidth, pixelHeight); err != nil {
+		return err
+	}
+	return chain.matchSizes()
+}
+
+// matchSizes sets the sizes of the fallback faces to match the current size of the first face.
+func (chain *FaceChain) matchSizes() error {
+	first := chain.faces[0]
+	metrics := first.Rec().Size.Rec().Metrics
+	// The first face's em, in 26.6 pixels.
+	emWidth, emHeight := Pos(metrics.Xppem)<<6, Pos(metrics.Yppem)<<6
+	if first.IsScalable() {
+		unitsPerEM := Long(first.Rec().UnitsPerEM)
+		emWidth, emHeight = MulFix(unitsPerEM, metrics.XScale), MulFix(unitsPerEM, metrics.YScale)
+	}
+	firstXHeight, firstHasXHeight := first.xHeight()
+
+	for i, face := range chain.faces[1:] {
+		scale, err := face.setEmSize(emWidth, emHeight)
+		if err != nil {
+			return err
+		}
+		if xHeight, ok := face.xHeight(); ok && firstHasXHeight && chain.SizeMatch == MatchXHeight {
+			// Scale the em of the face so that its x-height is the same as the first face's.
+			ratio := DivFix(firstXHeight, MulFix(xHeight, scale))
+			if scale, err = face.setEmSize(MulFix(emWidth, ratio), MulFix(emHeight, ratio)); err != nil {
+				return err
+			}
+		}
+		chain.scales[i+1] = scale
+	}
+	return nil
+}
+
+/*
+setEmSize sets the size of a face to an em size in 26.6 pixels.
+
+A face that only has fixed sizes is set to the smallest size that is at least as large,
+or else the largest size, and the factor by which its glyphs should be scaled is returned.
+*/
+func (face Face) setEmSize(width, height Pos) (Fixed, error) {
+	if face.IsScalable() || !face.HasFixedSizes() {
+		err := face.RequestSize(SizeRequestRec{Type: SIZE_REQUEST_TYPE_NOMINAL, Width: width, Height: height})
+		return 0x10000, err
+	}
+
+	sizes := face.Rec().AvailableSizes()
+	best := 0
+	for i, size := range sizes {
+		bestSize := sizes[best].YPpem
+		if bestSize >= height {
+			if size.YPpem >= height && size.YPpem < bestSize {
+				best = i
+			}
+		} else if size.YPpem > bestSize {
+			best = i
+		}
+	}
+	if err := face.SelectSize(Int(best)); err != nil {
+		return 0, err
+	}
+	return DivFix(height, sizes[best].YPpem), nil
+}
+
+/*
+xHeight returns the height of a face's lowercase letters, in 26.6 pixels at its current size.
+It is taken from the OS/2 table, or else from the height of the glyph of 'x'.
+*/
+func (face Face) xHeight() (Pos, bool) {
+	metrics := face.Rec().Size.Rec().Metrics
+	if os2, err := face.OS2Table(); err == nil {
+		if xHeight, err := os2.XHeight(); err == nil && xHeight > 0 {
+			return MulFix(Long(xHeight), metrics.YScale), true
+		}
+	}
+
+	index := face.GetCharIndex('x')
+	if index == 0 {
+		return 0, false
+	}
+	if err := face.LoadGlyph(index, LOAD_NO_HINTING); err != nil {
+		return 0, false
+	}
+	height := face.Rec().Glyph.Rec().Metrics.HoriBearingY
+	return height, height > 0
+}
+
+/*
+Metrics returns the size metrics of the chain's first face, with its ascender, descender, height,
+and maximum advance extended to those of the other faces, so that lines of text with glyphs
+from any of the faces can be spaced consistently.
+*/
+func (chain *FaceChain) Metrics() SizeMetrics {
+	metrics := chain.faces[0].Rec().Size.Rec().Metrics
+	for i, face := range chain.faces[1:] {
+		m := face.Rec().Size.Rec().Metrics
+		scale := chain.scales[i+1]
+		metrics.Ascender = max(metrics.Ascender, MulFix(m.Ascender, scale))
+		metrics.Descender = min(metrics.Descender, MulFix(m.Descender, scale))
+		metrics.Height = max(metrics.Height, MulFix(m.Height, scale))
+		metrics.MaxAdvance = max(metrics.MaxAdvance, MulFix(m.MaxAdvance, scale))
+	}
+	return metrics
+}
@@ -0,0 +1,93 @@
+package freetype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func newFaceChain(t *testing.T) *FaceChain {
+	t.Helper()
+	lib, err := Init()
+	assert.Nil(t, err)
+	roboto, err := lib.NewMemoryFace(font.RobotoVariable, 0)
+	assert.Nil(t, err)
+	dejaVu, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+	chain, err := NewFaceChain(roboto, dejaVu)
+	assert.Nil(t, err)
+	return chain
+}
+
+func TestNewFaceChainWithoutFaces(t *testing.T) {
+	_, err := NewFaceChain()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestFaceChainRuns(t *testing.T) {
+	chain := newFaceChain(t)
+	faces := chain.Faces()
+
+	faceIndex, index := chain.GetCharIndex('a')
+	assert.Equal(t, 0, faceIndex)
+	assert.Equal(t, faces[0].GetCharIndex('a'), index)
+	faceIndex, index = chain.GetCharIndex('א')
+	assert.Equal(t, 1, faceIndex)
+	assert.Equal(t, faces[1].GetCharIndex('א'), index)
+	faceIndex, index = chain.GetCharIndex(0xE000)
+	assert.Equal(t, 0, faceIndex)
+	assert.Equal(t, UInt(0), index)
+
+	text := "abc אָב★︎!"
+	var runs []string
+	var faceIndexes []int
+	for _, run := range chain.Runs(text) {
+		runs = append(runs, text[run.Start:run.End])
+		faceIndexes = append(faceIndexes, run.FaceIndex)
+		assert.Equal(t, faces[run.FaceIndex], run.Face)
+		assert.Equal(t, Fixed(0x10000), run.Scale)
+	}
+	// The Hebrew point stays with its letter, and the variation selector with the star.
+	assert.Equal(t, []string{"abc ", "אָב★︎", "!"}, runs)
+	assert.Equal(t, []int{0, 1, 0}, faceIndexes)
+
+	assert.Nil(t, chain.Runs(""))
+}
+
+func TestFaceChainCoverage(t *testing.T) {
+	chain := newFaceChain(t)
+	faces := chain.Faces()
+
+	coverage := chain.Coverage()
+	assert.Equal(t, faces[0].Coverage().Union(faces[1].Coverage()), coverage)
+	assert.Equal(t, []rune{'日'}, coverage.Missing("Aא日"))
+}
+
+func TestFaceChainSizes(t *testing.T) {
+	chain := newFaceChain(t)
+	faces := chain.Faces()
+
+	assert.Nil(t, chain.SetPixelSizes(0, 40))
+	assert.Equal(t, UShort(40), faces[0].Rec().Size.Rec().Metrics.Yppem)
+	assert.Equal(t, UShort(40), faces[1].Rec().Size.Rec().Metrics.Yppem)
+
+	xHeight := func(face Face) Pos {
+		height, ok := face.xHeight()
+		assert.True(t, ok)
+		return height
+	}
+	assert.NotEqual(t, xHeight(faces[0]), xHeight(faces[1]))
+
+	chain.SizeMatch = MatchXHeight
+	assert.Nil(t, chain.SetCharSize(0, 30*64, 96, 96))
+	// TrueType faces may round their sizes to whole pixels, so the x-heights are within half a pixel.
+	assert.InDelta(t, xHeight(faces[0]), xHeight(faces[1]), 32)
+
+	metrics := chain.Metrics()
+	for _, face := range faces {
+		assert.GreaterOrEqual(t, metrics.Ascender, face.Rec().Size.Rec().Metrics.Ascender)
+		assert.LessOrEqual(t, metrics.Descender, face.Rec().Size.Rec().Metrics.Descender)
+	}
+}
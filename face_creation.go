@@ -1,6 +1,11 @@
 package freetype
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"sync"
 	"unsafe"
 
 	"modernc.org/libc"
@@ -16,13 +21,143 @@ A face object models a given typeface, in a given style.
 https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_face
 */
 type Face struct {
+	face      libfreetype.TFT_Face
+	tls       *libc.TLS
+	resources *faceResources
+	owner     *owner
+}
+
+// faceResources holds memory that must outlive a face's FreeType object,
+// and that is released when the face is finally discarded.
+type faceResources struct {
 	face libfreetype.TFT_Face
 	tls  *libc.TLS
+	// state is the state of the face's library, and id is the face's id in it.
+	state *libraryState
+	id    uint64
+	// done is true when the face has been discarded.
+	done bool
+
+	source      stream
+	compressed  stream
+	incremental *incrementalInterface
+	// memory is the face's copy of the font data of a memory face,
+	// allocated with allocator, the memory manager of the face's library.
+	memory    uintptr
+	allocator libfreetype.TFT_Memory
+	// shared is font data that the face shares with other faces, and that it holds a reference to.
+	shared *sharedMemory
+	// opticalSizeAuto is true if SetVariations set the optical size axis to OpticalSizeAuto,
+	// so that the axis follows the size of the face.
+	opticalSizeAuto bool
+}
+
+func (res *faceResources) release(tls *libc.TLS) {
+	if res == nil {
+		return
+	}
+	if res.compressed != 0 {
+		// FT_Done_Face has already closed the compressed stream, as it was an external stream.
+		libc.Xfree(tls, uintptr(res.compressed))
+		res.compressed = 0
+	}
+	if res.source != 0 {
+		libfreetype.XFT_Stream_Free(tls, libfreetype.TFT_Stream(res.source), 0)
+		res.source = 0
+	}
+	if res.incremental != nil {
+		res.incremental.done(tls)
+		res.incremental = nil
+	}
+	if res.memory != 0 {
+		freeMemory(tls, res.allocator, res.memory)
+		res.memory = 0
+	}
+	if res.shared != nil {
+		res.shared.release(tls)
+		res.shared = nil
+	}
+}
+
+/*
+sharedMemory is a copy of font data in C memory, that is shared by faces.
+It is freed when the last of its references is released.
+*/
+type sharedMemory struct {
+	mutex sync.Mutex
+	// allocator is the memory manager that data was allocated with, as for allocMemory.
+	allocator libfreetype.TFT_Memory
+	data      uintptr
+	size      int
+	refs      int
+}
+
+// newSharedMemory copies data to memory allocated by allocator, as for allocMemory,
+// with one reference, that is held by the caller.
+func newSharedMemory(tls *libc.TLS, allocator libfreetype.TFT_Memory, data []byte) (*sharedMemory, error) {
+	block, err := allocMemory(tls, allocator, len(data))
+	if err != nil {
+		return nil, err
+	}
+	shared := &sharedMemory{allocator: allocator, data: block, size: len(data), refs: 1}
+	copy(shared.bytes(), data)
+	return shared, nil
+}
+
+// bytes returns the C memory as a slice, which is valid while a reference is held.
+func (shared *sharedMemory) bytes() []byte {
+	return unsafe.Slice(fromUintptr[byte](shared.data), shared.size)
+}
+
+// acquire adds a reference, and returns false if the memory has already been freed.
+func (shared *sharedMemory) acquire() bool {
+	shared.mutex.Lock()
+	defer shared.mutex.Unlock()
+	if shared.refs == 0 {
+		return false
+	}
+	shared.refs++
+	return true
+}
+
+// release releases a reference, and frees the memory if it was the last one.
+func (shared *sharedMemory) release(tls *libc.TLS) {
+	shared.mutex.Lock()
+	defer shared.mutex.Unlock()
+	shared.refs--
+	if shared.refs == 0 {
+		freeMemory(tls, shared.allocator, shared.data)
+		shared.data = 0
+	}
+}
+
+// discard discards the face, whatever its reference count, and releases its resources.
+// It is used when the face's library is discarded first, or when the face is garbage collected.
+func (res *faceResources) discard() {
+	for !res.done {
+		lastReference := faceRefcount(res.face) <= 1
+		if libfreetype.XFT_Done_Face(res.tls, res.face) != Err_Ok {
+			break
+		}
+		res.done = lastReference
+	}
+	res.done = true
+	res.release(res.tls)
+}
+
+// handle returns the face's FreeType handle, or 0 (a null handle) if the face has been discarded,
+// so that FreeType returns Err_Invalid_Face_Handle rather than using freed memory.
+func (face Face) handle() libfreetype.TFT_Face {
+	if face.resources != nil && face.resources.done {
+		return 0
+	}
+	return face.face
 }
 
 // Rec returns a pointer to the FaceRec that is referenced by the Face.
+// It returns nil if the face has been discarded.
 func (face Face) Rec() *FaceRec {
-	return fromUintptr[FaceRec](face.face)
+	return fromUintptr[FaceRec](face.handle())
 }
 
 func init() {
@@ -164,32 +299,61 @@ const (
 
 // NewFace opens a font by its pathname.
 //
+// Font files compressed with gzip, LZW (Unix compress) or bzip2 are decompressed transparently.
+//
 // https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_new_face
 func (lib Library) NewFace(filepathname string, faceIndex int) (Face, error) {
-	cFilepathname, err := libc.CString(filepathname)
-	if err != nil {
-		return Face{}, err
-	}
-	defer libc.Xfree(nil, cFilepathname)
-
-	face, freeFace := alloc(lib.tls, Face{})
-	face.tls = lib.tls
+	return lib.openFace(OpenArgs{Flags: OPEN_PATHNAME, Pathname: filepathname}, faceIndex,
+		"failed to create a face for file '%s'", filepathname)
+}
 
-	err_ := libfreetype.XFT_New_Face(lib.tls, lib.library, cFilepathname, Long(faceIndex), toUintptr(&face.face))
+/*
+Done discards a given face object, as well as all of its child slots and sizes.
 
-	face_ := *face
-	freeFace()
-	return face_, newError(err_, "failed to create a face for file '%s'", filepathname)
-}
+Using the face after it has been discarded returns an error wrapping ErrInvalidFaceHandle.
+A face that is garbage collected without being discarded is discarded by the next call
+that creates a face from its library, or when its library is discarded.
 
-// Done discards a given face object, as well as all of its child slots and sizes.
-//
-// https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_done_face
+https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_done_face
+*/
 func (face Face) Done() error {
-	err := libfreetype.XFT_Done_Face(face.tls, face.face)
+	defer runtime.KeepAlive(face.owner)
+	// The face is only destroyed when its reference counter reaches zero.
+	lastReference := face.refcount() <= 1
+	err := libfreetype.XFT_Done_Face(face.tls, face.handle())
+	if err == Err_Ok && lastReference && face.resources != nil {
+		face.resources.done = true
+		if face.resources.state != nil {
+			face.resources.state.unregister(face.resources.id)
+		}
+		if face.owner != nil {
+			face.owner.cleanup.Stop()
+		}
+		face.resources.release(face.tls)
+	}
 	return newError(err, "failed to discard face")
 }
 
+// Close discards the face, as Done does. It implements io.Closer.
+func (face Face) Close() error {
+	return face.Done()
+}
+
+func (face Face) refcount() Int {
+	return faceRefcount(face.handle())
+}
+
+func faceRefcount(face libfreetype.TFT_Face) Int {
+	if face == 0 {
+		return 0
+	}
+	internal := fromUintptr[libfreetype.TFT_FaceRec](face).Finternal
+	if internal == 0 {
+		return 0
+	}
+	return fromUintptr[libfreetype.TFT_Face_InternalRec](internal).Frefcount
+}
+
 /*
 A counter gets initialized to 1 at the time a Face structure is created.
 This function increments the counter.
@@ -197,25 +361,58 @@ This function increments the counter.
 https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_reference_face
 */
 func (face Face) Reference() error {
-	err := libfreetype.XFT_Reference_Face(face.tls, face.face)
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Reference_Face(face.tls, face.handle())
 	return newError(err, "failed to reference face")
 }
 
 // NewMemoryFace opens a font that has been loaded into memory.
 //
+// The data is copied, and the copy is freed by the face's Done,
+// so data may be modified or discarded after NewMemoryFace returns.
+//
+// Font data compressed with gzip, LZW (Unix compress) or bzip2 is decompressed transparently.
+//
 // https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_new_memory_face
 func (lib Library) NewMemoryFace(data []byte, faceIndex int) (Face, error) {
-	face, freeFace := alloc(lib.tls, Face{})
-	face.tls = lib.tls
+	return lib.openFace(OpenArgs{Flags: OPEN_MEMORY, MemoryBase: data}, faceIndex,
+		"failed to create a new memory face")
+}
 
-	err := libfreetype.XFT_New_Memory_Face(
-		lib.tls, lib.library,
-		toUintptr(&data[0]), libfreetype.TFT_Long(len(data)),
-		libfreetype.TFT_Long(faceIndex), toUintptr(&face.face))
+// NewFaceInstance opens a named instance of a variation font, or of a face in a font collection, by its pathname.
+// instanceIndex counts from 1, and 0 opens the face without selecting a named instance, as NewFace does.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_face
+func (lib Library) NewFaceInstance(filepathname string, faceIndex int, instanceIndex int) (Face, error) {
+	index, err := instanceFaceIndex(faceIndex, instanceIndex)
+	if err != nil {
+		return Face{}, err
+	}
+	return lib.openFace(OpenArgs{Flags: OPEN_PATHNAME, Pathname: filepathname}, index,
+		"failed to create a face for instance %d of face %d of file '%s'", instanceIndex, faceIndex, filepathname)
+}
 
-	face_ := *face
-	freeFace()
-	return face_, newError(err, "failed to create a new memory face")
+// NewMemoryFaceInstance opens a named instance of a variation font that has been loaded into memory,
+// as NewFaceInstance does.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_face
+func (lib Library) NewMemoryFaceInstance(data []byte, faceIndex int, instanceIndex int) (Face, error) {
+	index, err := instanceFaceIndex(faceIndex, instanceIndex)
+	if err != nil {
+		return Face{}, err
+	}
+	return lib.openFace(OpenArgs{Flags: OPEN_MEMORY, MemoryBase: data}, index,
+		"failed to create a new memory face for instance %d of face %d", instanceIndex, faceIndex)
+}
+
+// instanceFaceIndex returns the face_index argument of FT_Open_Face,
+// which holds the instance index in bits 16-30 and the face index in bits 0-15.
+func instanceFaceIndex(faceIndex int, instanceIndex int) (int, error) {
+	if faceIndex < 0 || faceIndex > 0xFFFF || instanceIndex < 0 || instanceIndex > 0x7FFF {
+		return 0, newError(Err_Invalid_Argument,
+			"face index %d or instance index %d is out of range", faceIndex, instanceIndex)
+	}
+	return instanceIndex<<16 | faceIndex, nil
 }
 
 /*
@@ -224,21 +421,240 @@ Properties sets or overrides certain (library or module-wide) properties on a fa
 https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_face_properties
 */
 func (face Face) Properties(properties ...Parameter) error {
-	err := libfreetype.XFT_Face_Properties(face.tls, face.face, UInt(len(properties)), toUintptr(&properties[0]))
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Face_Properties(face.tls, face.handle(), UInt(len(properties)), toUintptr(&properties[0]))
 	for _, param := range properties {
 		param.freeData()
 	}
 	return newError(err, "failed to set face properties")
 }
 
-// // FT_Open_Face
-// // https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_face
+// OpenFace creates a face object from a given resource described by OpenArgs.
+//
+// Font data compressed with gzip, LZW (Unix compress) or bzip2 is decompressed transparently.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_face
+func (lib Library) OpenFace(args OpenArgs, faceIndex int) (Face, error) {
+	return lib.openFace(args, faceIndex, "failed to open face")
+}
+
+func (lib Library) openFace(args OpenArgs, faceIndex int, format string, formatArgs ...any) (Face, error) {
+	defer runtime.KeepAlive(lib.owner)
+	defer func() {
+		for _, param := range args.Params {
+			param.freeData()
+		}
+	}()
+
+	lib.state.discardCollected()
+	args, err := args.decompressBzip2()
+	if err != nil {
+		return Face{}, err
+	}
+	args, err = lib.decompressLZW(args)
+	if err != nil {
+		return Face{}, err
+	}
+	resources := &faceResources{allocator: lib.memory()}
+
+	cArgs, freeCArgs, err := args.toC(lib.tls, resources)
+	if err != nil {
+		resources.release(lib.tls)
+		return Face{}, err
+	}
+	defer freeCArgs()
+
+	compression, err := args.compression()
+	if err != nil {
+		resources.release(lib.tls)
+		return Face{}, err
+	}
+	if compression != nil {
+		err = lib.openCompressedStream(cArgs, compression, resources)
+		if err != nil {
+			resources.release(lib.tls)
+			return Face{}, err
+		}
+		cArgs.Fflags = (cArgs.Fflags &^ (OPEN_MEMORY | OPEN_PATHNAME)) | OPEN_STREAM
+		cArgs.Fstream = libfreetype.TFT_Stream(resources.compressed)
+	}
+
+	face, freeFace := alloc(lib.tls, Face{})
+	face.tls = lib.tls
 
-// // FT_Open_Args
-// // https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_args
+	err_ := libfreetype.XFT_Open_Face(lib.tls, lib.handle(), toUintptr(cArgs), libfreetype.TFT_Long(faceIndex), toUintptr(&face.face))
 
-// // FT_OPEN_XXX
-// // https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_xxx
+	face_ := *face
+	freeFace()
+	if err_ != Err_Ok {
+		resources.release(lib.tls)
+		return Face{}, newError(err_, format, formatArgs...)
+	}
+	face_.resources = resources
+	resources.face = face_.face
+	resources.tls = lib.tls
+	if lib.state != nil {
+		resources.state = lib.state
+		resources.id = lib.state.register("Face", resources.discard)
+		face_.owner = &owner{parent: lib.owner}
+		face_.owner.cleanup = runtime.AddCleanup(face_.owner, lib.state.collect, resources.id)
+	}
+	return face_, nil
+}
+
+// openCompressedStream wraps the stream described by args in a stream that decompresses its content.
+// The streams are recorded in resources.
+func (lib Library) openCompressedStream(args *libfreetype.TFT_Open_Args, open streamOpener, resources *faceResources) error {
+	defer runtime.KeepAlive(lib.owner)
+	err := libfreetype.XFT_Stream_New(lib.tls, lib.handle(), toUintptr(args), toUintptr(&resources.source))
+	if err != Err_Ok {
+		return newError(err, "failed to open stream for compressed font")
+	}
+
+	resources.compressed = stream(libc.Xcalloc(lib.tls, 1, libc.Tsize_t(unsafe.Sizeof(libfreetype.TFT_StreamRec{}))))
+	return open(lib.tls, resources.compressed, resources.source)
+}
+
+/*
+OpenArgs is a structure to indicate how to open a new font file or stream.
+
+Unlike FreeType's FT_Open_Args, there are no stream or driver fields.
+Streams are managed internally, for example to decompress compressed fonts.
+
+MemoryBase is copied, and the copy is freed when the face is discarded.
+
+If Incremental is not nil, glyph data is fetched lazily from it when glyphs are loaded.
+See IncrementalSource.
+
+https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_args
+*/
+type OpenArgs struct {
+	Flags       OpenFlag
+	MemoryBase  []byte
+	Pathname    string
+	Params      []Parameter
+	Incremental IncrementalSource
+
+	// shared, if not nil, holds the font data in place of MemoryBase, and is shared by faces rather than copied.
+	shared *sharedMemory
+}
+
+// toC returns a C representation of the args, and a function to free it.
+// Any memory that must outlive the call to FT_Open_Face is recorded in resources.
+func (args OpenArgs) toC(tls *libc.TLS, resources *faceResources) (*libfreetype.TFT_Open_Args, func(), error) {
+	cArgs := fromUintptr[libfreetype.TFT_Open_Args](
+		libc.Xcalloc(tls, 1, libc.Tsize_t(unsafe.Sizeof(libfreetype.TFT_Open_Args{}))))
+	cArgs.Fflags = args.Flags
+	free := func() {
+		libc.Xfree(tls, cArgs.Fpathname)
+		libc.Xfree(tls, cArgs.Fparams)
+		libc.Xfree(tls, toUintptr(cArgs))
+	}
+
+	if args.Flags&OPEN_MEMORY != 0 && args.shared != nil {
+		if !args.shared.acquire() {
+			free()
+			return nil, nil, newError(Err_Invalid_Handle, "the shared font data has been freed")
+		}
+		resources.shared = args.shared
+		cArgs.Fmemory_base = args.shared.data
+		cArgs.Fmemory_size = libfreetype.TFT_Long(args.shared.size)
+	} else if args.Flags&OPEN_MEMORY != 0 && len(args.MemoryBase) > 0 {
+		// FreeType reads the data for as long as the face exists,
+		// so it is copied to memory allocated by the library's memory manager, that is freed with the face.
+		memory, err := allocMemory(tls, resources.allocator, len(args.MemoryBase))
+		if err != nil {
+			free()
+			return nil, nil, err
+		}
+		resources.memory = memory
+		copy(unsafe.Slice(fromUintptr[byte](resources.memory), len(args.MemoryBase)), args.MemoryBase)
+		cArgs.Fmemory_base = resources.memory
+		cArgs.Fmemory_size = libfreetype.TFT_Long(len(args.MemoryBase))
+	}
+
+	if args.Flags&OPEN_PATHNAME != 0 {
+		cPathname, err := libc.CString(args.Pathname)
+		if err != nil {
+			free()
+			return nil, nil, err
+		}
+		cArgs.Fpathname = cPathname
+	}
+
+	var params []Parameter
+	if args.Flags&OPEN_PARAMS != 0 {
+		params = append(params, args.Params...)
+	}
+	if args.Incremental != nil {
+		resources.incremental = newIncrementalInterface(tls, args.Incremental)
+		params = append(params, Parameter{
+			Ftag:  PARAM_TAG_INCREMENTAL,
+			Fdata: libfreetype.TFT_Pointer(resources.incremental.rec),
+		})
+		cArgs.Fflags |= OPEN_PARAMS
+	}
+	if len(params) > 0 {
+		size := int(unsafe.Sizeof(Parameter{})) * len(params)
+		cArgs.Fparams = libc.Xmalloc(tls, libc.Tsize_t(size))
+		copy(unsafe.Slice(fromUintptr[Parameter](cArgs.Fparams), len(params)), params)
+		cArgs.Fnum_params = Int(len(params))
+	}
+
+	return cArgs, free, nil
+}
+
+// compression returns the function to open a decompressing stream for the args' font data,
+// or nil if the data is not compressed, or is compressed with bzip2 or LZW (see decompressBzip2 and decompressLZW).
+func (args OpenArgs) compression() (streamOpener, error) {
+	header, err := args.header()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return openGzipStream, nil
+	}
+	return nil, nil
+}
+
+// header returns the first bytes of the args' font data, which identify its compression.
+// It returns no bytes for a file that cannot be opened, so that FreeType reports the failure to open it.
+func (args OpenArgs) header() ([]byte, error) {
+	switch {
+	case args.Flags&OPEN_MEMORY != 0 && args.shared != nil:
+		return args.shared.bytes(), nil
+	case args.Flags&OPEN_MEMORY != 0:
+		return args.MemoryBase, nil
+	case args.Flags&OPEN_PATHNAME != 0:
+		file, err := os.Open(args.Pathname)
+		if err != nil {
+			return nil, nil
+		}
+		defer file.Close()
+		header := make([]byte, 3)
+		n, err := io.ReadFull(file, header)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, err
+		}
+		return header[:n], nil
+	}
+	return nil, nil
+}
+
+// OpenFlag is a list of bit field constants used within the Flags field of the OpenArgs structure.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-face_creation.html#ft_open_xxx
+type OpenFlag = UInt
+
+const (
+	OPEN_MEMORY   = OpenFlag(0x1)
+	OPEN_STREAM   = OpenFlag(0x2) // used internally
+	OPEN_PATHNAME = OpenFlag(0x4)
+	OPEN_DRIVER   = OpenFlag(0x8) // not supported
+	OPEN_PARAMS   = OpenFlag(0x10)
+)
 
 /*
 Parameter is a simple structure to pass more or less generic parameters to Library.OpenFace and Face.Properties.
@@ -268,6 +684,9 @@ func ParameterTagIgnoreTypoGraphicSubfamily(value *bool) Parameter {
 
 // ParameterTagIncremental creates a Parameter for the FT_PARAM_TAG_INCREMENTAL tag.
 //
+// Deprecated: FreeType expects the data of the FT_PARAM_TAG_INCREMENTAL tag to be an incremental interface,
+// not a boolean. Use OpenArgs.Incremental instead.
+//
 // https://freetype.org/freetype2/docs/reference/ft2-parameter_tags.html#ft_param_tag_incremental
 func ParameterTagIncremental(value *bool) Parameter {
 	return booleanParamTag(PARAM_TAG_INCREMENTAL, value)
@@ -2,6 +2,7 @@ package freetype
 
 import (
 	_ "embed"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,45 @@ func TestLibraryNewMemoryFace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLibraryNewMemoryFaceOwnsData(t *testing.T) {
+	lib, _ := Init()
+	want, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+
+	data := append([]byte(nil), font.DejaVuSans...)
+	face, err := lib.NewMemoryFace(data, 0)
+	assert.Nil(t, err)
+	assert.NotZero(t, face.resources.memory)
+
+	// The caller overwrites and drops its slice, and the garbage collector runs while memory is reused.
+	for i := range data {
+		data[i] = 0xFF
+	}
+	data = nil
+	for range 10 {
+		garbage := make([][]byte, 100)
+		for i := range garbage {
+			garbage[i] = make([]byte, len(font.DejaVuSans)/100)
+		}
+		runtime.GC()
+	}
+
+	assert.Nil(t, want.SetPixelSizes(0, 24))
+	assert.Nil(t, face.SetPixelSizes(0, 24))
+	for _, r := range "Hello,wörld!אב" {
+		for _, f := range []Face{want, face} {
+			assert.Nil(t, f.LoadChar(r, LOAD_DEFAULT))
+			assert.Nil(t, f.RenderGlyph(RENDER_MODE_NORMAL))
+		}
+		assert.Equal(t, want.Rec().Glyph.Rec().Metrics, face.Rec().Glyph.Rec().Metrics, string(r))
+		assert.Equal(t, want.Rec().Glyph.Rec().Bitmap.Buffer(), face.Rec().Glyph.Rec().Bitmap.Buffer(), string(r))
+	}
+
+	resources := face.resources
+	assert.Nil(t, face.Done())
+	assert.Zero(t, resources.memory)
+}
+
 func TestFaceProperies(t *testing.T) {
 	lib, _ := Init()
 	true_ := true
@@ -0,0 +1,70 @@
+package freetype
+
+import "runtime"
+
+// Enumeration of the faces and named instances of a font file.
+
+// FaceInfo describes a face of a font file, or a named instance of one of its faces.
+type FaceInfo struct {
+	// FaceIndex is the index of the face in the file, which is not 0 only for font collections.
+	FaceIndex int
+	// InstanceIndex is the index of the named instance, counted from 1, or 0 for the face itself.
+	InstanceIndex int
+
+	FamilyName     string
+	StyleName      string
+	PostscriptName string
+}
+
+/*
+EnumerateFaces returns the faces of font data, followed by the named instances of each face that is a variation font.
+The faces and instances may be opened with NewMemoryFaceInstance, using their FaceIndex and InstanceIndex.
+
+The data is copied once, with the library's memory manager,
+and the copy is shared by the faces that are opened to read the names.
+*/
+func (lib Library) EnumerateFaces(data []byte) ([]FaceInfo, error) {
+	defer runtime.KeepAlive(lib.owner)
+	shared, err := newSharedMemory(lib.tls, lib.memory(), data)
+	if err != nil {
+		return nil, err
+	}
+	defer shared.release(lib.tls)
+	open := func(faceIndex int, instanceIndex int) (Face, error) {
+		return lib.openFace(OpenArgs{Flags: OPEN_MEMORY, shared: shared},
+			instanceIndex<<16|faceIndex, "failed to open instance %d of face %d", instanceIndex, faceIndex)
+	}
+
+	var infos []FaceInfo
+	for faceIndex, numFaces := 0, 1; faceIndex < numFaces; faceIndex++ {
+		face, err := open(faceIndex, 0)
+		if err != nil {
+			return nil, err
+		}
+		numFaces = int(face.Rec().NumFaces)
+		// The number of named instances is in bits 16-30 of the style flags.
+		numInstances := int(face.Rec().StyleFlags>>16) & 0x7FFF
+		infos = append(infos, faceInfo(face, faceIndex, 0))
+		_ = face.Done()
+
+		for instanceIndex := 1; instanceIndex <= numInstances; instanceIndex++ {
+			instance, err := open(faceIndex, instanceIndex)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, faceInfo(instance, faceIndex, instanceIndex))
+			_ = instance.Done()
+		}
+	}
+	return infos, nil
+}
+
+func faceInfo(face Face, faceIndex int, instanceIndex int) FaceInfo {
+	return FaceInfo{
+		FaceIndex:      faceIndex,
+		InstanceIndex:  instanceIndex,
+		FamilyName:     face.Rec().FamilyName(),
+		StyleName:      face.Rec().StyleName(),
+		PostscriptName: face.GetPostscriptName(),
+	}
+}
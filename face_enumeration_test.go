@@ -0,0 +1,41 @@
+package freetype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestLibraryEnumerateFaces(t *testing.T) {
+	lib, _ := Init()
+
+	infos, err := lib.EnumerateFaces(font.RobotoVariable)
+	assert.NoError(t, err)
+	assert.Equal(t, 19, len(infos))
+	assert.Equal(t, FaceInfo{FamilyName: "Roboto", StyleName: "Regular", PostscriptName: "Roboto-Regular"}, infos[0])
+	assert.Equal(t, FaceInfo{
+		InstanceIndex:  3,
+		FamilyName:     "Roboto",
+		StyleName:      "Light",
+		PostscriptName: "Roboto-Light",
+	}, infos[3])
+
+	face, err := lib.NewMemoryFaceInstance(font.RobotoVariable, infos[3].FaceIndex, infos[3].InstanceIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, "Light", face.Rec().StyleName())
+
+	infos, err = lib.EnumerateFaces(font.DejaVuSans)
+	assert.NoError(t, err)
+	assert.Equal(t, []FaceInfo{{FamilyName: "DejaVu Sans", StyleName: "Book", PostscriptName: "DejaVuSans"}}, infos)
+
+	_, err = lib.EnumerateFaces([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestLibraryNewMemoryFaceInstanceOutOfRange(t *testing.T) {
+	lib, _ := Init()
+	_, err := lib.NewMemoryFaceInstance(font.RobotoVariable, 0, 0x8000)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
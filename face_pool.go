@@ -0,0 +1,192 @@
+package freetype
+
+import (
+	"runtime"
+	"sync"
+
+	"modernc.org/libfreetype"
+)
+
+// A pool of faces, for using a font from many goroutines.
+
+/*
+FacePool provides faces of a font for concurrent use.
+
+A Library and its faces must only be used by one goroutine at a time.
+A FacePool holds faces of the same font data, each with its own Library,
+and hands each of them out to one goroutine at a time.
+The faces share a single copy of the font data, held in C memory that is freed with the last of them,
+so each additional face only costs FreeType's own structures.
+Throughput scales with the number of goroutines using the pool, up to GOMAXPROCS.
+
+A face is returned to the pool in the state that it was left in by its previous user,
+so set its size, and any transformation, before using it.
+
+A FacePool is safe for concurrent use.
+*/
+type FacePool struct {
+	data      *sharedMemory
+	faceIndex int
+	setup     func(Face) error
+
+	mutex  sync.Mutex
+	idle   []pooledFace
+	inUse  map[libfreetype.TFT_Face]pooledFace
+	closed bool
+}
+
+type pooledFace struct {
+	lib  Library
+	face Face
+}
+
+/*
+NewFacePool returns a pool of faces for font data, as for Library.NewMemoryFace.
+
+The data is copied, so it may be modified after NewFacePool returns.
+If setup is not nil, it is called for each face that the pool creates, such as to set its size.
+
+A face is created, to check that the data is valid, and is kept in the pool.
+*/
+func NewFacePool(data []byte, faceIndex int, setup func(Face) error) (*FacePool, error) {
+	// The faces' libraries use FreeType's default memory manager, which allocates from the C heap,
+	// and the data outlives the library of any one face, so it is allocated from the C heap.
+	shared, err := newSharedMemory(nil, 0, data)
+	if err != nil {
+		return nil, err
+	}
+	pool := &FacePool{
+		data:      shared,
+		faceIndex: faceIndex,
+		setup:     setup,
+		inUse:     map[libfreetype.TFT_Face]pooledFace{},
+	}
+	pf, err := pool.newFace()
+	if err != nil {
+		pool.data.release(nil)
+		return nil, err
+	}
+	pool.idle = append(pool.idle, pf)
+	return pool, nil
+}
+
+// newFace creates a face, with its own library.
+func (pool *FacePool) newFace() (pooledFace, error) {
+	lib, err := Init()
+	if err != nil {
+		return pooledFace{}, err
+	}
+	face, err := lib.openFace(OpenArgs{Flags: OPEN_MEMORY, shared: pool.data}, pool.faceIndex,
+		"failed to create a face for a face pool")
+	if err == nil && pool.setup != nil {
+		err = pool.setup(face)
+	}
+	if err != nil {
+		_ = lib.Done()
+		lib.tls.Close()
+		return pooledFace{}, err
+	}
+	return pooledFace{lib: lib, face: face}, nil
+}
+
+/*
+Get returns a face for the exclusive use of the caller, until it is returned with Put.
+An idle face is returned if there is one, otherwise a new face is created.
+
+Get returns an error wrapping ErrInvalidHandle if the pool has been closed.
+*/
+func (pool *FacePool) Get() (Face, error) {
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		return Face{}, newError(Err_Invalid_Handle, "the face pool is closed")
+	}
+	if n := len(pool.idle); n > 0 {
+		pf := pool.idle[n-1]
+		pool.idle = pool.idle[:n-1]
+		pool.inUse[pf.face.face] = pf
+		pool.mutex.Unlock()
+		return pf.face, nil
+	}
+	pool.mutex.Unlock()
+
+	pf, err := pool.newFace()
+	if err != nil {
+		return Face{}, err
+	}
+	pool.mutex.Lock()
+	pool.inUse[pf.face.face] = pf
+	pool.mutex.Unlock()
+	return pf.face, nil
+}
+
+/*
+Put returns a face, that was returned by Get, to the pool.
+The face must not be used after it has been returned.
+
+At most GOMAXPROCS faces are kept idle, and any more are discarded,
+as are faces that are returned after the pool has been closed.
+Faces that were not returned by the pool's Get are ignored.
+*/
+func (pool *FacePool) Put(face Face) {
+	pool.mutex.Lock()
+	pf, ok := pool.inUse[face.face]
+	if !ok {
+		pool.mutex.Unlock()
+		return
+	}
+	delete(pool.inUse, face.face)
+	if !pool.closed && len(pool.idle) < runtime.GOMAXPROCS(0) {
+		pool.idle = append(pool.idle, pf)
+		pool.mutex.Unlock()
+		return
+	}
+	pool.mutex.Unlock()
+	pf.done()
+}
+
+// Do calls fn with a face from the pool, and returns the face to the pool when fn returns.
+func (pool *FacePool) Do(fn func(face Face) error) error {
+	face, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(face)
+	return fn(face)
+}
+
+/*
+Close discards the idle faces of the pool.
+Faces that are in use are discarded when they are returned with Put.
+*/
+func (pool *FacePool) Close() error {
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		return nil
+	}
+	idle := pool.idle
+	pool.idle = nil
+	pool.closed = true
+	pool.mutex.Unlock()
+	// The faces that are in use hold their own references to the data.
+	pool.data.release(nil)
+
+	var firstErr error
+	for _, pf := range idle {
+		if err := pf.done(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// done discards a pooled face, and its library.
+func (pf pooledFace) done() error {
+	err := pf.face.Done()
+	if libErr := pf.lib.Done(); err == nil {
+		err = libErr
+	}
+	pf.lib.tls.Close()
+	return err
+}
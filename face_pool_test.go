@@ -0,0 +1,114 @@
+package freetype
+
+import (
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func newFacePool(t testing.TB) *FacePool {
+	t.Helper()
+	pool, err := NewFacePool(font.DejaVuSans, 0, func(face Face) error {
+		return face.SetPixelSizes(0, 32)
+	})
+	assert.Nil(t, err)
+	return pool
+}
+
+// drawPoolString draws text with a face from a pool, and returns the image.
+func drawPoolString(pool *FacePool, text string) (*image.Alpha, error) {
+	dst := image.NewAlpha(image.Rect(0, 0, 300, 50))
+	err := pool.Do(func(face Face) error {
+		_, err := DrawString(dst, face, text, Vector{X: 0, Y: 40 << 6}, image.Opaque, DrawOptions{})
+		return err
+	})
+	return dst, err
+}
+
+func TestNewFacePoolInvalidData(t *testing.T) {
+	_, err := NewFacePool([]byte("not a font"), 0, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFacePoolGetPut(t *testing.T) {
+	pool := newFacePool(t)
+
+	face1, err := pool.Get()
+	assert.Nil(t, err)
+	face2, err := pool.Get()
+	assert.Nil(t, err)
+	// Each face has its own library.
+	assert.NotEqual(t, face1.face, face2.face)
+	assert.NotEqual(t, face1.tls, face2.tls)
+	assert.Equal(t, UShort(32), face2.Rec().Size.Rec().Metrics.Yppem)
+
+	pool.Put(face1)
+	face3, err := pool.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, face1, face3)
+
+	pool.Put(face2)
+	pool.Put(face3)
+	assert.Nil(t, pool.Close())
+	_, err = pool.Get()
+	assert.ErrorIs(t, err, ErrInvalidHandle)
+}
+
+func TestFacePoolSharedData(t *testing.T) {
+	pool := newFacePool(t)
+	face, err := pool.Get()
+	assert.Nil(t, err)
+	// The pool and its face each hold a reference to the data.
+	assert.Equal(t, 2, pool.data.refs)
+
+	// A face that is in use keeps the data alive after the pool is closed.
+	assert.Nil(t, pool.Close())
+	assert.Nil(t, pool.Close())
+	assert.Equal(t, 1, pool.data.refs)
+	dst := image.NewAlpha(image.Rect(0, 0, 300, 50))
+	_, err = DrawString(dst, face, "abc", Vector{X: 0, Y: 40 << 6}, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+
+	pool.Put(face)
+	assert.Equal(t, 0, pool.data.refs)
+	assert.Zero(t, pool.data.data)
+}
+
+func TestFacePoolConcurrent(t *testing.T) {
+	pool := newFacePool(t)
+	defer pool.Close()
+
+	want, err := drawPoolString(pool, "Concurrent")
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				got, err := drawPoolString(pool, "Concurrent")
+				assert.Nil(t, err)
+				assert.Equal(t, want.Pix, got.Pix)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkFacePoolDrawString(b *testing.B) {
+	pool := newFacePool(b)
+	defer pool.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := drawPoolString(pool, "The quick brown fox"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
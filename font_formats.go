@@ -0,0 +1,20 @@
+package freetype
+
+import (
+	"runtime"
+
+	"modernc.org/libc"
+	"modernc.org/libfreetype"
+)
+
+// Getting the font format.
+
+/*
+GetFontFormat returns the font format of a face, such as "TrueType", "CFF", "Type 1", or "PCF".
+
+https://freetype.org/freetype2/docs/reference/ft2-font_formats.html#ft_get_font_format
+*/
+func (face Face) GetFontFormat() string {
+	defer runtime.KeepAlive(face.owner)
+	return libc.GoString(libfreetype.XFT_Get_Font_Format(face.tls, face.handle()))
+}
@@ -0,0 +1,18 @@
+package freetype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestFaceGetFontFormat(t *testing.T) {
+	lib, err := Init()
+	assert.Nil(t, err)
+	face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "TrueType", face.GetFontFormat())
+}
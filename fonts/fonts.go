@@ -0,0 +1,313 @@
+/*
+Package fonts discovers the fonts that are installed on a system, and matches a description of a font,
+such as a family, weight, style, and language, to the best installed font.
+
+Directories are scanned for font files, and every face of each file is indexed,
+including the named instances of variable fonts.
+An Index can be saved to, and loaded from, a cache file,
+so that only files that have changed are opened when the directories are scanned again.
+*/
+package fonts
+
+import (
+	"cmp"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pekim/freetype"
+)
+
+// Font describes a face of a font file.
+type Font struct {
+	// Path is the path of the font file.
+	Path string
+	// Index is the face index to open the face with, as for freetype.Library.NewFace.
+	// For a named instance of a variable font, bits 16-30 hold the instance's index, starting from 1.
+	Index int
+
+	// Family is the font's family name, such as "DejaVu Sans".
+	Family string
+	// Families are all of the font's family names, including those for other languages.
+	Families []string
+	// Style is the font's style name, such as "Bold Italic".
+	Style string
+	// Weight is the font's weight, from 1 to 1000, where 400 is regular and 700 is bold.
+	Weight int
+	// Width is the font's width as a percentage of the normal width, from 50 (ultra-condensed) to 200 (ultra-expanded).
+	Width float64
+	// Italic reports whether the font is italic or oblique.
+	Italic bool
+	// Monospace reports whether all of the font's glyphs have the same width.
+	Monospace bool
+	// Variable reports whether the font has variation axes.
+	Variable bool
+	// Color reports whether the font has color glyphs.
+	Color bool
+	// Format is the font format, as returned by freetype.Face.GetFontFormat, such as "TrueType" or "CFF".
+	Format string
+	// Coverage are the characters that the font has glyphs for.
+	Coverage Coverage
+}
+
+// Coverage is a set of characters, as sorted ranges.
+type Coverage = freetype.Coverage
+
+// Range is a range of characters, from Lo to Hi inclusive.
+type Range = freetype.Range
+
+// fontExtensions are the file extensions of the font files that are opened when directories are scanned.
+var fontExtensions = map[string]bool{
+	".ttf": true, ".ttc": true, ".otf": true, ".otc": true,
+	".pfa": true, ".pfb": true, ".t1": true, ".cff": true,
+	".pcf": true, ".bdf": true, ".pfr": true, ".fon": true, ".fnt": true,
+	".woff": true, ".woff2": true,
+}
+
+/*
+DefaultDirs returns the directories that fonts are usually installed in, on the current operating system.
+Directories that do not exist are included, and are skipped when they are scanned.
+*/
+func DefaultDirs() []string {
+	var dirs []string
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "windows":
+		dirs = append(dirs, filepath.Join(os.Getenv("WINDIR"), "Fonts"))
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			dirs = append(dirs, filepath.Join(local, "Microsoft", "Windows", "Fonts"))
+		}
+	case "darwin", "ios":
+		dirs = append(dirs, "/System/Library/Fonts", "/Library/Fonts")
+		if home != "" {
+			dirs = append(dirs, filepath.Join(home, "Library", "Fonts"))
+		}
+	default:
+		dirs = append(dirs, "/usr/share/fonts", "/usr/local/share/fonts")
+		if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+			dirs = append(dirs, filepath.Join(dataHome, "fonts"))
+		} else if home != "" {
+			dirs = append(dirs, filepath.Join(home, ".local", "share", "fonts"))
+		}
+		if home != "" {
+			dirs = append(dirs, filepath.Join(home, ".fonts"))
+		}
+	}
+	return dirs
+}
+
+// Scan returns an index of the fonts in directories, and their subdirectories.
+func Scan(lib freetype.Library, dirs ...string) (*Index, error) {
+	index := &Index{}
+	return index, index.Update(lib, dirs...)
+}
+
+/*
+Update scans directories, and their subdirectories, for fonts.
+
+Files that have not changed since they were indexed are not opened again.
+Files that are no longer in the directories are removed from the index.
+Directories that do not exist are skipped, and files that are not fonts are ignored.
+*/
+func (index *Index) Update(lib freetype.Library, dirs ...string) error {
+	previous := map[string]file{}
+	for _, f := range index.files {
+		previous[f.Path] = f
+	}
+
+	var files []file
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if path == dir && os.IsNotExist(err) {
+					return nil
+				}
+				// Skip unreadable directories, rather than failing the whole scan.
+				if entry != nil && entry.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if entry.IsDir() || seen[path] || !isFontFile(path) {
+				return nil
+			}
+			seen[path] = true
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil
+			}
+			f := file{Path: path, Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+			if old, ok := previous[path]; ok && old.Size == f.Size && old.ModTime == f.ModTime {
+				files = append(files, old)
+				return nil
+			}
+			f.Fonts = scanFile(lib, path)
+			files = append(files, f)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	index.files = files
+	return nil
+}
+
+func isFontFile(path string) bool {
+	path = strings.ToLower(path)
+	path = strings.TrimSuffix(path, ".gz")
+	return fontExtensions[filepath.Ext(path)]
+}
+
+// scanFile returns the fonts of each face, and each named instance, of a file.
+// A file that cannot be opened has no fonts.
+func scanFile(lib freetype.Library, path string) []Font {
+	face, err := lib.NewFace(path, 0)
+	if err != nil {
+		return nil
+	}
+	numFaces := int(face.Rec().NumFaces)
+	_ = face.Done()
+
+	var fonts []Font
+	for faceIndex := 0; faceIndex < numFaces; faceIndex++ {
+		face, err := lib.NewFace(path, faceIndex)
+		if err != nil {
+			continue
+		}
+		fonts = append(fonts, describe(lib, face, path, faceIndex))
+		numInstances := int(face.Rec().StyleFlags >> 16)
+		_ = face.Done()
+
+		for instance := 1; instance <= numInstances; instance++ {
+			index := instance<<16 | faceIndex
+			face, err := lib.NewFace(path, index)
+			if err != nil {
+				continue
+			}
+			fonts = append(fonts, describe(lib, face, path, index))
+			_ = face.Done()
+		}
+	}
+	return fonts
+}
+
+// describe returns the description of a face.
+func describe(lib freetype.Library, face freetype.Face, path string, index int) Font {
+	rec := face.Rec()
+	font := Font{
+		Path:      path,
+		Index:     index,
+		Family:    rec.FamilyName(),
+		Style:     rec.StyleName(),
+		Weight:    400,
+		Width:     100,
+		Italic:    rec.StyleFlags&freetype.STYLE_FLAG_ITALIC != 0,
+		Monospace: face.IsFixedWidth(),
+		Variable:  face.HasMultipleMasters(),
+		Color:     face.HasColor(),
+		Format:    face.GetFontFormat(),
+		Coverage:  face.Coverage(),
+	}
+	if rec.StyleFlags&freetype.STYLE_FLAG_BOLD != 0 {
+		font.Weight = 700
+	}
+
+	if os2, err := face.OS2Table(); err == nil {
+		if os2.UsWeightClass >= 1 && os2.UsWeightClass <= 1000 {
+			font.Weight = int(os2.UsWeightClass)
+		}
+		if width, ok := os2.Width(); ok {
+			font.Width = width
+		}
+		// fsSelection bit 0 is italic, and bit 9 is oblique.
+		if os2.FsSelection&(1<<0|1<<9) != 0 {
+			font.Italic = true
+		}
+	}
+
+	if font.Variable && face.IsNamedInstance() {
+		describeInstance(lib, face, &font)
+	}
+
+	font.Families = familyNames(face)
+	if len(font.Families) > 0 && (font.Family == "" || !face.IsSfnt()) {
+		font.Family = font.Families[0]
+	}
+	if font.Family != "" && !slices.Contains(font.Families, font.Family) {
+		font.Families = append([]string{font.Family}, font.Families...)
+	}
+	return font
+}
+
+// describeInstance sets the weight, width, and slant of the font of a named instance from its coordinates.
+func describeInstance(lib freetype.Library, face freetype.Face, font *Font) {
+	mmvar, err := face.GetMMVar()
+	if err != nil {
+		return
+	}
+	defer lib.DoneMMVar(mmvar)
+
+	axes := mmvar.Axes()
+	if len(axes) == 0 {
+		return
+	}
+	coords, err := face.GetVarDesignCoordinates(freetype.UInt(len(axes)))
+	if err != nil {
+		return
+	}
+	for i, axis := range axes {
+		value := float64(coords[i]) / 0x10000
+		switch freetype.Tag(axis.Tag).String() {
+		case "wght":
+			font.Weight = int(value + 0.5)
+		case "wdth":
+			font.Width = value
+		case "ital":
+			font.Italic = value >= 0.5
+		case "slnt":
+			font.Italic = value != 0
+		}
+	}
+}
+
+/*
+familyNames returns the family names of a face's 'name' table, in every language.
+
+Typographic family names, that group more than the four styles of a traditional family,
+are listed before the other family names, and English names are listed before names in other languages.
+*/
+func familyNames(face freetype.Face) []string {
+	names := face.Names()
+	var families []string
+	for _, nameID := range []freetype.TT_NameID{freetype.NAME_ID_TYPOGRAPHIC_FAMILY, freetype.NAME_ID_FONT_FAMILY} {
+		langs := slices.Sorted(maps.Keys(names[nameID]))
+		// Stable sorting keeps the languages in order of their tags, after English.
+		slices.SortStableFunc(langs, func(a, b string) int {
+			return cmp.Compare(english(b), english(a))
+		})
+		for _, lang := range langs {
+			if value := names[nameID][lang]; value != "" && !slices.Contains(families, value) {
+				families = append(families, value)
+			}
+		}
+	}
+	return families
+}
+
+// english returns 1 for the tags of the English language, and 0 for other tags.
+func english(lang string) int {
+	if lang == "en" || strings.HasPrefix(lang, "en-") {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package fonts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+	"github.com/pekim/freetype/internal/font"
+)
+
+// fontDir returns a directory with the test fonts, one of them in a subdirectory, and a file that is not a font.
+func fontDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "dejavu"), 0o755))
+	for name, data := range map[string][]byte{
+		"Roboto.ttf":                font.RobotoVariable,
+		"dejavu/DejaVuSans.ttf":     font.DejaVuSans,
+		"dejavu/DejaVuSansMono.ttf": font.DejaVuSansMono,
+		"README.txt":                []byte("not a font"),
+		"dejavu/not-a-font.ttf":     []byte("not a font either"),
+	} {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), data, 0o644))
+	}
+	return dir
+}
+
+func scan(t *testing.T, dirs ...string) *Index {
+	t.Helper()
+	lib, err := freetype.Init()
+	assert.Nil(t, err)
+	index, err := Scan(lib, dirs...)
+	assert.Nil(t, err)
+	return index
+}
+
+func TestScan(t *testing.T) {
+	dir := fontDir(t)
+	index := scan(t, dir, filepath.Join(dir, "missing"))
+
+	byStyle := map[string]Font{}
+	paths := map[string]bool{}
+	for _, font := range index.Fonts() {
+		paths[filepath.Base(font.Path)] = true
+		byStyle[font.Family+" "+font.Style] = font
+	}
+	assert.Equal(t, map[string]bool{"Roboto.ttf": true, "DejaVuSans.ttf": true, "DejaVuSansMono.ttf": true}, paths)
+
+	sans := byStyle["DejaVu Sans Book"]
+	assert.Equal(t, 0, sans.Index)
+	assert.Equal(t, 400, sans.Weight)
+	assert.Equal(t, 100.0, sans.Width)
+	assert.False(t, sans.Italic)
+	assert.False(t, sans.Monospace)
+	assert.Equal(t, "TrueType", sans.Format)
+	assert.True(t, sans.Coverage.Has('א'))
+	assert.False(t, sans.Coverage.Has(0xE000))
+
+	assert.True(t, byStyle["DejaVu Sans Mono Book"].Monospace)
+
+	// The named instances of the variable font have their own weights and widths.
+	bold := byStyle["Roboto Bold"]
+	assert.True(t, bold.Variable)
+	assert.Equal(t, 700, bold.Weight)
+	assert.NotZero(t, bold.Index>>16)
+	assert.Equal(t, 0, bold.Index&0xFFFF)
+	assert.Equal(t, 75.0, byStyle["Roboto Condensed Bold"].Width)
+	assert.Equal(t, []string{"Roboto"}, bold.Families)
+}
+
+func TestIndexUpdate(t *testing.T) {
+	dir := fontDir(t)
+	index := scan(t, dir)
+	count := len(index.Fonts())
+
+	lib, err := freetype.Init()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Remove(filepath.Join(dir, "dejavu", "DejaVuSansMono.ttf")))
+	assert.Nil(t, index.Update(lib, dir))
+	assert.Equal(t, count-1, len(index.Fonts()))
+}
@@ -0,0 +1,123 @@
+package fonts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pekim/freetype"
+)
+
+// Index is an index of the fonts of the files in some directories.
+type Index struct {
+	files []file
+}
+
+// file is an indexed font file.
+// Its size and modification time are recorded, so that it is only opened again if it has changed.
+type file struct {
+	Path    string
+	Size    int64
+	ModTime int64
+	Fonts   []Font
+}
+
+// Fonts returns the indexed fonts, ordered by path and face index.
+func (index *Index) Fonts() []Font {
+	var fonts []Font
+	for _, f := range index.files {
+		fonts = append(fonts, f.Fonts...)
+	}
+	return fonts
+}
+
+/*
+CacheVersion is the version of the format of cache files.
+It is changed whenever the format, or the information that is indexed, changes.
+*/
+const CacheVersion = 2
+
+// ErrCacheVersion is returned by Load for a cache file that was saved with a different CacheVersion.
+var ErrCacheVersion = errors.New("font cache has a different version")
+
+// cache is the content of a cache file.
+type cache struct {
+	Version int
+	Files   []file
+}
+
+// Load returns an index that was saved to a cache file with Save.
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to read font cache %q: %w", path, err)
+	}
+	if c.Version != CacheVersion {
+		return nil, fmt.Errorf("%w: %q has version %d, not %d", ErrCacheVersion, path, c.Version, CacheVersion)
+	}
+	return &Index{files: c.Files}, nil
+}
+
+// Save saves an index to a cache file, creating its directory if it does not exist.
+// The file is replaced atomically, so that a concurrent Load never reads a partial file.
+func (index *Index) Save(path string) error {
+	data, err := json.Marshal(cache{Version: CacheVersion, Files: index.files})
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(dir, filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// DefaultCachePath returns the path of a cache file in the user's cache directory.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "github.com-pekim-freetype", "fonts.json"), nil
+}
+
+/*
+Open returns an index of the fonts in directories, using a cache file.
+
+The index is loaded from the cache file, if it exists and has the current version,
+and is then updated, so that only files that have changed are opened.
+The updated index is saved to the cache file.
+If dirs is empty, the DefaultDirs are scanned.
+*/
+func Open(lib freetype.Library, cachePath string, dirs ...string) (*Index, error) {
+	if len(dirs) == 0 {
+		dirs = DefaultDirs()
+	}
+	index, err := Load(cachePath)
+	if err != nil {
+		// A missing, corrupt, or old cache is rebuilt.
+		index = &Index{}
+	}
+	if err := index.Update(lib, dirs...); err != nil {
+		return nil, err
+	}
+	return index, index.Save(cachePath)
+}
@@ -0,0 +1,54 @@
+package fonts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+)
+
+func TestSaveLoad(t *testing.T) {
+	index := scan(t, fontDir(t))
+	path := filepath.Join(t.TempDir(), "cache", "fonts.json")
+
+	assert.Nil(t, index.Save(path))
+	loaded, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, index.Fonts(), loaded.Fonts())
+
+	assert.Nil(t, os.WriteFile(path, []byte(`{"Version": 0, "Files": []}`), 0o644))
+	_, err = Load(path)
+	assert.ErrorIs(t, err, ErrCacheVersion)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestOpen(t *testing.T) {
+	lib, err := freetype.Init()
+	assert.Nil(t, err)
+	dir := fontDir(t)
+	path := filepath.Join(t.TempDir(), "fonts.json")
+
+	index, err := Open(lib, path, dir)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, index.Fonts())
+
+	// Unchanged files are taken from the cache, rather than being opened again.
+	cached, err := Load(path)
+	assert.Nil(t, err)
+	cached.files[0].Fonts[0].Family = "Cached"
+	assert.Nil(t, cached.Save(path))
+	index, err = Open(lib, path, dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "Cached", index.Fonts()[0].Family)
+
+	// An old cache is rebuilt.
+	assert.Nil(t, os.WriteFile(path, []byte(`{"Version": 0}`), 0o644))
+	index, err = Open(lib, path, dir)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "Cached", index.Fonts()[0].Family)
+}
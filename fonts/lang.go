@@ -0,0 +1,84 @@
+package fonts
+
+import "strings"
+
+// langSamples are characters that a font must have to cover a language.
+// They are a few common letters of the language's script, and the letters that are particular to the language.
+var langSamples = map[string]string{
+	"en":      "aezAEZ",
+	"de":      "aezäöüßÄÖÜ",
+	"fr":      "aezàâçéèêëîïôùûüÿœ",
+	"es":      "aezáéíñóúü¿¡",
+	"pt":      "aezãõçáâêô",
+	"it":      "aezàèéìòù",
+	"nl":      "aez",
+	"pl":      "aeząćęłńóśźż",
+	"cs":      "aezčďěňřšťůž",
+	"tr":      "aezçğıİöşü",
+	"vi":      "aezăâđêôơưạảấầẩẫậ",
+	"ru":      "абвгдежзийклмнопрстуфхцчшщъыьэюяё",
+	"uk":      "абвгґдеєжзиіїйклмнопрстуфхцчшщьюя",
+	"bg":      "абвгдежзийклмнопрстуфхцчшщъьюя",
+	"sr":      "абвгдђежзијклљмнњопрстћуфхцчџш",
+	"el":      "αβγδεζηθικλμνξοπρστυφχψω",
+	"he":      "אבגדהוזחטיכלמנסעפצקרשת",
+	"ar":      "ابتثجحخدذرزسشصضطظعغفقكلمنهوي",
+	"fa":      "ابپتجچحخدرزژسشصعفقکگلمنوهی",
+	"ur":      "ابپتٹجچحخدڈرڑزژسشعغفقکگلمنںوہھیے",
+	"hi":      "अआइईउऊएऐओऔकखगघचछजझटठडढणतथदधनपफबभमयरलवशषसह्ािीुूेैोौं",
+	"mr":      "अआइईउऊएऐओऔकखगघचछजझटठडढणतथदधनपफबभमयरलवशषसहळ्ािीुूेैोौं",
+	"ne":      "अआइईउऊएऐओऔकखगघचछजझटठडढणतथदधनपफबभमयरलवशषसह्ािीुूेैोौं",
+	"bn":      "অআইঈউঊএঐওঔকখগঘঙচছজঝঞটঠডঢণতথদধনপফবভমযরলশষসহ্ািীুূেৈোৌং",
+	"ta":      "அஆஇஈஉஊஎஏஐஒஓகஙசஞடணதநபமயரலவழளறன்ாிீுூெேைொோ",
+	"th":      "กขคงจฉชซญดตถทนบปผพฟภมยรลวสหอฮะาำิีึืุู่้",
+	"ka":      "აბგდევზთიკლმნოპჟრსტუფქღყშჩცძწჭხჯჰ",
+	"hy":      "աբգդեզէըթժիլխծկհձղճմյնշոչպջռսվտրցւփքօֆ",
+	"am":      "ሀለሐመሠረሰሸቀበተቸኀነኘአከኸወዐዘዠየደጀገጠጨጰጸፀፈፐ",
+	"ja":      "あいうえおかきくけこアイウエオカキクケコ日本語漢字",
+	"ko":      "가나다라마바사아자차카타파하한국어",
+	"zh":      "的一是不了人我在有他这中大来上国个到说们",
+	"zh-hans": "的一是不了人我在有他这中大来上国个到说们",
+	"zh-hant": "的一是不了人我在有他這中大來上國個到說們",
+}
+
+// langAliases are the languages of the regions and scripts that have their own samples.
+var langAliases = map[string]string{
+	"zh-cn": "zh-hans",
+	"zh-sg": "zh-hans",
+	"zh-tw": "zh-hant",
+	"zh-hk": "zh-hant",
+	"zh-mo": "zh-hant",
+}
+
+/*
+covers reports whether a coverage has the sample characters of a language.
+
+The most specific of the language tag's prefixes that has samples is used,
+so that "zh-Hant-TW" uses the samples of "zh-hant", and "fr-CA" uses those of "fr".
+A language without samples is covered if the coverage has the samples of "en", a few letters of the Latin alphabet.
+*/
+func covers(coverage Coverage, lang string) bool {
+	samples, ok := "", false
+	tag := strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	for tag != "" && !ok {
+		if alias, isAlias := langAliases[tag]; isAlias {
+			tag = alias
+		}
+		samples, ok = langSamples[tag]
+		if i := strings.LastIndexByte(tag, '-'); i >= 0 {
+			tag = tag[:i]
+		} else {
+			tag = ""
+		}
+	}
+	if !ok {
+		samples = langSamples["en"]
+	}
+
+	for _, r := range samples {
+		if !coverage.Has(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package fonts
+
+import (
+	"math"
+	"strings"
+)
+
+// Pattern describes a font to match.
+type Pattern struct {
+	/*
+		Family is a family name, such as "DejaVu Sans",
+		or one of the generic families "sans-serif", "serif", "monospace", "emoji" or "system-ui".
+		If it is empty any family may be matched.
+	*/
+	Family string
+	// Weight is the weight, from 1 to 1000. If it is 0, the regular weight (400) is matched.
+	Weight int
+	// Width is the width as a percentage of the normal width. If it is 0, the normal width (100) is matched.
+	Width float64
+	// Italic selects an italic or oblique font.
+	Italic bool
+	// Lang is a BCP 47 language tag, such as "ja" or "zh-Hant", of the text that the font should cover.
+	Lang string
+}
+
+// genericFamilies are the families that generic family names match, in order of preference.
+var genericFamilies = map[string][]string{
+	"sans-serif": {
+		"DejaVu Sans", "Noto Sans", "Liberation Sans", "Roboto", "Open Sans", "Cantarell",
+		"Helvetica Neue", "Helvetica", "Arial", "Segoe UI", "Verdana",
+	},
+	"serif": {
+		"DejaVu Serif", "Noto Serif", "Liberation Serif", "Times New Roman", "Times", "Georgia",
+	},
+	"monospace": {
+		"DejaVu Sans Mono", "Noto Sans Mono", "Liberation Mono", "Ubuntu Mono",
+		"Menlo", "Consolas", "Courier New", "Courier",
+	},
+	"emoji": {
+		"Noto Color Emoji", "Apple Color Emoji", "Segoe UI Emoji", "Twemoji",
+	},
+	"system-ui": {
+		"Cantarell", "Ubuntu", "Noto Sans", "DejaVu Sans", "San Francisco", ".AppleSystemUIFont", "Segoe UI",
+	},
+}
+
+/*
+Match returns the font that best matches a pattern, and false if the index has no fonts.
+
+Fonts are ranked, in order, by
+  - whether one of their family names is the pattern's family, ignoring case,
+  - whether they cover the pattern's language,
+  - the preference of their family, for a generic family,
+  - whether they are italic, as the pattern is,
+  - the difference of their weight from the pattern's weight,
+  - and the difference of their width from the pattern's width.
+
+So a font of the requested family is matched even if it does not cover the language,
+but a generic family prefers a font that covers the language.
+*/
+func (index *Index) Match(pattern Pattern) (Font, bool) {
+	var best Font
+	var bestScore score
+	found := false
+	for _, f := range index.files {
+		for _, font := range f.Fonts {
+			s := pattern.score(font)
+			if !found || s.less(bestScore) {
+				best, bestScore, found = font, s, true
+			}
+		}
+	}
+	return best, found
+}
+
+// score is the ranking of a font for a pattern, in which smaller values are better matches.
+type score [6]float64
+
+func (s score) less(other score) bool {
+	for i := range s {
+		if s[i] != other[i] {
+			return s[i] < other[i]
+		}
+	}
+	return false
+}
+
+func (pattern Pattern) score(font Font) score {
+	var s score
+
+	family := strings.ToLower(strings.TrimSpace(pattern.Family))
+	generic, isGeneric := genericFamilies[family]
+	if family != "" && !isGeneric && !hasFamily(font, family) {
+		s[0] = 1
+	}
+
+	if pattern.Lang != "" && !covers(font.Coverage, pattern.Lang) {
+		s[1] = 1
+	}
+
+	if isGeneric {
+		s[2] = float64(len(generic) + 1)
+		for i, name := range generic {
+			if hasFamily(font, strings.ToLower(name)) {
+				s[2] = float64(i)
+				break
+			}
+		}
+		// Fonts that are not in a generic family's list may still have its characteristics.
+		if s[2] > float64(len(generic)) && ((family == "monospace" && font.Monospace) || (family == "emoji" && font.Color)) {
+			s[2] = float64(len(generic))
+		}
+	}
+
+	if font.Italic != pattern.Italic {
+		s[3] = 1
+	}
+
+	weight := pattern.Weight
+	if weight == 0 {
+		weight = 400
+	}
+	s[4] = math.Abs(float64(font.Weight - weight))
+
+	width := pattern.Width
+	if width == 0 {
+		width = 100
+	}
+	s[5] = math.Abs(font.Width - width)
+	return s
+}
+
+// hasFamily reports whether one of a font's family names is a lower case family name.
+func hasFamily(font Font, family string) bool {
+	for _, name := range font.Families {
+		if strings.ToLower(name) == family {
+			return true
+		}
+	}
+	return strings.ToLower(font.Family) == family
+}
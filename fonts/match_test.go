@@ -0,0 +1,58 @@
+package fonts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+)
+
+func TestMatch(t *testing.T) {
+	index := scan(t, fontDir(t))
+
+	for _, test := range []struct {
+		pattern Pattern
+		file    string
+		style   string
+	}{
+		{Pattern{Family: "Roboto", Weight: 700}, "Roboto.ttf", "Bold"},
+		{Pattern{Family: "roboto", Weight: 650, Width: 75}, "Roboto.ttf", "Condensed SemiBold"},
+		{Pattern{Family: "sans-serif"}, "DejaVuSans.ttf", "Book"},
+		// The family is preferred to the weight.
+		{Pattern{Family: "sans-serif", Weight: 700}, "DejaVuSans.ttf", "Book"},
+		{Pattern{Family: "monospace"}, "DejaVuSansMono.ttf", "Book"},
+		// A generic family prefers a font that covers the language.
+		{Pattern{Family: "sans-serif", Lang: "ru"}, "DejaVuSans.ttf", "Book"},
+		{Pattern{Lang: "he", Weight: 700}, "DejaVuSans.ttf", "Book"},
+		// A named family is preferred to the language.
+		{Pattern{Family: "Roboto", Lang: "he"}, "Roboto.ttf", "Regular"},
+		{Pattern{Family: "Unknown", Italic: true, Weight: 300}, "Roboto.ttf", "Light"},
+	} {
+		font, ok := index.Match(test.pattern)
+		assert.True(t, ok, test.pattern)
+		assert.Equal(t, test.file, filepath.Base(font.Path), test.pattern)
+		assert.Equal(t, test.style, font.Style, test.pattern)
+	}
+
+	_, ok := (&Index{}).Match(Pattern{Family: "sans-serif"})
+	assert.False(t, ok)
+}
+
+func TestCovers(t *testing.T) {
+	latin := Coverage{{Lo: 'A', Hi: 'Z'}, {Lo: 'a', Hi: 'z'}}
+	assert.True(t, covers(latin, "en-GB"))
+	assert.True(t, covers(latin, "xx"))
+	assert.False(t, covers(latin, "de"))
+	assert.False(t, covers(latin, "zh-Hant-TW"))
+	// Dutch is covered without the ĳ ligature, which fonts rarely have.
+	assert.True(t, covers(latin, "nl"))
+	// A language without samples needs all of the samples of English.
+	assert.False(t, covers(Coverage{{Lo: 'a', Hi: 'z'}}, "xx"))
+
+	traditional := freetype.CoverageOf(langSamples["zh-hant"])
+	assert.True(t, covers(traditional, "zh-TW"))
+	assert.True(t, covers(traditional, "zh-Hant-HK"))
+	assert.False(t, covers(traditional, "zh-CN"))
+}
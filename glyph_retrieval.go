@@ -1,6 +1,8 @@
 package freetype
 
 import (
+	"runtime"
+
 	"modernc.org/libfreetype"
 )
 
@@ -88,7 +90,8 @@ LoadGlyph loads a glyph into the glyph slot of a face object.
 https://freetype.org/freetype2/docs/reference/ft2-glyph_retrieval.html#ft_load_glyph
 */
 func (face Face) LoadGlyph(glyphIndex UInt, loadFlags Int32) error {
-	err := libfreetype.XFT_Load_Glyph(face.tls, face.face, glyphIndex, loadFlags)
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Load_Glyph(face.tls, face.handle(), glyphIndex, loadFlags)
 	return newError(err, "failed to load glyph index %d with flags %04x", glyphIndex, loadFlags)
 }
 
@@ -145,6 +148,7 @@ RenderGlyph convert a given glyph image to a bitmap.
 https://freetype.org/freetype2/docs/reference/ft2-glyph_retrieval.html#ft_render_glyph
 */
 func (face Face) RenderGlyph(renderMode RenderMode) error {
+	defer runtime.KeepAlive(face.owner)
 	glyph := face.Rec().Glyph
 	err := libfreetype.XFT_Render_Glyph(face.tls, libfreetype.TFT_GlyphSlot(glyph), renderMode)
 	return newError(err, "failed to render glyph with index %d for render mode %04x", glyph.Rec().GlyphIndex, renderMode)
@@ -174,8 +178,9 @@ Use HasKerning to find out whether a font has data that can be extracted with Ge
 https://freetype.org/freetype2/docs/reference/ft2-glyph_retrieval.html#ft_get_kerning
 */
 func (face Face) GetKerning(leftGlyph UInt, rightGlyph UInt, kernMode KerningMode) (Vector, error) {
+	defer runtime.KeepAlive(face.owner)
 	var kerning Vector
-	err := libfreetype.XFT_Get_Kerning(face.tls, face.face, leftGlyph, rightGlyph, kernMode, toUintptr(&kerning))
+	err := libfreetype.XFT_Get_Kerning(face.tls, face.handle(), leftGlyph, rightGlyph, kernMode, toUintptr(&kerning))
 	return kerning, newError(err, "failed to get kerning for %d and %d with kern mode %d", leftGlyph, rightGlyph, kernMode)
 }
 
@@ -198,7 +203,8 @@ GetTrackKerning returns the track kerning for a given face object at a given siz
 https://freetype.org/freetype2/docs/reference/ft2-glyph_retrieval.html#ft_get_track_kerning
 */
 func (face Face) GetTrackKerning(pointSize Fixed, degree Int) (Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
 	var kerning Fixed
-	err := libfreetype.XFT_Get_Track_Kerning(face.tls, face.face, pointSize, degree, toUintptr(&kerning))
+	err := libfreetype.XFT_Get_Track_Kerning(face.tls, face.handle(), pointSize, degree, toUintptr(&kerning))
 	return kerning, newError(err, "failed to get track kerning")
 }
@@ -1,9 +1,10 @@
 module github.com/pekim/freetype
 
-go 1.23.4
+go 1.24
 
 require (
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.26.0
 	modernc.org/libc v1.66.0
 	modernc.org/libfreetype v0.9.21
 )
@@ -0,0 +1,48 @@
+package freetype
+
+import (
+	"runtime"
+
+	"modernc.org/libc"
+	"modernc.org/libfreetype"
+)
+
+// Using gzip-compressed font files.
+
+// gzipMagic is the header that identifies gzip-compressed data.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// openGzipStream opens a new stream to parse gzip-compressed font files.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-gzip.html#ft_stream_opengzip
+func openGzipStream(tls *libc.TLS, stream stream, source stream) error {
+	err := libfreetype.XFT_Stream_OpenGzip(tls, libfreetype.TFT_Stream(stream), libfreetype.TFT_Stream(source))
+	return newError(err, "failed to open gzip stream")
+}
+
+/*
+GzipUncompress decompresses a zipped input buffer into an output buffer.
+Both zlib and gzip headers are detected automatically.
+
+The size of the output buffer must be large enough to hold the uncompressed data;
+outputSize is the maximum size of the uncompressed data.
+The returned slice is truncated to the actual size of the uncompressed data.
+
+https://freetype.org/freetype2/docs/reference/ft2-gzip.html#ft_gzip_uncompress
+*/
+func (lib Library) GzipUncompress(input []byte, outputSize int) ([]byte, error) {
+	defer runtime.KeepAlive(lib.owner)
+	if len(input) == 0 || outputSize <= 0 {
+		return nil, newError(Err_Invalid_Argument, "failed to gzip uncompress")
+	}
+
+	output := make([]byte, outputSize)
+	outputLen := ULong(outputSize)
+	err := libfreetype.XFT_Gzip_Uncompress(lib.tls, lib.memory(),
+		toUintptr(&output[0]), toUintptr(&outputLen),
+		toUintptr(&input[0]), ULong(len(input)))
+	if err != Err_Ok {
+		return nil, newError(err, "failed to gzip uncompress")
+	}
+	return output[:outputLen], nil
+}
@@ -0,0 +1,96 @@
+package freetype
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestLibraryNewMemoryFaceGzip(t *testing.T) {
+	lib, _ := Init()
+	face, err := lib.NewMemoryFace(gzipData(t, font.DejaVuSansMono), 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadChar('A', LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
+
+	err = face.Done()
+	assert.Nil(t, err)
+}
+
+func TestLibraryNewFaceGzip(t *testing.T) {
+	lib, _ := Init()
+	pathname := filepath.Join(t.TempDir(), "DejaVuSansMono.ttf.gz")
+	err := os.WriteFile(pathname, gzipData(t, font.DejaVuSansMono), 0o600)
+	assert.Nil(t, err)
+
+	face, err := lib.NewFace(pathname, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	err = face.Done()
+	assert.Nil(t, err)
+}
+
+func TestLibraryNewMemoryFaceBzip2(t *testing.T) {
+	lib, _ := Init()
+	data, err := os.ReadFile("testdata/DejaVuSansMono.ttf.bz2")
+	assert.Nil(t, err)
+
+	face, err := lib.NewMemoryFace(data, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadChar('A', LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
+	assert.Nil(t, face.Done())
+
+	_, err = lib.NewMemoryFace([]byte("BZh91AY&SY"), 0)
+	assert.ErrorIs(t, err, ErrInvalidStreamRead)
+}
+
+func TestLibraryNewFaceBzip2(t *testing.T) {
+	lib, _ := Init()
+	face, err := lib.NewFace("testdata/DejaVuSansMono.ttf.bz2", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	assert.Nil(t, face.Done())
+}
+
+func TestLibraryGzipUncompress(t *testing.T) {
+	lib, _ := Init()
+	data := []byte("some data to compress, and uncompress")
+
+	uncompressed, err := lib.GzipUncompress(gzipData(t, data), 1024)
+	assert.Nil(t, err)
+	assert.Equal(t, data, uncompressed)
+
+	// output too small
+	_, err = lib.GzipUncompress(gzipData(t, data), 8)
+	assert.Error(t, err)
+}
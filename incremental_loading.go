@@ -0,0 +1,175 @@
+package freetype
+
+import (
+	"sync"
+	"unsafe"
+
+	"modernc.org/libc"
+	"modernc.org/libfreetype"
+)
+
+// Custom interface used to incrementally load glyph data, and optionally metrics.
+//
+// This is useful for fonts embedded in documents (such as PDF or PostScript files),
+// where the glyph programs are not stored with the font's headers.
+
+/*
+IncrementalSource supplies glyph data to a face opened with OpenArgs.Incremental.
+
+GlyphData returns the glyph program for a glyph index.
+For TrueType fonts this is the glyph's data from the ‘glyf’ table,
+and for Type 1 fonts it is the glyph's charstring.
+The returned slice is copied, so it may be reused by the source once GlyphData has returned.
+
+If an error is returned that is an Error, its FTError value is reported to FreeType.
+Otherwise Err_Invalid_Glyph_Index is reported.
+
+https://freetype.org/freetype2/docs/reference/ft2-incremental.html#ft_incremental_getglyphdatafunc
+*/
+type IncrementalSource interface {
+	GlyphData(glyphIndex UInt) ([]byte, error)
+}
+
+/*
+IncrementalMetricsSource is an IncrementalSource that can also supply glyph metrics,
+overriding those found in the font's headers.
+
+GlyphMetrics is called with metrics initialized with the font's values, which may be modified.
+
+https://freetype.org/freetype2/docs/reference/ft2-incremental.html#ft_incremental_getglyphmetricsfunc
+*/
+type IncrementalMetricsSource interface {
+	IncrementalSource
+	GlyphMetrics(glyphIndex UInt, vertical bool, metrics *IncrementalMetrics) error
+}
+
+func init() {
+	assertSameSize(IncrementalMetrics{}, libfreetype.TFT_Incremental_MetricsRec{})
+}
+
+/*
+IncrementalMetrics is a small structure used to contain the basic glyph metrics returned by
+IncrementalMetricsSource. The values are expressed in font units.
+
+https://freetype.org/freetype2/docs/reference/ft2-incremental.html#ft_incremental_metricsrec
+*/
+type IncrementalMetrics struct {
+	BearingX Long
+	BearingY Long
+	Advance  Long
+	AdvanceV Long
+}
+
+// incrementalSources maps the object field of FT_Incremental_InterfaceRec to a source.
+var incrementalSources = struct {
+	sync.Mutex
+	sources map[uintptr]IncrementalSource
+	nextID  uintptr
+}{
+	sources: make(map[uintptr]IncrementalSource),
+}
+
+func incrementalSource(object libfreetype.TFT_Incremental) IncrementalSource {
+	incrementalSources.Lock()
+	defer incrementalSources.Unlock()
+	return incrementalSources.sources[object]
+}
+
+// incrementalInterface is an FT_Incremental_InterfaceRec, and the funcs that it references,
+// allocated in C memory so that it can outlive the call to FT_Open_Face.
+type incrementalInterface struct {
+	rec uintptr
+}
+
+// incrementalInterfaceRec is the memory layout of an incrementalInterface.
+type incrementalInterfaceRec struct {
+	iface libfreetype.TFT_Incremental_InterfaceRec
+	funcs libfreetype.TFT_Incremental_FuncsRec
+}
+
+func newIncrementalInterface(tls *libc.TLS, source IncrementalSource) *incrementalInterface {
+	incrementalSources.Lock()
+	incrementalSources.nextID++
+	id := incrementalSources.nextID
+	incrementalSources.sources[id] = source
+	incrementalSources.Unlock()
+
+	rec := fromUintptr[incrementalInterfaceRec](
+		libc.Xcalloc(tls, 1, libc.Tsize_t(unsafe.Sizeof(incrementalInterfaceRec{}))))
+	rec.funcs.Fget_glyph_data = __ccgo_fp(incrementalGetGlyphData)
+	rec.funcs.Ffree_glyph_data = __ccgo_fp(incrementalFreeGlyphData)
+	if _, ok := source.(IncrementalMetricsSource); ok {
+		rec.funcs.Fget_glyph_metrics = __ccgo_fp(incrementalGetGlyphMetrics)
+	}
+	rec.iface.Ffuncs = toUintptr(&rec.funcs)
+	rec.iface.Fobject = id
+
+	return &incrementalInterface{rec: toUintptr(rec)}
+}
+
+func (ii *incrementalInterface) done(tls *libc.TLS) {
+	rec := fromUintptr[incrementalInterfaceRec](ii.rec)
+	incrementalSources.Lock()
+	delete(incrementalSources.sources, rec.iface.Fobject)
+	incrementalSources.Unlock()
+
+	libc.Xfree(tls, ii.rec)
+	ii.rec = 0
+}
+
+// incrementalGetGlyphData is an FT_Incremental_GetGlyphDataFunc.
+// The data is copied to C memory, that is freed by incrementalFreeGlyphData.
+func incrementalGetGlyphData(tls *libc.TLS, object libfreetype.TFT_Incremental, glyphIndex UInt, adata uintptr) FTError {
+	source := incrementalSource(object)
+	if source == nil {
+		return Err_Invalid_Handle
+	}
+
+	data, err := source.GlyphData(glyphIndex)
+	if err != nil {
+		return ftErrorOf(err, Err_Invalid_Glyph_Index)
+	}
+
+	ftData := fromUintptr[libfreetype.TFT_Data](adata)
+	ftData.Fpointer = 0
+	ftData.Flength = 0
+	if len(data) > 0 {
+		ftData.Fpointer = libc.Xmalloc(tls, libc.Tsize_t(len(data)))
+		if ftData.Fpointer == 0 {
+			return Err_Out_Of_Memory
+		}
+		copy(unsafe.Slice(fromUintptr[byte](ftData.Fpointer), len(data)), data)
+		setDataLength(&ftData.Flength, len(data))
+	}
+	return Err_Ok
+}
+
+// setDataLength sets the length of an FT_Data,
+// which is an FT_UInt on some platforms and an FT_Int on others.
+func setDataLength[T ~int32 | ~uint32](length *T, n int) {
+	*length = T(n)
+}
+
+// incrementalFreeGlyphData is an FT_Incremental_FreeGlyphDataFunc.
+func incrementalFreeGlyphData(tls *libc.TLS, _ libfreetype.TFT_Incremental, data uintptr) {
+	ftData := fromUintptr[libfreetype.TFT_Data](data)
+	libc.Xfree(tls, ftData.Fpointer)
+	ftData.Fpointer = 0
+	ftData.Flength = 0
+}
+
+// incrementalGetGlyphMetrics is an FT_Incremental_GetGlyphMetricsFunc.
+func incrementalGetGlyphMetrics(
+	_ *libc.TLS, object libfreetype.TFT_Incremental, glyphIndex UInt, vertical Bool, ametrics uintptr,
+) FTError {
+	source, ok := incrementalSource(object).(IncrementalMetricsSource)
+	if !ok {
+		return Err_Invalid_Handle
+	}
+
+	metrics := fromUintptr[IncrementalMetrics](ametrics)
+	if err := source.GlyphMetrics(glyphIndex, vertical != 0, metrics); err != nil {
+		return ftErrorOf(err, Err_Invalid_Glyph_Index)
+	}
+	return Err_Ok
+}
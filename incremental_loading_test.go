@@ -0,0 +1,118 @@
+package freetype
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+// glyfSource is an IncrementalSource that serves glyph data from a face's 'glyf' table.
+type glyfSource struct {
+	loca      []uint32
+	glyf      []byte
+	requested []UInt
+	// failures are the errors that are returned for glyph indexes, instead of their data.
+	failures map[UInt]error
+}
+
+func newGlyfSource(t *testing.T, face Face) *glyfSource {
+	t.Helper()
+	loadTable := func(tag Tag) []byte {
+		buffer, err := face.TableBytes(tag)
+		assert.Nil(t, err)
+		return buffer
+	}
+
+	head, err := face.HeadTable()
+	assert.Nil(t, err)
+	longOffsets := head.IndexToLocFormat == 1
+
+	source := &glyfSource{glyf: loadTable(MakeTag("glyf"))}
+	locaTable := loadTable(MakeTag("loca"))
+	if longOffsets {
+		for i := 0; i < len(locaTable); i += 4 {
+			source.loca = append(source.loca, binary.BigEndian.Uint32(locaTable[i:]))
+		}
+	} else {
+		for i := 0; i < len(locaTable); i += 2 {
+			source.loca = append(source.loca, 2*uint32(binary.BigEndian.Uint16(locaTable[i:])))
+		}
+	}
+	return source
+}
+
+func (source *glyfSource) GlyphData(glyphIndex UInt) ([]byte, error) {
+	source.requested = append(source.requested, glyphIndex)
+	if err, ok := source.failures[glyphIndex]; ok {
+		return nil, err
+	}
+	if int(glyphIndex)+1 >= len(source.loca) {
+		return nil, newError(Err_Invalid_Glyph_Index, "no such glyph")
+	}
+	return source.glyf[source.loca[glyphIndex]:source.loca[glyphIndex+1]], nil
+}
+
+type glyfMetricsSource struct {
+	*glyfSource
+}
+
+func (source glyfMetricsSource) GlyphMetrics(_ UInt, _ bool, metrics *IncrementalMetrics) error {
+	metrics.Advance *= 2
+	return nil
+}
+
+func TestLibraryOpenFaceIncremental(t *testing.T) {
+	lib, _ := Init()
+	plainFace, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+	source := newGlyfSource(t, plainFace)
+
+	face, err := lib.OpenFace(OpenArgs{
+		Flags:       OPEN_MEMORY,
+		MemoryBase:  font.DejaVuSansMono,
+		Incremental: source,
+	}, 0)
+	assert.Nil(t, err)
+
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	glyphIndex := face.GetCharIndex('A')
+	err = face.LoadGlyph(glyphIndex, LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
+	assert.Contains(t, source.requested, glyphIndex)
+
+	// error from source
+	glyphIndex = face.GetCharIndex('B')
+	source.failures = map[UInt]error{glyphIndex: ErrCorruptedFontGlyphs}
+	err = face.LoadGlyph(glyphIndex, LOAD_DEFAULT)
+	assert.ErrorIs(t, err, ErrCorruptedFontGlyphs)
+	assert.Contains(t, source.requested, glyphIndex)
+
+	err = face.Done()
+	assert.Nil(t, err)
+}
+
+func TestLibraryOpenFaceIncrementalMetrics(t *testing.T) {
+	lib, _ := Init()
+	plainFace, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	face, err := lib.OpenFace(OpenArgs{
+		Flags:       OPEN_MEMORY,
+		MemoryBase:  font.DejaVuSansMono,
+		Incremental: glyfMetricsSource{newGlyfSource(t, plainFace)},
+	}, 0)
+	assert.Nil(t, err)
+
+	err = plainFace.LoadChar('A', LOAD_NO_SCALE)
+	assert.Nil(t, err)
+	err = face.LoadChar('A', LOAD_NO_SCALE)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		2*plainFace.Rec().Glyph.Rec().Metrics.HoriAdvance,
+		face.Rec().Glyph.Rec().Metrics.HoriAdvance)
+}
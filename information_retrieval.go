@@ -1,6 +1,8 @@
 package freetype
 
 import (
+	"runtime"
+
 	"modernc.org/libc"
 	"modernc.org/libfreetype"
 )
@@ -14,13 +16,14 @@ This only works for those faces where HasGlyphNames returns true.
 https://freetype.org/freetype2/docs/reference/ft2-information_retrieval.html#ft_get_name_index
 */
 func (face Face) GetNameIndex(glyphName string) (UInt, error) {
+	defer runtime.KeepAlive(face.owner)
 	cName, err := libc.CString(glyphName)
 	if err != nil {
 		return 0, err
 	}
 	defer libc.Xfree(nil, cName)
 
-	return libfreetype.XFT_Get_Name_Index(face.tls, face.face, cName), nil
+	return libfreetype.XFT_Get_Name_Index(face.tls, face.handle(), cName), nil
 }
 
 /*
@@ -30,8 +33,9 @@ This only works for those faces where HasGlyphNames returns true.
 https://freetype.org/freetype2/docs/reference/ft2-information_retrieval.html#ft_get_glyph_name
 */
 func (face Face) GetGlyphName(glyphIndex UInt) (string, error) {
+	defer runtime.KeepAlive(face.owner)
 	buffer := make([]byte, 128)
-	err := libfreetype.XFT_Get_Glyph_Name(face.tls, face.face, glyphIndex,
+	err := libfreetype.XFT_Get_Glyph_Name(face.tls, face.handle(), glyphIndex,
 		toUintptr(&buffer[0]), UInt(len(buffer)))
 	name := libc.GoString(toUintptr(&buffer[0]))
 	return name, newError(err, "failed to get glyph name for glyph index %d", glyphIndex)
@@ -43,7 +47,8 @@ GetPostscriptName retrieves the ASCII PostScript name of a given face, if availa
 https://freetype.org/freetype2/docs/reference/ft2-information_retrieval.html#ft_get_postscript_name
 */
 func (face Face) GetPostscriptName() string {
-	cName := libfreetype.XFT_Get_Postscript_Name(face.tls, face.face)
+	defer runtime.KeepAlive(face.owner)
+	cName := libfreetype.XFT_Get_Postscript_Name(face.tls, face.handle())
 	if cName == 0 {
 		return ""
 	}
@@ -56,7 +61,8 @@ GetFSTypeFlags returns the FSType flags for a font.
 https://freetype.org/freetype2/docs/reference/ft2-information_retrieval.html#ft_get_fstype_flags
 */
 func (face Face) GetFSTypeFlags() FSType {
-	return libfreetype.XFT_Get_FSType_Flags(face.tls, face.face)
+	defer runtime.KeepAlive(face.owner)
+	return libfreetype.XFT_Get_FSType_Flags(face.tls, face.handle())
 }
 
 /*
@@ -82,6 +88,7 @@ GetSubGlyphInfo retrieves a description of a given subglyph. Only use it if glyp
 https://freetype.org/freetype2/docs/reference/ft2-information_retrieval.html#ft_get_subglyph_info
 */
 func (face Face) GetSubGlyphInfo(glyph *GlyphSlotRec, subIndex UInt) (Int, SubglyphFlag, Int, Int, Matrix, error) {
+	defer runtime.KeepAlive(face.owner)
 	var index Int
 	var flags UInt
 	var arg1 Int
@@ -0,0 +1,34 @@
+package layout
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/pekim/freetype"
+)
+
+// Drawing of laid out text in to images from the standard library's image package.
+
+/*
+Draw draws a paragraph's glyphs in to an image.
+
+The origin is the position of the paragraph's top left corner in dst's coordinate space,
+in 26.6 fixed-point format (1/64 of a pixel).
+
+The glyphs' coverage is used as a mask to draw src, as for freetype.DrawString.
+Glyphs are loaded with the paragraph's LoadFlags.
+*/
+func (p *Paragraph) Draw(dst draw.Image, origin freetype.Vector, src image.Image, opts freetype.DrawOptions) error {
+	opts.LoadFlags = p.opts.LoadFlags
+	for _, line := range p.Lines {
+		for _, run := range line.Runs {
+			for _, glyph := range run.Glyphs {
+				position := freetype.Vector{X: origin.X + glyph.Position.X, Y: origin.Y + glyph.Position.Y}
+				if err := freetype.DrawGlyph(dst, run.Face, glyph.Index, position, src, opts); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
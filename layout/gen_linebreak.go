@@ -0,0 +1,159 @@
+//go:build ignore
+
+// gen_linebreak generates linebreak_table.go from LineBreak.txt of the Unicode Character Database.
+//
+// Usage:
+//
+//	go run gen_linebreak.go [LineBreak.txt]
+//
+// The file is downloaded from unicode.org if it is not given.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// unicodeVersion is the version of the Unicode Character Database, which is the version of the rules in linebreak.go.
+const unicodeVersion = "15.0.0"
+
+var url = "https://www.unicode.org/Public/" + unicodeVersion + "/ucd/LineBreak.txt"
+
+const maxRune = 0x10FFFF
+
+func main() {
+	data, err := readUCD()
+	if err != nil {
+		log.Fatal(err)
+	}
+	classes, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(classes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("linebreak_table.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readUCD() ([]byte, error) {
+	if len(os.Args) > 1 {
+		return os.ReadFile(os.Args[1])
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s : %s", url, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// parse returns the class of every code point.
+// The defaults of the @missing lines are applied first, then the classes of the data lines.
+func parse(data []byte) ([]string, error) {
+	classes := make([]string, maxRune+1)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if missing, ok := strings.CutPrefix(text, "# @missing:"); ok {
+			text = missing
+		} else if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		codePoints, class, ok := strings.Cut(text, ";")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing ';'", line)
+		}
+		lo, hi, err := parseRange(strings.TrimSpace(codePoints))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		for r := lo; r <= hi; r++ {
+			classes[r] = strings.TrimSpace(class)
+		}
+	}
+	return classes, scanner.Err()
+}
+
+func parseRange(s string) (int, int, error) {
+	loText, hiText, isRange := strings.Cut(s, "..")
+	lo, err := strconv.ParseUint(loText, 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return int(lo), int(lo), nil
+	}
+	hi, err := strconv.ParseUint(hiText, 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hi < lo || hi > maxRune {
+		return 0, 0, fmt.Errorf("invalid range %s", s)
+	}
+	return int(lo), int(hi), nil
+}
+
+// resolved returns the class of a code point in the table, or "" if it is omitted.
+func resolved(r int, class string) string {
+	if 0xAC00 <= r && r <= 0xD7A3 {
+		// Hangul syllables are H2 or H3 by their position in the block, so hangulSyllableClass is used.
+		return ""
+	}
+	switch class {
+	case "AI", "SG", "XX": // LB1
+		return ""
+	case "AL":
+		// AL is the class of code points that are not in the table.
+		return ""
+	}
+	return class
+}
+
+func generate(classes []string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_linebreak.go from %s. DO NOT EDIT.\n\n", url)
+	b.WriteString("package layout\n\n")
+	b.WriteString("// lineBreakClasses are the line breaking classes of characters, from the Unicode Character Database.\n")
+	b.WriteString("// Characters that are not in the table are AL, including those that rule LB1 resolves to AL.\n")
+	b.WriteString("// Hangul syllables are omitted, as their classes are derived from their position in their block.\n")
+	b.WriteString("// It is sorted, and its ranges do not overlap.\n")
+	b.WriteString("var lineBreakClasses = []classRange{\n")
+
+	lo, class := 0, resolved(0, classes[0])
+	for r := 1; r <= maxRune+1; r++ {
+		next := ""
+		if r <= maxRune {
+			next = resolved(r, classes[r])
+		}
+		if next == class {
+			continue
+		}
+		if class != "" {
+			fmt.Fprintf(&b, "\t{0x%04X, 0x%04X, class%s},\n", lo, r-1, class)
+		}
+		lo, class = r, next
+	}
+
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,696 @@
+/*
+Package layout arranges text in to lines of positioned glyphs, using faces from the freetype package.
+
+Lines are broken at the opportunities described by the Unicode Line Breaking Algorithm (UAX #14),
+optionally with hyphenation, and aligned within a maximum width.
+Bidirectional text is reordered within each line with the Unicode Bidirectional Algorithm (UAX #9).
+*/
+package layout
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pekim/freetype"
+	"github.com/pekim/freetype/bidi"
+)
+
+// Alignment is the horizontal alignment of the lines of a Paragraph.
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+	// AlignJustify stretches the spaces of each line to fill the maximum width,
+	// except for the last line and lines that end with a mandatory break, which are aligned to their start.
+	AlignJustify
+	// AlignStart aligns lines left in left-to-right paragraphs, and right in right-to-left paragraphs.
+	AlignStart
+	// AlignEnd aligns lines right in left-to-right paragraphs, and left in right-to-left paragraphs.
+	AlignEnd
+)
+
+// Options control the layout of text by Layout.
+// The zero value lays out left aligned text, that is only broken at mandatory breaks.
+type Options struct {
+	// MaxWidth is the maximum width of a line, in 26.6 fixed-point format.
+	// A value of 0 means that there is no maximum width.
+	MaxWidth freetype.Pos
+	// Align is the horizontal alignment of the lines.
+	Align Alignment
+	// LineSpacing is the distance between the baselines of lines, as a multiple of the height
+	// (SizeMetrics.Height) of the first face. A value of 0 is equivalent to 1.
+	LineSpacing float64
+	// LoadFlags are used to load glyphs, when measuring and drawing them.
+	LoadFlags freetype.LoadFlag
+	// NoKerning disables the kerning of pairs of glyphs.
+	NoKerning bool
+	// Hyphenate, if not nil, returns the byte offsets within a word at which it may be hyphenated.
+	// It is only called for words that do not fit on a line.
+	// Soft hyphens (U+00AD) in the text are always used as hyphenation points.
+	Hyphenate func(word string) []int
+	// Hyphen is the character that is displayed at the end of a hyphenated line.
+	// The zero value is a hyphen-minus ('-').
+	Hyphen rune
+	// Direction is the base direction of the text's paragraphs.
+	// The zero value, bidi.Auto, takes each paragraph's direction from its first strong character.
+	Direction bidi.Direction
+}
+
+func (opts Options) hyphen() rune {
+	if opts.Hyphen == 0 {
+		return '-'
+	}
+	return opts.Hyphen
+}
+
+func (opts Options) lineSpacing() float64 {
+	if opts.LineSpacing == 0 {
+		return 1
+	}
+	return opts.LineSpacing
+}
+
+// Glyph is a glyph positioned in a Paragraph.
+type Glyph struct {
+	// Index is the glyph's index in its run's face.
+	Index freetype.UInt
+	// Cluster is the byte offset in the text of the character that the glyph represents.
+	Cluster int
+	// Position is the position of the glyph's origin, relative to the paragraph's origin,
+	// in 26.6 fixed-point format. As for images the y axis points down.
+	Position freetype.Vector
+	// Advance is the glyph's horizontal advance, including any space added to justify its line.
+	Advance freetype.Pos
+}
+
+// Run is a sequence of glyphs in a line that use the same face, and have the same embedding level.
+// The glyphs are in visual order, from left to right, even if the run is right-to-left.
+type Run struct {
+	Face   freetype.Face
+	Glyphs []Glyph
+	// Level is the embedding level of the run's characters.
+	// Runs at odd levels are right-to-left.
+	Level bidi.Level
+}
+
+// Line is a line of a Paragraph.
+type Line struct {
+	// Runs are the line's runs, in visual order.
+	Runs []Run
+	// Start and End are the byte offsets in the text of the line's characters,
+	// including any trailing spaces and line break.
+	Start int
+	End   int
+	// Baseline is the y position of the line's baseline, relative to the paragraph's origin.
+	Baseline freetype.Pos
+	// X is the position of the start of the line, following alignment.
+	X freetype.Pos
+	// Width is the width of the line's glyphs, excluding trailing spaces.
+	Width freetype.Pos
+	// Hyphenated is true if the line ends with a hyphen, that was added to break a word.
+	Hyphenated bool
+	// Mandatory is true if the line ends with a mandatory break, such as a line feed.
+	Mandatory bool
+
+	// spaces are the positions in Runs of the spaces between words, that are stretched to justify the line.
+	spaces []glyphPosition
+	// rtl is true if the line is part of a right-to-left paragraph.
+	rtl bool
+}
+
+type glyphPosition struct {
+	run   int
+	glyph int
+}
+
+// Paragraph is some text that has been laid out in to lines.
+//
+// The paragraph's origin is its top left corner.
+// The first line's baseline is the first face's ascender below the origin.
+type Paragraph struct {
+	Lines []Line
+	// Width is the maximum width, or if there is no maximum width the width of the widest line.
+	Width freetype.Pos
+	// Height is the distance from the paragraph's origin to the bottom of the last line,
+	// as determined by the first face's descender.
+	Height freetype.Pos
+
+	opts Options
+}
+
+// item is a character of the text, and its glyph.
+type item struct {
+	r       rune
+	cluster int
+	face    freetype.Face
+	index   freetype.UInt
+	advance freetype.Pos
+	// kern is the adjustment to the distance from the previous glyph, for kerning and hinting.
+	kern freetype.Pos
+	// lsbDelta and rsbDelta are the glyph's hinting deltas.
+	lsbDelta freetype.Pos
+	rsbDelta freetype.Pos
+	space    bool
+	level    bidi.Level
+	// hidden items, such as line feeds and soft hyphens, have no glyph unless they end a line.
+	hidden bool
+}
+
+/*
+Layout arranges text in to lines.
+
+Glyphs are taken from the first of the faces that has a glyph for each character,
+and the faces are used at their current sizes.
+If none of the faces has a glyph for a character the first face's missing glyph is used.
+At least one face must be provided.
+
+Lines are broken in the text's logical order, and the characters of each line are then
+reordered in to visual order. Characters displayed right-to-left, such as brackets, use the glyph of their
+mirrored character if a face has one.
+*/
+func Layout(text string, opts Options, faces ...freetype.Face) (*Paragraph, error) {
+	if len(faces) == 0 {
+		return nil, freetype.ErrInvalidArgument
+	}
+
+	l := layouter{
+		opts:    opts,
+		faces:   faces,
+		metrics: make(map[glyphKey]glyphMetrics),
+		bidi:    bidi.Analyze(text, opts.Direction),
+	}
+	items, err := l.items(text)
+	if err != nil {
+		return nil, err
+	}
+	breaks := l.breaks(text, items)
+	lines, err := l.lines(text, items, breaks)
+	if err != nil {
+		return nil, err
+	}
+
+	paragraph := &Paragraph{Lines: lines, opts: opts}
+	l.position(paragraph)
+	return paragraph, nil
+}
+
+type glyphKey struct {
+	face  freetype.Face
+	index freetype.UInt
+}
+
+type glyphMetrics struct {
+	advance  freetype.Pos
+	lsbDelta freetype.Pos
+	rsbDelta freetype.Pos
+}
+
+type layouter struct {
+	opts    Options
+	faces   []freetype.Face
+	metrics map[glyphKey]glyphMetrics
+	// bidi has the embedding levels of the text's characters, which are indexed in the same way as items.
+	bidi *bidi.Text
+}
+
+// glyph returns the face and glyph index to use for a character.
+func (l *layouter) glyph(r rune) (freetype.Face, freetype.UInt) {
+	for _, face := range l.faces {
+		if index := face.GetCharIndex(r); index != 0 {
+			return face, index
+		}
+	}
+	return l.faces[0], 0
+}
+
+func (l *layouter) glyphMetrics(face freetype.Face, index freetype.UInt) (glyphMetrics, error) {
+	key := glyphKey{face: face, index: index}
+	if metrics, ok := l.metrics[key]; ok {
+		return metrics, nil
+	}
+
+	if err := face.LoadGlyph(index, l.opts.LoadFlags|freetype.LOAD_IGNORE_TRANSFORM); err != nil {
+		return glyphMetrics{}, err
+	}
+	glyph := face.Rec().Glyph.Rec()
+	metrics := glyphMetrics{advance: glyph.Advance.X, lsbDelta: glyph.LsbDelta, rsbDelta: glyph.RsbDelta}
+	l.metrics[key] = metrics
+	return metrics, nil
+}
+
+// mirroredGlyph returns the face and glyph index to use for a character displayed right-to-left.
+// The glyph of the mirrored character is used, if any of the faces has one.
+func (l *layouter) mirroredGlyph(r rune) (freetype.Face, freetype.UInt) {
+	if mirror, ok := bidi.Mirror(r); ok {
+		if face, index := l.glyph(mirror); index != 0 {
+			return face, index
+		}
+	}
+	return l.glyph(r)
+}
+
+// newItem returns an item for a character at an embedding level, with its glyph's metrics.
+func (l *layouter) newItem(r rune, cluster int, level bidi.Level) (item, error) {
+	it := item{r: r, cluster: cluster, space: isSpace(r), hidden: isHardBreakChar(r) || r == 0x00AD, level: level}
+	if r == '\t' {
+		r = ' '
+	}
+	if level.RightToLeft() {
+		it.face, it.index = l.mirroredGlyph(r)
+	} else {
+		it.face, it.index = l.glyph(r)
+	}
+	metrics, err := l.glyphMetrics(it.face, it.index)
+	if err != nil {
+		return item{}, err
+	}
+	if !it.hidden {
+		it.advance = metrics.advance
+	}
+	it.lsbDelta = metrics.lsbDelta
+	it.rsbDelta = metrics.rsbDelta
+	return it, nil
+}
+
+// items returns the text's characters, with their glyphs.
+func (l *layouter) items(text string) ([]item, error) {
+	items := make([]item, 0, utf8.RuneCountInString(text))
+	levels := l.bidi.Levels()
+	for cluster, r := range text {
+		it, err := l.newItem(r, cluster, levels[len(items)])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+
+	var previous *item
+	for i := range items {
+		it := &items[i]
+		if it.hidden {
+			continue
+		}
+		if previous != nil {
+			left, right := *previous, *it
+			if left.level == right.level && left.level.RightToLeft() {
+				left, right = right, left
+			}
+			kern, err := l.kerning(left, right)
+			if err != nil {
+				return nil, err
+			}
+			it.kern = kern
+		}
+		previous = it
+	}
+	return items, nil
+}
+
+// kerning returns the adjustment of the distance between two adjacent glyphs,
+// for kerning and for hinting corrections.
+func (l *layouter) kerning(left item, right item) (freetype.Pos, error) {
+	if left.face != right.face {
+		return 0, nil
+	}
+
+	var kern freetype.Pos
+	if !l.opts.NoKerning && left.face.HasKerning() && left.index != 0 && right.index != 0 {
+		vector, err := left.face.GetKerning(left.index, right.index, freetype.KERNING_DEFAULT)
+		if err != nil {
+			return 0, err
+		}
+		kern = vector.X
+	}
+
+	if left.rsbDelta-right.lsbDelta > 32 {
+		kern -= 64
+	} else if left.rsbDelta-right.lsbDelta < -31 {
+		kern += 64
+	}
+	return kern, nil
+}
+
+// breakOpportunity is a position between items at which a line may be broken.
+type breakOpportunity struct {
+	// item is the index of the first item following the break.
+	item      int
+	mandatory bool
+}
+
+// breaks returns the break opportunities, indexed by the items that follow them.
+func (l *layouter) breaks(text string, items []item) []breakOpportunity {
+	itemIndexes := make(map[int]int, len(items)+1)
+	for i, it := range items {
+		itemIndexes[it.cluster] = i
+	}
+	itemIndexes[len(text)] = len(items)
+
+	lineBreaks := LineBreaks(text)
+	breaks := make([]breakOpportunity, 0, len(lineBreaks))
+	for _, b := range lineBreaks {
+		breaks = append(breaks, breakOpportunity{item: itemIndexes[b.Index], mandatory: b.Mandatory})
+	}
+	return breaks
+}
+
+// width returns the width of a sequence of items when they start a line, excluding trailing spaces.
+// If hyphen is not nil, its advance is added.
+func width(items []item, hyphen *item) freetype.Pos {
+	end := len(items)
+	for end > 0 && (items[end-1].space || items[end-1].hidden) {
+		end--
+	}
+
+	var w freetype.Pos
+	for i, it := range items[:end] {
+		if i > 0 {
+			w += it.kern
+		}
+		w += it.advance
+	}
+	if hyphen != nil {
+		w += hyphen.advance
+	}
+	return w
+}
+
+// hyphenItem returns an item for the hyphen added to the end of a line, after a given item.
+func (l *layouter) hyphenItem(after item) (item, error) {
+	return l.newItem(l.opts.hyphen(), after.cluster, after.level)
+}
+
+// fits reports whether a line fits within the maximum width.
+func (l *layouter) fits(items []item, hyphen *item) bool {
+	return l.opts.MaxWidth <= 0 || width(items, hyphen) <= l.opts.MaxWidth
+}
+
+func (l *layouter) lines(text string, items []item, breaks []breakOpportunity) ([]Line, error) {
+	var lines []Line
+	start := 0
+	fit := -1 // the index in breaks of the last break that fits on the current line
+
+	newLine := func(end int, hyphen *item, mandatory bool) error {
+		line := Line{Start: itemCluster(items, start, len(text)), End: itemCluster(items, end, len(text))}
+		line.Mandatory = mandatory && end > start && isHardBreakChar(items[end-1].r)
+		line.Hyphenated = hyphen != nil
+		line.rtl = l.paragraphLevel(start).RightToLeft()
+
+		lineItems := append([]item(nil), items[start:end]...)
+		levels := l.bidi.LineLevels(start, end)
+		if hyphen != nil {
+			lineItems = append(lineItems, *hyphen)
+			levels = append(levels, hyphen.level)
+		}
+		var err error
+		line.Runs, line.spaces, line.Width, err = l.runs(lineItems, levels)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, line)
+		start = end
+		return nil
+	}
+
+	for b := 0; b < len(breaks); b++ {
+		brk := breaks[b]
+		if brk.item <= start {
+			continue
+		}
+
+		shy, err := l.softHyphen(items, brk.item)
+		if err != nil {
+			return nil, err
+		}
+
+		if l.fits(items[start:brk.item], shy) {
+			if brk.mandatory {
+				if err := newLine(brk.item, nil, true); err != nil {
+					return nil, err
+				}
+				fit = -1
+			} else {
+				fit = b
+			}
+			continue
+		}
+
+		// The text up to this break does not fit.
+		// Try to hyphenate the word that overflows, then break at the last break that fits.
+		wordStart := start
+		if fit >= 0 {
+			wordStart = breaks[fit].item
+		}
+		hyphenAt, hyphen, err := l.hyphenate(text, items, start, wordStart, brk.item)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case hyphenAt > 0:
+			err = newLine(hyphenAt, hyphen, false)
+		case fit >= 0:
+			var shy *item
+			shy, err = l.softHyphen(items, breaks[fit].item)
+			if err == nil {
+				err = newLine(breaks[fit].item, shy, false)
+			}
+		default:
+			// A single word is too wide, so break it wherever it overflows.
+			end := start + 1
+			for end < brk.item && l.fits(items[start:end+1], nil) {
+				end++
+			}
+			err = newLine(end, nil, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fit = -1
+		// Consider the current break again, for the next line.
+		b--
+	}
+
+	return lines, nil
+}
+
+// paragraphLevel returns the embedding level of the paragraph containing the item at index i.
+func (l *layouter) paragraphLevel(i int) bidi.Level {
+	n := len(l.bidi.Runes())
+	switch {
+	case i < n:
+		return l.bidi.ParagraphLevel(i)
+	case n > 0:
+		return l.bidi.ParagraphLevel(n - 1)
+	case l.opts.Direction == bidi.RightToLeft:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func itemCluster(items []item, i int, textLen int) int {
+	if i < len(items) {
+		return items[i].cluster
+	}
+	return textLen
+}
+
+// softHyphen returns a hyphen item if a break before an item follows a soft hyphen.
+func (l *layouter) softHyphen(items []item, i int) (*item, error) {
+	if i == 0 || items[i-1].r != 0x00AD {
+		return nil, nil
+	}
+	hyphen, err := l.hyphenItem(items[i-1])
+	if err != nil {
+		return nil, err
+	}
+	return &hyphen, nil
+}
+
+/*
+hyphenate finds the last hyphenation point in the word that starts at wordStart,
+and that ends at or before wordEnd, such that the line fits when hyphenated there.
+It returns the index of the item following the hyphenation point, and the hyphen's item.
+It returns an index of 0 if the word cannot be hyphenated.
+*/
+func (l *layouter) hyphenate(text string, items []item, lineStart, wordStart, wordEnd int) (int, *item, error) {
+	if l.opts.Hyphenate == nil {
+		return 0, nil, nil
+	}
+
+	// Only the letters of the word are hyphenated, excluding any leading or trailing punctuation and spaces.
+	for wordStart < wordEnd && !unicode.IsLetter(items[wordStart].r) {
+		wordStart++
+	}
+	end := wordStart
+	for end < wordEnd && (unicode.IsLetter(items[end].r) || unicode.In(items[end].r, unicode.Mn, unicode.Mc)) {
+		end++
+	}
+	if end-wordStart < 2 {
+		return 0, nil, nil
+	}
+
+	word := text[items[wordStart].cluster:itemCluster(items, end, len(text))]
+	points := l.opts.Hyphenate(word)
+	for p := len(points) - 1; p >= 0; p-- {
+		point := points[p]
+		if point <= 0 || point >= len(word) {
+			continue
+		}
+		i := wordStart
+		for i < end && items[i].cluster-items[wordStart].cluster < point {
+			i++
+		}
+		if i <= lineStart || i >= end {
+			continue
+		}
+
+		hyphen, err := l.hyphenItem(items[i-1])
+		if err != nil {
+			return 0, nil, err
+		}
+		if l.fits(items[lineStart:i], &hyphen) {
+			return i, &hyphen, nil
+		}
+	}
+	return 0, nil, nil
+}
+
+/*
+runs reorders a line's items in to visual order, using their embedding levels,
+and groups them in to runs of glyphs with the same face and level.
+The glyphs are positioned relative to the start of the line's baseline.
+
+It also returns the positions of the spaces between words, and the width of the line.
+Trailing spaces are excluded from the width, and in right-to-left paragraphs,
+where they are at the start of the line, they are positioned before the start.
+*/
+func (l *layouter) runs(items []item, levels []bidi.Level) ([]Run, []glyphPosition, freetype.Pos, error) {
+	var runs []Run
+	var spaces []glyphPosition
+	var x, lineWidth freetype.Pos
+
+	// Trailing spaces are not stretched when justifying.
+	trailing := len(items)
+	for trailing > 0 && (items[trailing-1].space || items[trailing-1].hidden) {
+		trailing--
+	}
+
+	order := bidi.VisualOrder(levels)
+	for _, i := range order {
+		if i < trailing {
+			break
+		}
+		x -= items[i].advance
+	}
+
+	var previous *item
+	for _, i := range order {
+		it := &items[i]
+		if it.hidden {
+			continue
+		}
+		if previous != nil {
+			kern, err := l.kerning(*previous, *it)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			x += kern
+		}
+		previous = it
+
+		if len(runs) == 0 || runs[len(runs)-1].Face != it.face || runs[len(runs)-1].Level != levels[i] {
+			runs = append(runs, Run{Face: it.face, Level: levels[i]})
+		}
+		run := &runs[len(runs)-1]
+		if it.space && i < trailing && x > 0 {
+			spaces = append(spaces, glyphPosition{run: len(runs) - 1, glyph: len(run.Glyphs)})
+		}
+		run.Glyphs = append(run.Glyphs, Glyph{
+			Index:    it.index,
+			Cluster:  it.cluster,
+			Position: freetype.Vector{X: x},
+			Advance:  it.advance,
+		})
+		x += it.advance
+		if i < trailing {
+			lineWidth = x
+		}
+	}
+	return runs, spaces, lineWidth, nil
+}
+
+// position sets the positions of a paragraph's lines, and aligns them.
+func (l *layouter) position(paragraph *Paragraph) {
+	metrics := l.faces[0].Rec().Size.Rec().Metrics
+	lineHeight := freetype.Pos(float64(metrics.Height) * l.opts.lineSpacing())
+
+	paragraph.Width = l.opts.MaxWidth
+	if paragraph.Width <= 0 {
+		for _, line := range paragraph.Lines {
+			paragraph.Width = max(paragraph.Width, line.Width)
+		}
+	}
+	if len(paragraph.Lines) > 0 {
+		paragraph.Height = metrics.Ascender + freetype.Pos(len(paragraph.Lines)-1)*lineHeight - metrics.Descender
+	}
+
+	for n := range paragraph.Lines {
+		line := &paragraph.Lines[n]
+		line.Baseline = metrics.Ascender + freetype.Pos(n)*lineHeight
+
+		extra := max(0, paragraph.Width-line.Width)
+		align := l.opts.Align
+		if align == AlignJustify {
+			last := n == len(paragraph.Lines)-1
+			if !last && !line.Mandatory && len(line.spaces) > 0 {
+				line.justify(extra)
+			} else {
+				align = AlignStart
+			}
+		}
+		switch {
+		case align == AlignRight,
+			align == AlignStart && line.rtl,
+			align == AlignEnd && !line.rtl:
+			line.X = extra
+		case align == AlignCenter:
+			line.X = extra / 2
+		}
+
+		for r := range line.Runs {
+			for g := range line.Runs[r].Glyphs {
+				glyph := &line.Runs[r].Glyphs[g]
+				glyph.Position.X += line.X
+				glyph.Position.Y = line.Baseline
+			}
+		}
+	}
+}
+
+// justify distributes extra space between the spaces of a line.
+func (line *Line) justify(extra freetype.Pos) {
+	perSpace := extra / freetype.Pos(len(line.spaces))
+	remainder := extra % freetype.Pos(len(line.spaces))
+
+	var shift freetype.Pos
+	next := 0
+	for r := range line.Runs {
+		for g := range line.Runs[r].Glyphs {
+			glyph := &line.Runs[r].Glyphs[g]
+			glyph.Position.X += shift
+			if next < len(line.spaces) && line.spaces[next] == (glyphPosition{run: r, glyph: g}) {
+				stretch := perSpace
+				if freetype.Pos(next) < remainder {
+					stretch++
+				}
+				glyph.Advance += stretch
+				shift += stretch
+				next++
+			}
+		}
+	}
+	line.Width += extra
+}
@@ -0,0 +1,265 @@
+package layout
+
+import (
+	"image"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+	"github.com/pekim/freetype/bidi"
+	"github.com/pekim/freetype/internal/font"
+)
+
+func newFace(t *testing.T, data []byte) freetype.Face {
+	t.Helper()
+	lib, err := freetype.Init()
+	assert.Nil(t, err)
+	face, err := lib.NewMemoryFace(data, 0)
+	assert.Nil(t, err)
+	err = face.SetPixelSizes(0, 16)
+	assert.Nil(t, err)
+	return face
+}
+
+// lineTexts returns the text of each line, excluding trailing spaces and line breaks.
+func lineTexts(text string, paragraph *Paragraph) []string {
+	var texts []string
+	for _, line := range paragraph.Lines {
+		lineText := strings.TrimRight(text[line.Start:line.End], " \n­")
+		if line.Hyphenated {
+			lineText += "-"
+		}
+		texts = append(texts, lineText)
+	}
+	return texts
+}
+
+func lineEnd(line Line) freetype.Pos {
+	lastRun := line.Runs[len(line.Runs)-1]
+	lastGlyph := lastRun.Glyphs[len(lastRun.Glyphs)-1]
+	return lastGlyph.Position.X + lastGlyph.Advance
+}
+
+func TestLayoutWrapsAtMaxWidth(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	text := "The quick brown fox jumps over the lazy dog."
+
+	paragraph, err := Layout(text, Options{MaxWidth: 100 * 64}, face)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"The quick", "brown fox", "jumps over", "the lazy", "dog."}, lineTexts(text, paragraph))
+
+	metrics := face.Rec().Size.Rec().Metrics
+	for n, line := range paragraph.Lines {
+		assert.LessOrEqual(t, line.Width, freetype.Pos(100*64))
+		assert.Equal(t, metrics.Ascender+freetype.Pos(n)*metrics.Height, line.Baseline)
+		assert.Equal(t, freetype.Pos(0), line.Runs[0].Glyphs[0].Position.X)
+	}
+	assert.Equal(t, freetype.Pos(100*64), paragraph.Width)
+	assert.Equal(t, metrics.Ascender+4*metrics.Height-metrics.Descender, paragraph.Height)
+
+	// Each line is as long as possible.
+	for n, line := range paragraph.Lines[:len(paragraph.Lines)-1] {
+		nextWord := strings.Fields(text[paragraph.Lines[n+1].Start:])[0]
+		longer, err := Layout(text[line.Start:line.End]+nextWord, Options{}, face)
+		assert.Nil(t, err)
+		assert.Greater(t, longer.Width, freetype.Pos(100*64))
+	}
+}
+
+func TestLayoutMandatoryBreaks(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	text := "one\ntwo\n\nthree"
+
+	paragraph, err := Layout(text, Options{LineSpacing: 1.5}, face)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two", "", "three"}, lineTexts(text, paragraph))
+	assert.True(t, paragraph.Lines[0].Mandatory)
+	assert.False(t, paragraph.Lines[3].Mandatory)
+
+	height := face.Rec().Size.Rec().Metrics.Height
+	assert.Equal(t, freetype.Pos(float64(height)*1.5), paragraph.Lines[1].Baseline-paragraph.Lines[0].Baseline)
+}
+
+func TestLayoutAlignment(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	text := "The quick brown fox jumps over the lazy dog."
+	maxWidth := freetype.Pos(100 * 64)
+
+	right, err := Layout(text, Options{MaxWidth: maxWidth, Align: AlignRight}, face)
+	assert.Nil(t, err)
+	for _, line := range right.Lines {
+		assert.Equal(t, maxWidth, line.X+line.Width)
+	}
+
+	center, err := Layout(text, Options{MaxWidth: maxWidth, Align: AlignCenter}, face)
+	assert.Nil(t, err)
+	for _, line := range center.Lines {
+		assert.InDelta(t, maxWidth-line.Width, 2*line.X, 1)
+	}
+
+	justified, err := Layout(text, Options{MaxWidth: maxWidth, Align: AlignJustify}, face)
+	assert.Nil(t, err)
+	for _, line := range justified.Lines[:len(justified.Lines)-1] {
+		assert.Equal(t, maxWidth, line.Width)
+		assert.Equal(t, freetype.Pos(0), line.X)
+		// The last glyph before any trailing space ends at the maximum width.
+		lastRun := line.Runs[len(line.Runs)-1]
+		last := lastRun.Glyphs[len(lastRun.Glyphs)-2]
+		assert.Equal(t, maxWidth, last.Position.X+last.Advance)
+	}
+	last := justified.Lines[len(justified.Lines)-1]
+	assert.Less(t, lineEnd(last), maxWidth)
+}
+
+func TestLayoutHyphenation(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	maxWidth := freetype.Pos(80 * 64)
+
+	text := "an extra­ordinary word"
+	paragraph, err := Layout(text, Options{MaxWidth: maxWidth}, face)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"an extra-", "ordinary", "word"}, lineTexts(text, paragraph))
+	assert.True(t, paragraph.Lines[0].Hyphenated)
+
+	text = "an extraordinary word"
+	var hyphenated []string
+	hyphenate := func(word string) []int {
+		hyphenated = append(hyphenated, word)
+		if word == "extraordinary" {
+			return []int{2, 5, 7}
+		}
+		return nil
+	}
+	paragraph, err = Layout(text, Options{MaxWidth: maxWidth, Hyphenate: hyphenate, Hyphen: '‐'}, face)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"an extra-", "ordinary", "word"}, lineTexts(text, paragraph))
+	assert.Equal(t, []string{"extraordinary", "word"}, hyphenated)
+	lastRun := paragraph.Lines[0].Runs[len(paragraph.Lines[0].Runs)-1]
+	assert.Equal(t, face.GetCharIndex('‐'), lastRun.Glyphs[len(lastRun.Glyphs)-1].Index)
+	for _, line := range paragraph.Lines {
+		assert.LessOrEqual(t, line.Width, maxWidth)
+	}
+}
+
+func TestLayoutOverlongWord(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	text := "Supercalifragilisticexpialidocious"
+
+	paragraph, err := Layout(text, Options{MaxWidth: 60 * 64}, face)
+	assert.Nil(t, err)
+	assert.Greater(t, len(paragraph.Lines), 1)
+	assert.Equal(t, text, strings.Join(lineTexts(text, paragraph), ""))
+	for _, line := range paragraph.Lines {
+		assert.LessOrEqual(t, line.Width, freetype.Pos(60*64))
+	}
+}
+
+func TestLayoutFallbackFaces(t *testing.T) {
+	roboto := newFace(t, font.RobotoVariable)
+	dejaVu := newFace(t, font.DejaVuSans)
+	text := "a א"
+
+	paragraph, err := Layout(text, Options{}, roboto, dejaVu)
+	assert.Nil(t, err)
+	runs := paragraph.Lines[0].Runs
+	assert.Len(t, runs, 2)
+	assert.Equal(t, roboto, runs[0].Face)
+	assert.Equal(t, dejaVu, runs[1].Face)
+	assert.Equal(t, 2, runs[1].Glyphs[0].Cluster)
+
+	_, err = Layout(text, Options{})
+	assert.ErrorIs(t, err, freetype.ErrInvalidArgument)
+}
+
+// visualText returns a line's characters in visual order, as the characters of its glyphs' clusters.
+func visualText(text string, line Line) string {
+	var runes []rune
+	for _, run := range line.Runs {
+		for _, glyph := range run.Glyphs {
+			r, _ := utf8.DecodeRuneInString(text[glyph.Cluster:])
+			runes = append(runes, r)
+		}
+	}
+	return string(runes)
+}
+
+func TestLayoutBidi(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+
+	text := "abc אבג def"
+	paragraph, err := Layout(text, Options{}, face)
+	assert.Nil(t, err)
+	line := paragraph.Lines[0]
+	assert.Equal(t, "abc גבא def", visualText(text, line))
+	assert.Len(t, line.Runs, 3)
+	assert.Equal(t, []bidi.Level{0, 1, 0}, []bidi.Level{line.Runs[0].Level, line.Runs[1].Level, line.Runs[2].Level})
+	assert.Equal(t, line.Width, lineEnd(line))
+
+	// Brackets are mirrored in right-to-left text.
+	text = "אב(ג)"
+	paragraph, err = Layout(text, Options{}, face)
+	assert.Nil(t, err)
+	glyphs := paragraph.Lines[0].Runs[0].Glyphs
+	assert.Equal(t, ")ג(בא", visualText(text, paragraph.Lines[0]))
+	assert.Equal(t, face.GetCharIndex('('), glyphs[0].Index)
+	assert.Equal(t, face.GetCharIndex(')'), glyphs[2].Index)
+}
+
+func TestLayoutBidiLines(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+	maxWidth := freetype.Pos(60 * 64)
+	text := "אבג דהו זחט יכל"
+
+	paragraph, err := Layout(text, Options{MaxWidth: maxWidth, Align: AlignStart}, face)
+	assert.Nil(t, err)
+	assert.Greater(t, len(paragraph.Lines), 1)
+	// The first word is at the right of the first line, and the trailing space is at its left.
+	first := visualText(text, paragraph.Lines[0])
+	assert.True(t, strings.HasPrefix(first, " "))
+	assert.True(t, strings.HasSuffix(first, "גבא"))
+	assert.Less(t, paragraph.Lines[0].Runs[0].Glyphs[0].Position.X, paragraph.Lines[0].X)
+	for _, line := range paragraph.Lines {
+		assert.LessOrEqual(t, line.Width, maxWidth)
+		assert.Equal(t, maxWidth, line.X+line.Width)
+		assert.Equal(t, maxWidth, lineEnd(line))
+	}
+
+	paragraph, err = Layout(text, Options{MaxWidth: maxWidth, Align: AlignEnd}, face)
+	assert.Nil(t, err)
+	for _, line := range paragraph.Lines {
+		assert.Equal(t, freetype.Pos(0), line.X)
+	}
+
+	// In a left-to-right paragraph the start is at the left, and trailing spaces are at the right.
+	paragraph, err = Layout(text, Options{MaxWidth: maxWidth, Align: AlignStart, Direction: bidi.LeftToRight}, face)
+	assert.Nil(t, err)
+	assert.Equal(t, freetype.Pos(0), paragraph.Lines[0].X)
+	first = visualText(text, paragraph.Lines[0])
+	assert.True(t, strings.HasSuffix(first, "גבא "))
+}
+
+func TestParagraphDraw(t *testing.T) {
+	face := newFace(t, font.DejaVuSans)
+
+	paragraph, err := Layout("Hello, world", Options{MaxWidth: 60 * 64}, face)
+	assert.Nil(t, err)
+	assert.Len(t, paragraph.Lines, 2)
+
+	dst := image.NewAlpha(image.Rect(0, 0, 70, 50))
+	err = paragraph.Draw(dst, freetype.Vector{X: 5 * 64}, image.Opaque, freetype.DrawOptions{})
+	assert.Nil(t, err)
+
+	inkRows := map[bool]bool{}
+	for y := 0; y < 50; y++ {
+		for x := 0; x < 70; x++ {
+			if dst.AlphaAt(x, y).A != 0 {
+				inkRows[y >= int(paragraph.Lines[0].Baseline>>6)+2] = true
+			}
+		}
+	}
+	assert.True(t, inkRows[false], "first line drawn")
+	assert.True(t, inkRows[true], "second line drawn")
+}
@@ -0,0 +1,323 @@
+package layout
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Line breaking, following the Unicode Line Breaking Algorithm (UAX #14).
+//
+// https://www.unicode.org/reports/tr14/
+
+//go:generate go run gen_linebreak.go
+
+// breakClass is a line breaking class, as assigned to characters by UAX #14.
+type breakClass uint8
+
+const (
+	classAL  breakClass = iota // Alphabetic
+	classBA                    // Break After
+	classBB                    // Break Before
+	classB2                    // Break Opportunity Before and After
+	classBK                    // Mandatory Break
+	classCB                    // Contingent Break Opportunity
+	classCJ                    // Conditional Japanese Starter
+	classCL                    // Close Punctuation
+	classCM                    // Combining Mark
+	classCP                    // Close Parenthesis
+	classCR                    // Carriage Return
+	classEB                    // Emoji Base
+	classEM                    // Emoji Modifier
+	classEX                    // Exclamation/Interrogation
+	classGL                    // Non-breaking ("Glue")
+	classH2                    // Hangul LV Syllable
+	classH3                    // Hangul LVT Syllable
+	classHL                    // Hebrew Letter
+	classHY                    // Hyphen
+	classID                    // Ideographic
+	classIN                    // Inseparable
+	classIS                    // Infix Numeric Separator
+	classJL                    // Hangul L Jamo
+	classJT                    // Hangul T Jamo
+	classJV                    // Hangul V Jamo
+	classLF                    // Line Feed
+	classNL                    // Next Line
+	classNS                    // Nonstarter
+	classNU                    // Numeric
+	classOP                    // Open Punctuation
+	classPO                    // Postfix Numeric
+	classPR                    // Prefix Numeric
+	classQU                    // Quotation
+	classRI                    // Regional Indicator
+	classSA                    // Complex Context Dependent (South East Asian)
+	classSP                    // Space
+	classSY                    // Symbols Allowing Break After
+	classWJ                    // Word Joiner
+	classZW                    // Zero Width Space
+	classZWJ                   // Zero Width Joiner
+)
+
+type classRange struct {
+	lo, hi rune
+	class  breakClass
+}
+
+func lookupClass(table []classRange, r rune) (breakClass, bool) {
+	i := sort.Search(len(table), func(i int) bool { return table[i].hi >= r })
+	if i < len(table) && table[i].lo <= r {
+		return table[i].class, true
+	}
+	return 0, false
+}
+
+// lineBreakClass returns the line breaking class of a character, resolved as described by rule LB1.
+// So classes AI, SG, and XX are resolved to AL, SA is resolved to CM or AL, and CJ is resolved to NS.
+func lineBreakClass(r rune) breakClass {
+	if 0xAC00 <= r && r <= 0xD7A3 {
+		return hangulSyllableClass(r)
+	}
+	class, ok := lookupClass(lineBreakClasses, r)
+	if !ok {
+		return classAL
+	}
+
+	switch class {
+	case classSA:
+		if unicode.In(r, unicode.Mn, unicode.Mc) {
+			return classCM
+		}
+		return classAL
+	case classCJ:
+		return classNS
+	}
+	return class
+}
+
+func hangulSyllableClass(r rune) breakClass {
+	if (r-0xAC00)%28 == 0 {
+		return classH2
+	}
+	return classH3
+}
+
+// Break is a line break opportunity in some text.
+type Break struct {
+	// Index is the byte offset in the text of the character that follows the break.
+	Index int
+	// Mandatory is true if a line break must occur, for example following a line feed.
+	Mandatory bool
+}
+
+/*
+LineBreaks returns the positions in text at which lines may be broken,
+following the Unicode Line Breaking Algorithm (UAX #14).
+
+There is never a break at the start of the text, and there is always a mandatory break at its end.
+Characters that need dictionary based breaking, such as Thai, are treated as alphabetic
+so are only broken at spaces and punctuation.
+*/
+func LineBreaks(text string) []Break {
+	var breaks []Break
+	if text == "" {
+		return breaks
+	}
+
+	var (
+		prev        breakClass // the class of the previous character, after LB9 and LB10
+		prevPrev    breakClass // the class of the character before prev
+		beforeSpace breakClass // the class of the last character that was not a space
+		prevIsZWJ   bool       // the previous character is a ZWJ, even if absorbed by LB9
+		riCount     int        // the number of consecutive regional indicators
+		numeric     bool       // within a number, as described by LB25
+	)
+
+	first := true
+	for i, r := range text {
+		class := lineBreakClass(r)
+
+		if first {
+			first = false
+			if class == classCM || class == classZWJ {
+				class = classAL // LB10
+			}
+			prev, prevPrev, beforeSpace = class, class, class
+			prevIsZWJ = class == classZWJ
+			riCount = boolToInt(class == classRI)
+			numeric = class == classNU
+			continue
+		}
+
+		// LB9: do not break a combining character sequence.
+		if (class == classCM || class == classZWJ) && !isBreakOrSpace(prev) {
+			prevIsZWJ = class == classZWJ
+			continue
+		}
+		if class == classCM || class == classZWJ {
+			class = classAL // LB10
+		}
+
+		allowed, mandatory := breakBetween(prev, prevPrev, beforeSpace, class, prevIsZWJ, riCount, numeric)
+		if allowed {
+			breaks = append(breaks, Break{Index: i, Mandatory: mandatory})
+		}
+
+		numeric = class == classNU ||
+			(numeric && (class == classSY || class == classIS || class == classCL || class == classCP) &&
+				prev != classCL && prev != classCP)
+		prevPrev = prev
+		prev = class
+		if class != classSP {
+			beforeSpace = class
+		}
+		prevIsZWJ = class == classZWJ
+		if class == classRI {
+			riCount++
+		} else {
+			riCount = 0
+		}
+	}
+
+	return append(breaks, Break{Index: len(text), Mandatory: true}) // LB3
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func isBreakOrSpace(class breakClass) bool {
+	switch class {
+	case classBK, classCR, classLF, classNL, classSP, classZW:
+		return true
+	}
+	return false
+}
+
+// isHardBreak reports whether there is a mandatory break between two characters (LB4 and LB5).
+func isHardBreak(prev breakClass, class breakClass) bool {
+	switch prev {
+	case classBK, classLF, classNL:
+		return true
+	case classCR:
+		return class != classLF
+	}
+	return false
+}
+
+func isAlphabetic(class breakClass) bool {
+	return class == classAL || class == classHL
+}
+
+func isHangul(class breakClass) bool {
+	switch class {
+	case classJL, classJV, classJT, classH2, classH3:
+		return true
+	}
+	return false
+}
+
+func isIdeographicOrEmoji(class breakClass) bool {
+	return class == classID || class == classEB || class == classEM
+}
+
+// breakBetween applies rules LB4 to LB31 to the characters either side of a potential break.
+func breakBetween(
+	prev, prevPrev, beforeSpace, class breakClass, prevIsZWJ bool, riCount int, numeric bool,
+) (allowed bool, mandatory bool) {
+	switch {
+	case isHardBreak(prev, class): // LB4, LB5
+		return true, true
+	case prev == classCR && class == classLF: // LB5
+		return false, false
+	case class == classBK || class == classCR || class == classLF || class == classNL: // LB6
+		return false, false
+	case class == classSP || class == classZW: // LB7
+		return false, false
+	case beforeSpace == classZW && (prev == classZW || prev == classSP): // LB8
+		return true, false
+	case prevIsZWJ: // LB8a
+		return false, false
+	case prev == classWJ || class == classWJ: // LB11
+		return false, false
+	case prev == classGL: // LB12
+		return false, false
+	case class == classGL && prev != classSP && prev != classBA && prev != classHY: // LB12a
+		return false, false
+	case class == classCL || class == classCP || class == classEX || class == classIS || class == classSY: // LB13
+		return false, false
+	case beforeSpace == classOP && (prev == classOP || prev == classSP): // LB14
+		return false, false
+	case beforeSpace == classQU && (prev == classQU || prev == classSP) && class == classOP: // LB15
+		return false, false
+	case (beforeSpace == classCL || beforeSpace == classCP) && class == classNS: // LB16
+		return false, false
+	case beforeSpace == classB2 && class == classB2: // LB17
+		return false, false
+	case prev == classSP: // LB18
+		return true, false
+	case prev == classQU || class == classQU: // LB19
+		return false, false
+	case prev == classCB || class == classCB: // LB20
+		return true, false
+	case class == classBA || class == classHY || class == classNS || prev == classBB: // LB21
+		return false, false
+	case prevPrev == classHL && (prev == classHY || prev == classBA): // LB21a
+		return false, false
+	case prev == classSY && class == classHL: // LB21b
+		return false, false
+	case class == classIN: // LB22
+		return false, false
+	case (isAlphabetic(prev) && class == classNU) || (prev == classNU && isAlphabetic(class)): // LB23
+		return false, false
+	case (prev == classPR && isIdeographicOrEmoji(class)) || (isIdeographicOrEmoji(prev) && class == classPO): // LB23a
+		return false, false
+	case ((prev == classPR || prev == classPO) && isAlphabetic(class)) ||
+		(isAlphabetic(prev) && (class == classPR || class == classPO)): // LB24
+		return false, false
+	case numeric && (class == classPO || class == classPR): // LB25
+		return false, false
+	case (prev == classPO || prev == classPR) && (class == classOP || class == classNU): // LB25
+		return false, false
+	case (prev == classHY || prev == classIS || prev == classNU || prev == classSY) && class == classNU: // LB25
+		return false, false
+	case prev == classJL && (class == classJL || class == classJV || class == classH2 || class == classH3): // LB26
+		return false, false
+	case (prev == classJV || prev == classH2) && (class == classJV || class == classJT): // LB26
+		return false, false
+	case (prev == classJT || prev == classH3) && class == classJT: // LB26
+		return false, false
+	case (isHangul(prev) && class == classPO) || (prev == classPR && isHangul(class)): // LB27
+		return false, false
+	case isAlphabetic(prev) && isAlphabetic(class): // LB28
+		return false, false
+	case prev == classIS && isAlphabetic(class): // LB29
+		return false, false
+	case (isAlphabetic(prev) || prev == classNU) && class == classOP: // LB30
+		return false, false
+	case prev == classCP && (isAlphabetic(class) || class == classNU): // LB30
+		return false, false
+	case prev == classRI && class == classRI && riCount%2 == 1: // LB30a
+		return false, false
+	case prev == classEB && class == classEM: // LB30b
+		return false, false
+	}
+	return true, false // LB31
+}
+
+// isSpace reports whether a character is a space, that hangs at the end of a line,
+// and that is stretched when a line is justified.
+func isSpace(r rune) bool {
+	class := lineBreakClass(r)
+	return r == '\t' || class == classSP || (class == classBA && unicode.Is(unicode.Zs, r))
+}
+
+// isHardBreakChar reports whether a character is a mandatory line break, that has no glyph.
+func isHardBreakChar(r rune) bool {
+	switch lineBreakClass(r) {
+	case classBK, classCR, classLF, classNL:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,1122 @@
+// Code generated by gen_linebreak.go from https://www.unicode.org/Public/15.0.0/ucd/LineBreak.txt. DO NOT EDIT.
+
+package layout
+
+// lineBreakClasses are the line breaking classes of characters, from the Unicode Character Database.
+// Characters that are not in the table are AL, including those that rule LB1 resolves to AL.
+// Hangul syllables are omitted, as their classes are derived from their position in their block.
+// It is sorted, and its ranges do not overlap.
+var lineBreakClasses = []classRange{
+	{0x0000, 0x0008, classCM},
+	{0x0009, 0x0009, classBA},
+	{0x000A, 0x000A, classLF},
+	{0x000B, 0x000C, classBK},
+	{0x000D, 0x000D, classCR},
+	{0x000E, 0x001F, classCM},
+	{0x0020, 0x0020, classSP},
+	{0x0021, 0x0021, classEX},
+	{0x0022, 0x0022, classQU},
+	{0x0024, 0x0024, classPR},
+	{0x0025, 0x0025, classPO},
+	{0x0027, 0x0027, classQU},
+	{0x0028, 0x0028, classOP},
+	{0x0029, 0x0029, classCP},
+	{0x002B, 0x002B, classPR},
+	{0x002C, 0x002C, classIS},
+	{0x002D, 0x002D, classHY},
+	{0x002E, 0x002E, classIS},
+	{0x002F, 0x002F, classSY},
+	{0x0030, 0x0039, classNU},
+	{0x003A, 0x003B, classIS},
+	{0x003F, 0x003F, classEX},
+	{0x005B, 0x005B, classOP},
+	{0x005C, 0x005C, classPR},
+	{0x005D, 0x005D, classCP},
+	{0x007B, 0x007B, classOP},
+	{0x007C, 0x007C, classBA},
+	{0x007D, 0x007D, classCL},
+	{0x007F, 0x0084, classCM},
+	{0x0085, 0x0085, classNL},
+	{0x0086, 0x009F, classCM},
+	{0x00A0, 0x00A0, classGL},
+	{0x00A1, 0x00A1, classOP},
+	{0x00A2, 0x00A2, classPO},
+	{0x00A3, 0x00A5, classPR},
+	{0x00AB, 0x00AB, classQU},
+	{0x00AD, 0x00AD, classBA},
+	{0x00B0, 0x00B0, classPO},
+	{0x00B1, 0x00B1, classPR},
+	{0x00B4, 0x00B4, classBB},
+	{0x00BB, 0x00BB, classQU},
+	{0x00BF, 0x00BF, classOP},
+	{0x02C8, 0x02C8, classBB},
+	{0x02CC, 0x02CC, classBB},
+	{0x02DF, 0x02DF, classBB},
+	{0x0300, 0x034E, classCM},
+	{0x034F, 0x034F, classGL},
+	{0x0350, 0x035B, classCM},
+	{0x035C, 0x0362, classGL},
+	{0x0363, 0x036F, classCM},
+	{0x037E, 0x037E, classIS},
+	{0x0483, 0x0489, classCM},
+	{0x0589, 0x0589, classIS},
+	{0x058A, 0x058A, classBA},
+	{0x058F, 0x058F, classPR},
+	{0x0591, 0x05BD, classCM},
+	{0x05BE, 0x05BE, classBA},
+	{0x05BF, 0x05BF, classCM},
+	{0x05C1, 0x05C2, classCM},
+	{0x05C4, 0x05C5, classCM},
+	{0x05C6, 0x05C6, classEX},
+	{0x05C7, 0x05C7, classCM},
+	{0x05D0, 0x05EA, classHL},
+	{0x05EF, 0x05F2, classHL},
+	{0x0609, 0x060B, classPO},
+	{0x060C, 0x060D, classIS},
+	{0x0610, 0x061A, classCM},
+	{0x061B, 0x061B, classEX},
+	{0x061C, 0x061C, classCM},
+	{0x061D, 0x061F, classEX},
+	{0x064B, 0x065F, classCM},
+	{0x0660, 0x0669, classNU},
+	{0x066A, 0x066A, classPO},
+	{0x066B, 0x066C, classNU},
+	{0x0670, 0x0670, classCM},
+	{0x06D4, 0x06D4, classEX},
+	{0x06D6, 0x06DC, classCM},
+	{0x06DF, 0x06E4, classCM},
+	{0x06E7, 0x06E8, classCM},
+	{0x06EA, 0x06ED, classCM},
+	{0x06F0, 0x06F9, classNU},
+	{0x0711, 0x0711, classCM},
+	{0x0730, 0x074A, classCM},
+	{0x07A6, 0x07B0, classCM},
+	{0x07C0, 0x07C9, classNU},
+	{0x07EB, 0x07F3, classCM},
+	{0x07F8, 0x07F8, classIS},
+	{0x07F9, 0x07F9, classEX},
+	{0x07FD, 0x07FD, classCM},
+	{0x07FE, 0x07FF, classPR},
+	{0x0816, 0x0819, classCM},
+	{0x081B, 0x0823, classCM},
+	{0x0825, 0x0827, classCM},
+	{0x0829, 0x082D, classCM},
+	{0x0859, 0x085B, classCM},
+	{0x0898, 0x089F, classCM},
+	{0x08CA, 0x08E1, classCM},
+	{0x08E3, 0x0903, classCM},
+	{0x093A, 0x093C, classCM},
+	{0x093E, 0x094F, classCM},
+	{0x0951, 0x0957, classCM},
+	{0x0962, 0x0963, classCM},
+	{0x0964, 0x0965, classBA},
+	{0x0966, 0x096F, classNU},
+	{0x0981, 0x0983, classCM},
+	{0x09BC, 0x09BC, classCM},
+	{0x09BE, 0x09C4, classCM},
+	{0x09C7, 0x09C8, classCM},
+	{0x09CB, 0x09CD, classCM},
+	{0x09D7, 0x09D7, classCM},
+	{0x09E2, 0x09E3, classCM},
+	{0x09E6, 0x09EF, classNU},
+	{0x09F2, 0x09F3, classPO},
+	{0x09F9, 0x09F9, classPO},
+	{0x09FB, 0x09FB, classPR},
+	{0x09FE, 0x09FE, classCM},
+	{0x0A01, 0x0A03, classCM},
+	{0x0A3C, 0x0A3C, classCM},
+	{0x0A3E, 0x0A42, classCM},
+	{0x0A47, 0x0A48, classCM},
+	{0x0A4B, 0x0A4D, classCM},
+	{0x0A51, 0x0A51, classCM},
+	{0x0A66, 0x0A6F, classNU},
+	{0x0A70, 0x0A71, classCM},
+	{0x0A75, 0x0A75, classCM},
+	{0x0A81, 0x0A83, classCM},
+	{0x0ABC, 0x0ABC, classCM},
+	{0x0ABE, 0x0AC5, classCM},
+	{0x0AC7, 0x0AC9, classCM},
+	{0x0ACB, 0x0ACD, classCM},
+	{0x0AE2, 0x0AE3, classCM},
+	{0x0AE6, 0x0AEF, classNU},
+	{0x0AF1, 0x0AF1, classPR},
+	{0x0AFA, 0x0AFF, classCM},
+	{0x0B01, 0x0B03, classCM},
+	{0x0B3C, 0x0B3C, classCM},
+	{0x0B3E, 0x0B44, classCM},
+	{0x0B47, 0x0B48, classCM},
+	{0x0B4B, 0x0B4D, classCM},
+	{0x0B55, 0x0B57, classCM},
+	{0x0B62, 0x0B63, classCM},
+	{0x0B66, 0x0B6F, classNU},
+	{0x0B82, 0x0B82, classCM},
+	{0x0BBE, 0x0BC2, classCM},
+	{0x0BC6, 0x0BC8, classCM},
+	{0x0BCA, 0x0BCD, classCM},
+	{0x0BD7, 0x0BD7, classCM},
+	{0x0BE6, 0x0BEF, classNU},
+	{0x0BF9, 0x0BF9, classPR},
+	{0x0C00, 0x0C04, classCM},
+	{0x0C3C, 0x0C3C, classCM},
+	{0x0C3E, 0x0C44, classCM},
+	{0x0C46, 0x0C48, classCM},
+	{0x0C4A, 0x0C4D, classCM},
+	{0x0C55, 0x0C56, classCM},
+	{0x0C62, 0x0C63, classCM},
+	{0x0C66, 0x0C6F, classNU},
+	{0x0C77, 0x0C77, classBB},
+	{0x0C81, 0x0C83, classCM},
+	{0x0C84, 0x0C84, classBB},
+	{0x0CBC, 0x0CBC, classCM},
+	{0x0CBE, 0x0CC4, classCM},
+	{0x0CC6, 0x0CC8, classCM},
+	{0x0CCA, 0x0CCD, classCM},
+	{0x0CD5, 0x0CD6, classCM},
+	{0x0CE2, 0x0CE3, classCM},
+	{0x0CE6, 0x0CEF, classNU},
+	{0x0CF3, 0x0CF3, classCM},
+	{0x0D00, 0x0D03, classCM},
+	{0x0D3B, 0x0D3C, classCM},
+	{0x0D3E, 0x0D44, classCM},
+	{0x0D46, 0x0D48, classCM},
+	{0x0D4A, 0x0D4D, classCM},
+	{0x0D57, 0x0D57, classCM},
+	{0x0D62, 0x0D63, classCM},
+	{0x0D66, 0x0D6F, classNU},
+	{0x0D79, 0x0D79, classPO},
+	{0x0D81, 0x0D83, classCM},
+	{0x0DCA, 0x0DCA, classCM},
+	{0x0DCF, 0x0DD4, classCM},
+	{0x0DD6, 0x0DD6, classCM},
+	{0x0DD8, 0x0DDF, classCM},
+	{0x0DE6, 0x0DEF, classNU},
+	{0x0DF2, 0x0DF3, classCM},
+	{0x0E01, 0x0E3A, classSA},
+	{0x0E3F, 0x0E3F, classPR},
+	{0x0E40, 0x0E4E, classSA},
+	{0x0E50, 0x0E59, classNU},
+	{0x0E5A, 0x0E5B, classBA},
+	{0x0E81, 0x0E82, classSA},
+	{0x0E84, 0x0E84, classSA},
+	{0x0E86, 0x0E8A, classSA},
+	{0x0E8C, 0x0EA3, classSA},
+	{0x0EA5, 0x0EA5, classSA},
+	{0x0EA7, 0x0EBD, classSA},
+	{0x0EC0, 0x0EC4, classSA},
+	{0x0EC6, 0x0EC6, classSA},
+	{0x0EC8, 0x0ECE, classSA},
+	{0x0ED0, 0x0ED9, classNU},
+	{0x0EDC, 0x0EDF, classSA},
+	{0x0F01, 0x0F04, classBB},
+	{0x0F06, 0x0F07, classBB},
+	{0x0F08, 0x0F08, classGL},
+	{0x0F09, 0x0F0A, classBB},
+	{0x0F0B, 0x0F0B, classBA},
+	{0x0F0C, 0x0F0C, classGL},
+	{0x0F0D, 0x0F11, classEX},
+	{0x0F12, 0x0F12, classGL},
+	{0x0F14, 0x0F14, classEX},
+	{0x0F18, 0x0F19, classCM},
+	{0x0F20, 0x0F29, classNU},
+	{0x0F34, 0x0F34, classBA},
+	{0x0F35, 0x0F35, classCM},
+	{0x0F37, 0x0F37, classCM},
+	{0x0F39, 0x0F39, classCM},
+	{0x0F3A, 0x0F3A, classOP},
+	{0x0F3B, 0x0F3B, classCL},
+	{0x0F3C, 0x0F3C, classOP},
+	{0x0F3D, 0x0F3D, classCL},
+	{0x0F3E, 0x0F3F, classCM},
+	{0x0F71, 0x0F7E, classCM},
+	{0x0F7F, 0x0F7F, classBA},
+	{0x0F80, 0x0F84, classCM},
+	{0x0F85, 0x0F85, classBA},
+	{0x0F86, 0x0F87, classCM},
+	{0x0F8D, 0x0F97, classCM},
+	{0x0F99, 0x0FBC, classCM},
+	{0x0FBE, 0x0FBF, classBA},
+	{0x0FC6, 0x0FC6, classCM},
+	{0x0FD0, 0x0FD1, classBB},
+	{0x0FD2, 0x0FD2, classBA},
+	{0x0FD3, 0x0FD3, classBB},
+	{0x0FD9, 0x0FDA, classGL},
+	{0x1000, 0x103F, classSA},
+	{0x1040, 0x1049, classNU},
+	{0x104A, 0x104B, classBA},
+	{0x1050, 0x108F, classSA},
+	{0x1090, 0x1099, classNU},
+	{0x109A, 0x109F, classSA},
+	{0x1100, 0x115F, classJL},
+	{0x1160, 0x11A7, classJV},
+	{0x11A8, 0x11FF, classJT},
+	{0x135D, 0x135F, classCM},
+	{0x1361, 0x1361, classBA},
+	{0x1400, 0x1400, classBA},
+	{0x1680, 0x1680, classBA},
+	{0x169B, 0x169B, classOP},
+	{0x169C, 0x169C, classCL},
+	{0x16EB, 0x16ED, classBA},
+	{0x1712, 0x1715, classCM},
+	{0x1732, 0x1734, classCM},
+	{0x1735, 0x1736, classBA},
+	{0x1752, 0x1753, classCM},
+	{0x1772, 0x1773, classCM},
+	{0x1780, 0x17D3, classSA},
+	{0x17D4, 0x17D5, classBA},
+	{0x17D6, 0x17D6, classNS},
+	{0x17D7, 0x17D7, classSA},
+	{0x17D8, 0x17D8, classBA},
+	{0x17DA, 0x17DA, classBA},
+	{0x17DB, 0x17DB, classPR},
+	{0x17DC, 0x17DD, classSA},
+	{0x17E0, 0x17E9, classNU},
+	{0x1802, 0x1803, classEX},
+	{0x1804, 0x1805, classBA},
+	{0x1806, 0x1806, classBB},
+	{0x1808, 0x1809, classEX},
+	{0x180B, 0x180D, classCM},
+	{0x180E, 0x180E, classGL},
+	{0x180F, 0x180F, classCM},
+	{0x1810, 0x1819, classNU},
+	{0x1885, 0x1886, classCM},
+	{0x18A9, 0x18A9, classCM},
+	{0x1920, 0x192B, classCM},
+	{0x1930, 0x193B, classCM},
+	{0x1944, 0x1945, classEX},
+	{0x1946, 0x194F, classNU},
+	{0x1950, 0x196D, classSA},
+	{0x1970, 0x1974, classSA},
+	{0x1980, 0x19AB, classSA},
+	{0x19B0, 0x19C9, classSA},
+	{0x19D0, 0x19D9, classNU},
+	{0x19DA, 0x19DA, classSA},
+	{0x19DE, 0x19DF, classSA},
+	{0x1A17, 0x1A1B, classCM},
+	{0x1A20, 0x1A5E, classSA},
+	{0x1A60, 0x1A7C, classSA},
+	{0x1A7F, 0x1A7F, classCM},
+	{0x1A80, 0x1A89, classNU},
+	{0x1A90, 0x1A99, classNU},
+	{0x1AA0, 0x1AAD, classSA},
+	{0x1AB0, 0x1ACE, classCM},
+	{0x1B00, 0x1B04, classCM},
+	{0x1B34, 0x1B44, classCM},
+	{0x1B50, 0x1B59, classNU},
+	{0x1B5A, 0x1B5B, classBA},
+	{0x1B5D, 0x1B60, classBA},
+	{0x1B6B, 0x1B73, classCM},
+	{0x1B7D, 0x1B7E, classBA},
+	{0x1B80, 0x1B82, classCM},
+	{0x1BA1, 0x1BAD, classCM},
+	{0x1BB0, 0x1BB9, classNU},
+	{0x1BE6, 0x1BF3, classCM},
+	{0x1C24, 0x1C37, classCM},
+	{0x1C3B, 0x1C3F, classBA},
+	{0x1C40, 0x1C49, classNU},
+	{0x1C50, 0x1C59, classNU},
+	{0x1C7E, 0x1C7F, classBA},
+	{0x1CD0, 0x1CD2, classCM},
+	{0x1CD4, 0x1CE8, classCM},
+	{0x1CED, 0x1CED, classCM},
+	{0x1CF4, 0x1CF4, classCM},
+	{0x1CF7, 0x1CF9, classCM},
+	{0x1DC0, 0x1DCC, classCM},
+	{0x1DCD, 0x1DCD, classGL},
+	{0x1DCE, 0x1DFB, classCM},
+	{0x1DFC, 0x1DFC, classGL},
+	{0x1DFD, 0x1DFF, classCM},
+	{0x1FFD, 0x1FFD, classBB},
+	{0x2000, 0x2006, classBA},
+	{0x2007, 0x2007, classGL},
+	{0x2008, 0x200A, classBA},
+	{0x200B, 0x200B, classZW},
+	{0x200C, 0x200C, classCM},
+	{0x200D, 0x200D, classZWJ},
+	{0x200E, 0x200F, classCM},
+	{0x2010, 0x2010, classBA},
+	{0x2011, 0x2011, classGL},
+	{0x2012, 0x2013, classBA},
+	{0x2014, 0x2014, classB2},
+	{0x2018, 0x2019, classQU},
+	{0x201A, 0x201A, classOP},
+	{0x201B, 0x201D, classQU},
+	{0x201E, 0x201E, classOP},
+	{0x201F, 0x201F, classQU},
+	{0x2024, 0x2026, classIN},
+	{0x2027, 0x2027, classBA},
+	{0x2028, 0x2029, classBK},
+	{0x202A, 0x202E, classCM},
+	{0x202F, 0x202F, classGL},
+	{0x2030, 0x2037, classPO},
+	{0x2039, 0x203A, classQU},
+	{0x203C, 0x203D, classNS},
+	{0x2044, 0x2044, classIS},
+	{0x2045, 0x2045, classOP},
+	{0x2046, 0x2046, classCL},
+	{0x2047, 0x2049, classNS},
+	{0x2056, 0x2056, classBA},
+	{0x2057, 0x2057, classPO},
+	{0x2058, 0x205B, classBA},
+	{0x205D, 0x205F, classBA},
+	{0x2060, 0x2060, classWJ},
+	{0x2066, 0x206F, classCM},
+	{0x207D, 0x207D, classOP},
+	{0x207E, 0x207E, classCL},
+	{0x208D, 0x208D, classOP},
+	{0x208E, 0x208E, classCL},
+	{0x20A0, 0x20A6, classPR},
+	{0x20A7, 0x20A7, classPO},
+	{0x20A8, 0x20B5, classPR},
+	{0x20B6, 0x20B6, classPO},
+	{0x20B7, 0x20BA, classPR},
+	{0x20BB, 0x20BB, classPO},
+	{0x20BC, 0x20BD, classPR},
+	{0x20BE, 0x20BE, classPO},
+	{0x20BF, 0x20BF, classPR},
+	{0x20C0, 0x20C0, classPO},
+	{0x20C1, 0x20CF, classPR},
+	{0x20D0, 0x20F0, classCM},
+	{0x2103, 0x2103, classPO},
+	{0x2109, 0x2109, classPO},
+	{0x2116, 0x2116, classPR},
+	{0x2212, 0x2213, classPR},
+	{0x22EF, 0x22EF, classIN},
+	{0x2308, 0x2308, classOP},
+	{0x2309, 0x2309, classCL},
+	{0x230A, 0x230A, classOP},
+	{0x230B, 0x230B, classCL},
+	{0x231A, 0x231B, classID},
+	{0x2329, 0x2329, classOP},
+	{0x232A, 0x232A, classCL},
+	{0x23F0, 0x23F3, classID},
+	{0x2600, 0x2603, classID},
+	{0x2614, 0x2615, classID},
+	{0x2618, 0x2618, classID},
+	{0x261A, 0x261C, classID},
+	{0x261D, 0x261D, classEB},
+	{0x261E, 0x261F, classID},
+	{0x2639, 0x263B, classID},
+	{0x2668, 0x2668, classID},
+	{0x267F, 0x267F, classID},
+	{0x26BD, 0x26C8, classID},
+	{0x26CD, 0x26CD, classID},
+	{0x26CF, 0x26D1, classID},
+	{0x26D3, 0x26D4, classID},
+	{0x26D8, 0x26D9, classID},
+	{0x26DC, 0x26DC, classID},
+	{0x26DF, 0x26E1, classID},
+	{0x26EA, 0x26EA, classID},
+	{0x26F1, 0x26F5, classID},
+	{0x26F7, 0x26F8, classID},
+	{0x26F9, 0x26F9, classEB},
+	{0x26FA, 0x26FA, classID},
+	{0x26FD, 0x2704, classID},
+	{0x2708, 0x2709, classID},
+	{0x270A, 0x270D, classEB},
+	{0x275B, 0x2760, classQU},
+	{0x2762, 0x2763, classEX},
+	{0x2764, 0x2764, classID},
+	{0x2768, 0x2768, classOP},
+	{0x2769, 0x2769, classCL},
+	{0x276A, 0x276A, classOP},
+	{0x276B, 0x276B, classCL},
+	{0x276C, 0x276C, classOP},
+	{0x276D, 0x276D, classCL},
+	{0x276E, 0x276E, classOP},
+	{0x276F, 0x276F, classCL},
+	{0x2770, 0x2770, classOP},
+	{0x2771, 0x2771, classCL},
+	{0x2772, 0x2772, classOP},
+	{0x2773, 0x2773, classCL},
+	{0x2774, 0x2774, classOP},
+	{0x2775, 0x2775, classCL},
+	{0x27C5, 0x27C5, classOP},
+	{0x27C6, 0x27C6, classCL},
+	{0x27E6, 0x27E6, classOP},
+	{0x27E7, 0x27E7, classCL},
+	{0x27E8, 0x27E8, classOP},
+	{0x27E9, 0x27E9, classCL},
+	{0x27EA, 0x27EA, classOP},
+	{0x27EB, 0x27EB, classCL},
+	{0x27EC, 0x27EC, classOP},
+	{0x27ED, 0x27ED, classCL},
+	{0x27EE, 0x27EE, classOP},
+	{0x27EF, 0x27EF, classCL},
+	{0x2983, 0x2983, classOP},
+	{0x2984, 0x2984, classCL},
+	{0x2985, 0x2985, classOP},
+	{0x2986, 0x2986, classCL},
+	{0x2987, 0x2987, classOP},
+	{0x2988, 0x2988, classCL},
+	{0x2989, 0x2989, classOP},
+	{0x298A, 0x298A, classCL},
+	{0x298B, 0x298B, classOP},
+	{0x298C, 0x298C, classCL},
+	{0x298D, 0x298D, classOP},
+	{0x298E, 0x298E, classCL},
+	{0x298F, 0x298F, classOP},
+	{0x2990, 0x2990, classCL},
+	{0x2991, 0x2991, classOP},
+	{0x2992, 0x2992, classCL},
+	{0x2993, 0x2993, classOP},
+	{0x2994, 0x2994, classCL},
+	{0x2995, 0x2995, classOP},
+	{0x2996, 0x2996, classCL},
+	{0x2997, 0x2997, classOP},
+	{0x2998, 0x2998, classCL},
+	{0x29D8, 0x29D8, classOP},
+	{0x29D9, 0x29D9, classCL},
+	{0x29DA, 0x29DA, classOP},
+	{0x29DB, 0x29DB, classCL},
+	{0x29FC, 0x29FC, classOP},
+	{0x29FD, 0x29FD, classCL},
+	{0x2CEF, 0x2CF1, classCM},
+	{0x2CF9, 0x2CF9, classEX},
+	{0x2CFA, 0x2CFC, classBA},
+	{0x2CFE, 0x2CFE, classEX},
+	{0x2CFF, 0x2CFF, classBA},
+	{0x2D70, 0x2D70, classBA},
+	{0x2D7F, 0x2D7F, classCM},
+	{0x2DE0, 0x2DFF, classCM},
+	{0x2E00, 0x2E0D, classQU},
+	{0x2E0E, 0x2E15, classBA},
+	{0x2E17, 0x2E17, classBA},
+	{0x2E18, 0x2E18, classOP},
+	{0x2E19, 0x2E19, classBA},
+	{0x2E1C, 0x2E1D, classQU},
+	{0x2E20, 0x2E21, classQU},
+	{0x2E22, 0x2E22, classOP},
+	{0x2E23, 0x2E23, classCL},
+	{0x2E24, 0x2E24, classOP},
+	{0x2E25, 0x2E25, classCL},
+	{0x2E26, 0x2E26, classOP},
+	{0x2E27, 0x2E27, classCL},
+	{0x2E28, 0x2E28, classOP},
+	{0x2E29, 0x2E29, classCL},
+	{0x2E2A, 0x2E2D, classBA},
+	{0x2E2E, 0x2E2E, classEX},
+	{0x2E30, 0x2E31, classBA},
+	{0x2E33, 0x2E34, classBA},
+	{0x2E3A, 0x2E3B, classB2},
+	{0x2E3C, 0x2E3E, classBA},
+	{0x2E40, 0x2E41, classBA},
+	{0x2E42, 0x2E42, classOP},
+	{0x2E43, 0x2E4A, classBA},
+	{0x2E4C, 0x2E4C, classBA},
+	{0x2E4E, 0x2E4F, classBA},
+	{0x2E53, 0x2E54, classEX},
+	{0x2E55, 0x2E55, classOP},
+	{0x2E56, 0x2E56, classCL},
+	{0x2E57, 0x2E57, classOP},
+	{0x2E58, 0x2E58, classCL},
+	{0x2E59, 0x2E59, classOP},
+	{0x2E5A, 0x2E5A, classCL},
+	{0x2E5B, 0x2E5B, classOP},
+	{0x2E5C, 0x2E5C, classCL},
+	{0x2E5D, 0x2E5D, classBA},
+	{0x2E80, 0x2E99, classID},
+	{0x2E9B, 0x2EF3, classID},
+	{0x2F00, 0x2FD5, classID},
+	{0x2FF0, 0x2FFB, classID},
+	{0x3000, 0x3000, classBA},
+	{0x3001, 0x3002, classCL},
+	{0x3003, 0x3004, classID},
+	{0x3005, 0x3005, classNS},
+	{0x3006, 0x3007, classID},
+	{0x3008, 0x3008, classOP},
+	{0x3009, 0x3009, classCL},
+	{0x300A, 0x300A, classOP},
+	{0x300B, 0x300B, classCL},
+	{0x300C, 0x300C, classOP},
+	{0x300D, 0x300D, classCL},
+	{0x300E, 0x300E, classOP},
+	{0x300F, 0x300F, classCL},
+	{0x3010, 0x3010, classOP},
+	{0x3011, 0x3011, classCL},
+	{0x3012, 0x3013, classID},
+	{0x3014, 0x3014, classOP},
+	{0x3015, 0x3015, classCL},
+	{0x3016, 0x3016, classOP},
+	{0x3017, 0x3017, classCL},
+	{0x3018, 0x3018, classOP},
+	{0x3019, 0x3019, classCL},
+	{0x301A, 0x301A, classOP},
+	{0x301B, 0x301B, classCL},
+	{0x301C, 0x301C, classNS},
+	{0x301D, 0x301D, classOP},
+	{0x301E, 0x301F, classCL},
+	{0x3020, 0x3029, classID},
+	{0x302A, 0x302F, classCM},
+	{0x3030, 0x3034, classID},
+	{0x3035, 0x3035, classCM},
+	{0x3036, 0x303A, classID},
+	{0x303B, 0x303C, classNS},
+	{0x303D, 0x303F, classID},
+	{0x3041, 0x3041, classCJ},
+	{0x3042, 0x3042, classID},
+	{0x3043, 0x3043, classCJ},
+	{0x3044, 0x3044, classID},
+	{0x3045, 0x3045, classCJ},
+	{0x3046, 0x3046, classID},
+	{0x3047, 0x3047, classCJ},
+	{0x3048, 0x3048, classID},
+	{0x3049, 0x3049, classCJ},
+	{0x304A, 0x3062, classID},
+	{0x3063, 0x3063, classCJ},
+	{0x3064, 0x3082, classID},
+	{0x3083, 0x3083, classCJ},
+	{0x3084, 0x3084, classID},
+	{0x3085, 0x3085, classCJ},
+	{0x3086, 0x3086, classID},
+	{0x3087, 0x3087, classCJ},
+	{0x3088, 0x308D, classID},
+	{0x308E, 0x308E, classCJ},
+	{0x308F, 0x3094, classID},
+	{0x3095, 0x3096, classCJ},
+	{0x3099, 0x309A, classCM},
+	{0x309B, 0x309E, classNS},
+	{0x309F, 0x309F, classID},
+	{0x30A0, 0x30A0, classNS},
+	{0x30A1, 0x30A1, classCJ},
+	{0x30A2, 0x30A2, classID},
+	{0x30A3, 0x30A3, classCJ},
+	{0x30A4, 0x30A4, classID},
+	{0x30A5, 0x30A5, classCJ},
+	{0x30A6, 0x30A6, classID},
+	{0x30A7, 0x30A7, classCJ},
+	{0x30A8, 0x30A8, classID},
+	{0x30A9, 0x30A9, classCJ},
+	{0x30AA, 0x30C2, classID},
+	{0x30C3, 0x30C3, classCJ},
+	{0x30C4, 0x30E2, classID},
+	{0x30E3, 0x30E3, classCJ},
+	{0x30E4, 0x30E4, classID},
+	{0x30E5, 0x30E5, classCJ},
+	{0x30E6, 0x30E6, classID},
+	{0x30E7, 0x30E7, classCJ},
+	{0x30E8, 0x30ED, classID},
+	{0x30EE, 0x30EE, classCJ},
+	{0x30EF, 0x30F4, classID},
+	{0x30F5, 0x30F6, classCJ},
+	{0x30F7, 0x30FA, classID},
+	{0x30FB, 0x30FB, classNS},
+	{0x30FC, 0x30FC, classCJ},
+	{0x30FD, 0x30FE, classNS},
+	{0x30FF, 0x30FF, classID},
+	{0x3105, 0x312F, classID},
+	{0x3131, 0x318E, classID},
+	{0x3190, 0x31E3, classID},
+	{0x31F0, 0x31FF, classCJ},
+	{0x3200, 0x321E, classID},
+	{0x3220, 0x3247, classID},
+	{0x3250, 0x4DBF, classID},
+	{0x4E00, 0xA014, classID},
+	{0xA015, 0xA015, classNS},
+	{0xA016, 0xA48C, classID},
+	{0xA490, 0xA4C6, classID},
+	{0xA4FE, 0xA4FF, classBA},
+	{0xA60D, 0xA60D, classBA},
+	{0xA60E, 0xA60E, classEX},
+	{0xA60F, 0xA60F, classBA},
+	{0xA620, 0xA629, classNU},
+	{0xA66F, 0xA672, classCM},
+	{0xA674, 0xA67D, classCM},
+	{0xA69E, 0xA69F, classCM},
+	{0xA6F0, 0xA6F1, classCM},
+	{0xA6F3, 0xA6F7, classBA},
+	{0xA802, 0xA802, classCM},
+	{0xA806, 0xA806, classCM},
+	{0xA80B, 0xA80B, classCM},
+	{0xA823, 0xA827, classCM},
+	{0xA82C, 0xA82C, classCM},
+	{0xA838, 0xA838, classPO},
+	{0xA874, 0xA875, classBB},
+	{0xA876, 0xA877, classEX},
+	{0xA880, 0xA881, classCM},
+	{0xA8B4, 0xA8C5, classCM},
+	{0xA8CE, 0xA8CF, classBA},
+	{0xA8D0, 0xA8D9, classNU},
+	{0xA8E0, 0xA8F1, classCM},
+	{0xA8FC, 0xA8FC, classBB},
+	{0xA8FF, 0xA8FF, classCM},
+	{0xA900, 0xA909, classNU},
+	{0xA926, 0xA92D, classCM},
+	{0xA92E, 0xA92F, classBA},
+	{0xA947, 0xA953, classCM},
+	{0xA960, 0xA97C, classJL},
+	{0xA980, 0xA983, classCM},
+	{0xA9B3, 0xA9C0, classCM},
+	{0xA9C7, 0xA9C9, classBA},
+	{0xA9D0, 0xA9D9, classNU},
+	{0xA9E0, 0xA9EF, classSA},
+	{0xA9F0, 0xA9F9, classNU},
+	{0xA9FA, 0xA9FE, classSA},
+	{0xAA29, 0xAA36, classCM},
+	{0xAA43, 0xAA43, classCM},
+	{0xAA4C, 0xAA4D, classCM},
+	{0xAA50, 0xAA59, classNU},
+	{0xAA5D, 0xAA5F, classBA},
+	{0xAA60, 0xAAC2, classSA},
+	{0xAADB, 0xAADF, classSA},
+	{0xAAEB, 0xAAEF, classCM},
+	{0xAAF0, 0xAAF1, classBA},
+	{0xAAF5, 0xAAF6, classCM},
+	{0xABE3, 0xABEA, classCM},
+	{0xABEB, 0xABEB, classBA},
+	{0xABEC, 0xABED, classCM},
+	{0xABF0, 0xABF9, classNU},
+	{0xD7B0, 0xD7C6, classJV},
+	{0xD7CB, 0xD7FB, classJT},
+	{0xF900, 0xFAFF, classID},
+	{0xFB1D, 0xFB1D, classHL},
+	{0xFB1E, 0xFB1E, classCM},
+	{0xFB1F, 0xFB28, classHL},
+	{0xFB2A, 0xFB36, classHL},
+	{0xFB38, 0xFB3C, classHL},
+	{0xFB3E, 0xFB3E, classHL},
+	{0xFB40, 0xFB41, classHL},
+	{0xFB43, 0xFB44, classHL},
+	{0xFB46, 0xFB4F, classHL},
+	{0xFD3E, 0xFD3E, classCL},
+	{0xFD3F, 0xFD3F, classOP},
+	{0xFDFC, 0xFDFC, classPO},
+	{0xFE00, 0xFE0F, classCM},
+	{0xFE10, 0xFE10, classIS},
+	{0xFE11, 0xFE12, classCL},
+	{0xFE13, 0xFE14, classIS},
+	{0xFE15, 0xFE16, classEX},
+	{0xFE17, 0xFE17, classOP},
+	{0xFE18, 0xFE18, classCL},
+	{0xFE19, 0xFE19, classIN},
+	{0xFE20, 0xFE2F, classCM},
+	{0xFE30, 0xFE34, classID},
+	{0xFE35, 0xFE35, classOP},
+	{0xFE36, 0xFE36, classCL},
+	{0xFE37, 0xFE37, classOP},
+	{0xFE38, 0xFE38, classCL},
+	{0xFE39, 0xFE39, classOP},
+	{0xFE3A, 0xFE3A, classCL},
+	{0xFE3B, 0xFE3B, classOP},
+	{0xFE3C, 0xFE3C, classCL},
+	{0xFE3D, 0xFE3D, classOP},
+	{0xFE3E, 0xFE3E, classCL},
+	{0xFE3F, 0xFE3F, classOP},
+	{0xFE40, 0xFE40, classCL},
+	{0xFE41, 0xFE41, classOP},
+	{0xFE42, 0xFE42, classCL},
+	{0xFE43, 0xFE43, classOP},
+	{0xFE44, 0xFE44, classCL},
+	{0xFE45, 0xFE46, classID},
+	{0xFE47, 0xFE47, classOP},
+	{0xFE48, 0xFE48, classCL},
+	{0xFE49, 0xFE4F, classID},
+	{0xFE50, 0xFE50, classCL},
+	{0xFE51, 0xFE51, classID},
+	{0xFE52, 0xFE52, classCL},
+	{0xFE54, 0xFE55, classNS},
+	{0xFE56, 0xFE57, classEX},
+	{0xFE58, 0xFE58, classID},
+	{0xFE59, 0xFE59, classOP},
+	{0xFE5A, 0xFE5A, classCL},
+	{0xFE5B, 0xFE5B, classOP},
+	{0xFE5C, 0xFE5C, classCL},
+	{0xFE5D, 0xFE5D, classOP},
+	{0xFE5E, 0xFE5E, classCL},
+	{0xFE5F, 0xFE66, classID},
+	{0xFE68, 0xFE68, classID},
+	{0xFE69, 0xFE69, classPR},
+	{0xFE6A, 0xFE6A, classPO},
+	{0xFE6B, 0xFE6B, classID},
+	{0xFEFF, 0xFEFF, classWJ},
+	{0xFF01, 0xFF01, classEX},
+	{0xFF02, 0xFF03, classID},
+	{0xFF04, 0xFF04, classPR},
+	{0xFF05, 0xFF05, classPO},
+	{0xFF06, 0xFF07, classID},
+	{0xFF08, 0xFF08, classOP},
+	{0xFF09, 0xFF09, classCL},
+	{0xFF0A, 0xFF0B, classID},
+	{0xFF0C, 0xFF0C, classCL},
+	{0xFF0D, 0xFF0D, classID},
+	{0xFF0E, 0xFF0E, classCL},
+	{0xFF0F, 0xFF19, classID},
+	{0xFF1A, 0xFF1B, classNS},
+	{0xFF1C, 0xFF1E, classID},
+	{0xFF1F, 0xFF1F, classEX},
+	{0xFF20, 0xFF3A, classID},
+	{0xFF3B, 0xFF3B, classOP},
+	{0xFF3C, 0xFF3C, classID},
+	{0xFF3D, 0xFF3D, classCL},
+	{0xFF3E, 0xFF5A, classID},
+	{0xFF5B, 0xFF5B, classOP},
+	{0xFF5C, 0xFF5C, classID},
+	{0xFF5D, 0xFF5D, classCL},
+	{0xFF5E, 0xFF5E, classID},
+	{0xFF5F, 0xFF5F, classOP},
+	{0xFF60, 0xFF61, classCL},
+	{0xFF62, 0xFF62, classOP},
+	{0xFF63, 0xFF64, classCL},
+	{0xFF65, 0xFF65, classNS},
+	{0xFF66, 0xFF66, classID},
+	{0xFF67, 0xFF70, classCJ},
+	{0xFF71, 0xFF9D, classID},
+	{0xFF9E, 0xFF9F, classNS},
+	{0xFFA0, 0xFFBE, classID},
+	{0xFFC2, 0xFFC7, classID},
+	{0xFFCA, 0xFFCF, classID},
+	{0xFFD2, 0xFFD7, classID},
+	{0xFFDA, 0xFFDC, classID},
+	{0xFFE0, 0xFFE0, classPO},
+	{0xFFE1, 0xFFE1, classPR},
+	{0xFFE2, 0xFFE4, classID},
+	{0xFFE5, 0xFFE6, classPR},
+	{0xFFF9, 0xFFFB, classCM},
+	{0xFFFC, 0xFFFC, classCB},
+	{0x10100, 0x10102, classBA},
+	{0x101FD, 0x101FD, classCM},
+	{0x102E0, 0x102E0, classCM},
+	{0x10376, 0x1037A, classCM},
+	{0x1039F, 0x1039F, classBA},
+	{0x103D0, 0x103D0, classBA},
+	{0x104A0, 0x104A9, classNU},
+	{0x10857, 0x10857, classBA},
+	{0x1091F, 0x1091F, classBA},
+	{0x10A01, 0x10A03, classCM},
+	{0x10A05, 0x10A06, classCM},
+	{0x10A0C, 0x10A0F, classCM},
+	{0x10A38, 0x10A3A, classCM},
+	{0x10A3F, 0x10A3F, classCM},
+	{0x10A50, 0x10A57, classBA},
+	{0x10AE5, 0x10AE6, classCM},
+	{0x10AF0, 0x10AF5, classBA},
+	{0x10AF6, 0x10AF6, classIN},
+	{0x10B39, 0x10B3F, classBA},
+	{0x10D24, 0x10D27, classCM},
+	{0x10D30, 0x10D39, classNU},
+	{0x10EAB, 0x10EAC, classCM},
+	{0x10EAD, 0x10EAD, classBA},
+	{0x10EFD, 0x10EFF, classCM},
+	{0x10F46, 0x10F50, classCM},
+	{0x10F82, 0x10F85, classCM},
+	{0x11000, 0x11002, classCM},
+	{0x11038, 0x11046, classCM},
+	{0x11047, 0x11048, classBA},
+	{0x11066, 0x1106F, classNU},
+	{0x11070, 0x11070, classCM},
+	{0x11073, 0x11074, classCM},
+	{0x1107F, 0x11082, classCM},
+	{0x110B0, 0x110BA, classCM},
+	{0x110BE, 0x110C1, classBA},
+	{0x110C2, 0x110C2, classCM},
+	{0x110F0, 0x110F9, classNU},
+	{0x11100, 0x11102, classCM},
+	{0x11127, 0x11134, classCM},
+	{0x11136, 0x1113F, classNU},
+	{0x11140, 0x11143, classBA},
+	{0x11145, 0x11146, classCM},
+	{0x11173, 0x11173, classCM},
+	{0x11175, 0x11175, classBB},
+	{0x11180, 0x11182, classCM},
+	{0x111B3, 0x111C0, classCM},
+	{0x111C5, 0x111C6, classBA},
+	{0x111C8, 0x111C8, classBA},
+	{0x111C9, 0x111CC, classCM},
+	{0x111CE, 0x111CF, classCM},
+	{0x111D0, 0x111D9, classNU},
+	{0x111DB, 0x111DB, classBB},
+	{0x111DD, 0x111DF, classBA},
+	{0x1122C, 0x11237, classCM},
+	{0x11238, 0x11239, classBA},
+	{0x1123B, 0x1123C, classBA},
+	{0x1123E, 0x1123E, classCM},
+	{0x11241, 0x11241, classCM},
+	{0x112A9, 0x112A9, classBA},
+	{0x112DF, 0x112EA, classCM},
+	{0x112F0, 0x112F9, classNU},
+	{0x11300, 0x11303, classCM},
+	{0x1133B, 0x1133C, classCM},
+	{0x1133E, 0x11344, classCM},
+	{0x11347, 0x11348, classCM},
+	{0x1134B, 0x1134D, classCM},
+	{0x11357, 0x11357, classCM},
+	{0x11362, 0x11363, classCM},
+	{0x11366, 0x1136C, classCM},
+	{0x11370, 0x11374, classCM},
+	{0x11435, 0x11446, classCM},
+	{0x1144B, 0x1144E, classBA},
+	{0x11450, 0x11459, classNU},
+	{0x1145A, 0x1145B, classBA},
+	{0x1145E, 0x1145E, classCM},
+	{0x114B0, 0x114C3, classCM},
+	{0x114D0, 0x114D9, classNU},
+	{0x115AF, 0x115B5, classCM},
+	{0x115B8, 0x115C0, classCM},
+	{0x115C1, 0x115C1, classBB},
+	{0x115C2, 0x115C3, classBA},
+	{0x115C4, 0x115C5, classEX},
+	{0x115C9, 0x115D7, classBA},
+	{0x115DC, 0x115DD, classCM},
+	{0x11630, 0x11640, classCM},
+	{0x11641, 0x11642, classBA},
+	{0x11650, 0x11659, classNU},
+	{0x11660, 0x1166C, classBB},
+	{0x116AB, 0x116B7, classCM},
+	{0x116C0, 0x116C9, classNU},
+	{0x11700, 0x1171A, classSA},
+	{0x1171D, 0x1172B, classSA},
+	{0x11730, 0x11739, classNU},
+	{0x1173A, 0x1173B, classSA},
+	{0x1173C, 0x1173E, classBA},
+	{0x1173F, 0x11746, classSA},
+	{0x1182C, 0x1183A, classCM},
+	{0x118E0, 0x118E9, classNU},
+	{0x11930, 0x11935, classCM},
+	{0x11937, 0x11938, classCM},
+	{0x1193B, 0x1193E, classCM},
+	{0x11940, 0x11940, classCM},
+	{0x11942, 0x11943, classCM},
+	{0x11944, 0x11946, classBA},
+	{0x11950, 0x11959, classNU},
+	{0x119D1, 0x119D7, classCM},
+	{0x119DA, 0x119E0, classCM},
+	{0x119E2, 0x119E2, classBB},
+	{0x119E4, 0x119E4, classCM},
+	{0x11A01, 0x11A0A, classCM},
+	{0x11A33, 0x11A39, classCM},
+	{0x11A3B, 0x11A3E, classCM},
+	{0x11A3F, 0x11A3F, classBB},
+	{0x11A41, 0x11A44, classBA},
+	{0x11A45, 0x11A45, classBB},
+	{0x11A47, 0x11A47, classCM},
+	{0x11A51, 0x11A5B, classCM},
+	{0x11A8A, 0x11A99, classCM},
+	{0x11A9A, 0x11A9C, classBA},
+	{0x11A9E, 0x11AA0, classBB},
+	{0x11AA1, 0x11AA2, classBA},
+	{0x11B00, 0x11B09, classBB},
+	{0x11C2F, 0x11C36, classCM},
+	{0x11C38, 0x11C3F, classCM},
+	{0x11C41, 0x11C45, classBA},
+	{0x11C50, 0x11C59, classNU},
+	{0x11C70, 0x11C70, classBB},
+	{0x11C71, 0x11C71, classEX},
+	{0x11C92, 0x11CA7, classCM},
+	{0x11CA9, 0x11CB6, classCM},
+	{0x11D31, 0x11D36, classCM},
+	{0x11D3A, 0x11D3A, classCM},
+	{0x11D3C, 0x11D3D, classCM},
+	{0x11D3F, 0x11D45, classCM},
+	{0x11D47, 0x11D47, classCM},
+	{0x11D50, 0x11D59, classNU},
+	{0x11D8A, 0x11D8E, classCM},
+	{0x11D90, 0x11D91, classCM},
+	{0x11D93, 0x11D97, classCM},
+	{0x11DA0, 0x11DA9, classNU},
+	{0x11EF3, 0x11EF6, classCM},
+	{0x11F00, 0x11F01, classCM},
+	{0x11F03, 0x11F03, classCM},
+	{0x11F34, 0x11F3A, classCM},
+	{0x11F3E, 0x11F42, classCM},
+	{0x11F43, 0x11F44, classBA},
+	{0x11F45, 0x11F4F, classID},
+	{0x11F50, 0x11F59, classNU},
+	{0x11FDD, 0x11FE0, classPO},
+	{0x11FFF, 0x11FFF, classBA},
+	{0x12470, 0x12474, classBA},
+	{0x13258, 0x1325A, classOP},
+	{0x1325B, 0x1325D, classCL},
+	{0x13282, 0x13282, classCL},
+	{0x13286, 0x13286, classOP},
+	{0x13287, 0x13287, classCL},
+	{0x13288, 0x13288, classOP},
+	{0x13289, 0x13289, classCL},
+	{0x13379, 0x13379, classOP},
+	{0x1337A, 0x1337B, classCL},
+	{0x13430, 0x13436, classGL},
+	{0x13437, 0x13437, classOP},
+	{0x13438, 0x13438, classCL},
+	{0x13439, 0x1343B, classGL},
+	{0x1343C, 0x1343C, classOP},
+	{0x1343D, 0x1343D, classCL},
+	{0x1343E, 0x1343E, classOP},
+	{0x1343F, 0x1343F, classCL},
+	{0x13440, 0x13440, classCM},
+	{0x13447, 0x13455, classCM},
+	{0x145CE, 0x145CE, classOP},
+	{0x145CF, 0x145CF, classCL},
+	{0x16A60, 0x16A69, classNU},
+	{0x16A6E, 0x16A6F, classBA},
+	{0x16AC0, 0x16AC9, classNU},
+	{0x16AF0, 0x16AF4, classCM},
+	{0x16AF5, 0x16AF5, classBA},
+	{0x16B30, 0x16B36, classCM},
+	{0x16B37, 0x16B39, classBA},
+	{0x16B44, 0x16B44, classBA},
+	{0x16B50, 0x16B59, classNU},
+	{0x16E97, 0x16E98, classBA},
+	{0x16F4F, 0x16F4F, classCM},
+	{0x16F51, 0x16F87, classCM},
+	{0x16F8F, 0x16F92, classCM},
+	{0x16FE0, 0x16FE3, classNS},
+	{0x16FE4, 0x16FE4, classGL},
+	{0x16FF0, 0x16FF1, classCM},
+	{0x17000, 0x187F7, classID},
+	{0x18800, 0x18AFF, classID},
+	{0x18D00, 0x18D08, classID},
+	{0x1B000, 0x1B122, classID},
+	{0x1B132, 0x1B132, classCJ},
+	{0x1B150, 0x1B152, classCJ},
+	{0x1B155, 0x1B155, classCJ},
+	{0x1B164, 0x1B167, classCJ},
+	{0x1B170, 0x1B2FB, classID},
+	{0x1BC9D, 0x1BC9E, classCM},
+	{0x1BC9F, 0x1BC9F, classBA},
+	{0x1BCA0, 0x1BCA3, classCM},
+	{0x1CF00, 0x1CF2D, classCM},
+	{0x1CF30, 0x1CF46, classCM},
+	{0x1D165, 0x1D169, classCM},
+	{0x1D16D, 0x1D182, classCM},
+	{0x1D185, 0x1D18B, classCM},
+	{0x1D1AA, 0x1D1AD, classCM},
+	{0x1D242, 0x1D244, classCM},
+	{0x1D7CE, 0x1D7FF, classNU},
+	{0x1DA00, 0x1DA36, classCM},
+	{0x1DA3B, 0x1DA6C, classCM},
+	{0x1DA75, 0x1DA75, classCM},
+	{0x1DA84, 0x1DA84, classCM},
+	{0x1DA87, 0x1DA8A, classBA},
+	{0x1DA9B, 0x1DA9F, classCM},
+	{0x1DAA1, 0x1DAAF, classCM},
+	{0x1E000, 0x1E006, classCM},
+	{0x1E008, 0x1E018, classCM},
+	{0x1E01B, 0x1E021, classCM},
+	{0x1E023, 0x1E024, classCM},
+	{0x1E026, 0x1E02A, classCM},
+	{0x1E08F, 0x1E08F, classCM},
+	{0x1E130, 0x1E136, classCM},
+	{0x1E140, 0x1E149, classNU},
+	{0x1E2AE, 0x1E2AE, classCM},
+	{0x1E2EC, 0x1E2EF, classCM},
+	{0x1E2F0, 0x1E2F9, classNU},
+	{0x1E2FF, 0x1E2FF, classPR},
+	{0x1E4EC, 0x1E4EF, classCM},
+	{0x1E4F0, 0x1E4F9, classNU},
+	{0x1E8D0, 0x1E8D6, classCM},
+	{0x1E944, 0x1E94A, classCM},
+	{0x1E950, 0x1E959, classNU},
+	{0x1E95E, 0x1E95F, classOP},
+	{0x1ECAC, 0x1ECAC, classPO},
+	{0x1ECB0, 0x1ECB0, classPO},
+	{0x1F000, 0x1F0FF, classID},
+	{0x1F10D, 0x1F10F, classID},
+	{0x1F16D, 0x1F16F, classID},
+	{0x1F1AD, 0x1F1E5, classID},
+	{0x1F1E6, 0x1F1FF, classRI},
+	{0x1F200, 0x1F384, classID},
+	{0x1F385, 0x1F385, classEB},
+	{0x1F386, 0x1F39B, classID},
+	{0x1F39E, 0x1F3B4, classID},
+	{0x1F3B7, 0x1F3BB, classID},
+	{0x1F3BD, 0x1F3C1, classID},
+	{0x1F3C2, 0x1F3C4, classEB},
+	{0x1F3C5, 0x1F3C6, classID},
+	{0x1F3C7, 0x1F3C7, classEB},
+	{0x1F3C8, 0x1F3C9, classID},
+	{0x1F3CA, 0x1F3CC, classEB},
+	{0x1F3CD, 0x1F3FA, classID},
+	{0x1F3FB, 0x1F3FF, classEM},
+	{0x1F400, 0x1F441, classID},
+	{0x1F442, 0x1F443, classEB},
+	{0x1F444, 0x1F445, classID},
+	{0x1F446, 0x1F450, classEB},
+	{0x1F451, 0x1F465, classID},
+	{0x1F466, 0x1F478, classEB},
+	{0x1F479, 0x1F47B, classID},
+	{0x1F47C, 0x1F47C, classEB},
+	{0x1F47D, 0x1F480, classID},
+	{0x1F481, 0x1F483, classEB},
+	{0x1F484, 0x1F484, classID},
+	{0x1F485, 0x1F487, classEB},
+	{0x1F488, 0x1F48E, classID},
+	{0x1F48F, 0x1F48F, classEB},
+	{0x1F490, 0x1F490, classID},
+	{0x1F491, 0x1F491, classEB},
+	{0x1F492, 0x1F49F, classID},
+	{0x1F4A1, 0x1F4A1, classID},
+	{0x1F4A3, 0x1F4A3, classID},
+	{0x1F4A5, 0x1F4A9, classID},
+	{0x1F4AA, 0x1F4AA, classEB},
+	{0x1F4AB, 0x1F4AE, classID},
+	{0x1F4B0, 0x1F4B0, classID},
+	{0x1F4B3, 0x1F4FF, classID},
+	{0x1F507, 0x1F516, classID},
+	{0x1F525, 0x1F531, classID},
+	{0x1F54A, 0x1F573, classID},
+	{0x1F574, 0x1F575, classEB},
+	{0x1F576, 0x1F579, classID},
+	{0x1F57A, 0x1F57A, classEB},
+	{0x1F57B, 0x1F58F, classID},
+	{0x1F590, 0x1F590, classEB},
+	{0x1F591, 0x1F594, classID},
+	{0x1F595, 0x1F596, classEB},
+	{0x1F597, 0x1F5D3, classID},
+	{0x1F5DC, 0x1F5F3, classID},
+	{0x1F5FA, 0x1F644, classID},
+	{0x1F645, 0x1F647, classEB},
+	{0x1F648, 0x1F64A, classID},
+	{0x1F64B, 0x1F64F, classEB},
+	{0x1F676, 0x1F678, classQU},
+	{0x1F679, 0x1F67B, classNS},
+	{0x1F680, 0x1F6A2, classID},
+	{0x1F6A3, 0x1F6A3, classEB},
+	{0x1F6A4, 0x1F6B3, classID},
+	{0x1F6B4, 0x1F6B6, classEB},
+	{0x1F6B7, 0x1F6BF, classID},
+	{0x1F6C0, 0x1F6C0, classEB},
+	{0x1F6C1, 0x1F6CB, classID},
+	{0x1F6CC, 0x1F6CC, classEB},
+	{0x1F6CD, 0x1F6FF, classID},
+	{0x1F774, 0x1F77F, classID},
+	{0x1F7D5, 0x1F7FF, classID},
+	{0x1F80C, 0x1F80F, classID},
+	{0x1F848, 0x1F84F, classID},
+	{0x1F85A, 0x1F85F, classID},
+	{0x1F888, 0x1F88F, classID},
+	{0x1F8AE, 0x1F8FF, classID},
+	{0x1F90C, 0x1F90C, classEB},
+	{0x1F90D, 0x1F90E, classID},
+	{0x1F90F, 0x1F90F, classEB},
+	{0x1F910, 0x1F917, classID},
+	{0x1F918, 0x1F91F, classEB},
+	{0x1F920, 0x1F925, classID},
+	{0x1F926, 0x1F926, classEB},
+	{0x1F927, 0x1F92F, classID},
+	{0x1F930, 0x1F939, classEB},
+	{0x1F93A, 0x1F93B, classID},
+	{0x1F93C, 0x1F93E, classEB},
+	{0x1F93F, 0x1F976, classID},
+	{0x1F977, 0x1F977, classEB},
+	{0x1F978, 0x1F9B4, classID},
+	{0x1F9B5, 0x1F9B6, classEB},
+	{0x1F9B7, 0x1F9B7, classID},
+	{0x1F9B8, 0x1F9B9, classEB},
+	{0x1F9BA, 0x1F9BA, classID},
+	{0x1F9BB, 0x1F9BB, classEB},
+	{0x1F9BC, 0x1F9CC, classID},
+	{0x1F9CD, 0x1F9CF, classEB},
+	{0x1F9D0, 0x1F9D0, classID},
+	{0x1F9D1, 0x1F9DD, classEB},
+	{0x1F9DE, 0x1F9FF, classID},
+	{0x1FA54, 0x1FAC2, classID},
+	{0x1FAC3, 0x1FAC5, classEB},
+	{0x1FAC6, 0x1FAEF, classID},
+	{0x1FAF0, 0x1FAF8, classEB},
+	{0x1FAF9, 0x1FAFF, classID},
+	{0x1FBF0, 0x1FBF9, classNU},
+	{0x1FC00, 0x1FFFD, classID},
+	{0x20000, 0x2FFFD, classID},
+	{0x30000, 0x3FFFD, classID},
+	{0xE0001, 0xE0001, classCM},
+	{0xE0020, 0xE007F, classCM},
+	{0xE0100, 0xE01EF, classCM},
+}
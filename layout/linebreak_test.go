@@ -0,0 +1,78 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// breakIndexes returns the indexes of the breaks, with mandatory breaks negated.
+func breakIndexes(text string) []int {
+	var indexes []int
+	for _, b := range LineBreaks(text) {
+		if b.Mandatory {
+			indexes = append(indexes, -b.Index)
+		} else {
+			indexes = append(indexes, b.Index)
+		}
+	}
+	return indexes
+}
+
+func TestLineBreaks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{"empty", "", nil},
+		{"single word", "word", []int{-4}},
+		{"spaces", "a quick  fox", []int{2, 9, -12}},
+		{"mandatory", "one\ntwo", []int{-4, -7}},
+		{"crlf", "one\r\ntwo", []int{-5, -8}},
+		{"hyphen", "well-known", []int{5, -10}},
+		{"soft hyphen", "hy­phen", []int{4, -8}},
+		{"no break before punctuation", "end. next", []int{5, -9}},
+		{"parentheses", "a (b) c", []int{2, 6, -7}},
+		{"numbers", "$1,000.50 off", []int{10, -13}},
+		{"percent", "50% off", []int{4, -7}},
+		{"non-breaking space", "a b c", []int{5, -6}},
+		{"word joiner", "a⁠b", []int{-5}},
+		{"zero width space", "a​b", []int{4, -5}},
+		{"combining mark", "é x", []int{4, -5}},
+		{"ideographs", "日本語", []int{3, 6, -9}},
+		{"ideographic full stop", "日本。語", []int{3, 9, -12}},
+		{"small kana", "ちょっと", []int{9, -12}},
+		{"hangul", "한국어 단어", []int{3, 6, 10, 13, -16}},
+		{"regional indicators", "🇬🇧🇫🇷", []int{8, -16}},
+		{"emoji modifier", "👍🏽👍", []int{8, -12}},
+		{"quotation", `say "hi" now`, []int{4, 9, -12}},
+		{"em dash", "a—b", []int{1, 4, -5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, breakIndexes(test.text))
+		})
+	}
+}
+
+func TestLineBreakClass(t *testing.T) {
+	assert.Equal(t, classAL, lineBreakClass('a'))
+	assert.Equal(t, classNU, lineBreakClass('٣'))
+	assert.Equal(t, classHL, lineBreakClass('א'))
+	assert.Equal(t, classCM, lineBreakClass('́'))
+	assert.Equal(t, classID, lineBreakClass('字'))
+	assert.Equal(t, classH2, lineBreakClass('가'))
+	assert.Equal(t, classH3, lineBreakClass('각'))
+	assert.Equal(t, classNS, lineBreakClass('ゃ'))
+	assert.Equal(t, classAL, lineBreakClass('ก'))
+	assert.Equal(t, classCM, lineBreakClass('ั'))
+	assert.Equal(t, classOP, lineBreakClass('「'))
+	assert.Equal(t, classCL, lineBreakClass('」'))
+	// Characters whose classes do not follow from their general category or block.
+	assert.Equal(t, classOP, lineBreakClass(0xFF5F))
+	assert.Equal(t, classBA, lineBreakClass(0x17D4))
+	assert.Equal(t, classB2, lineBreakClass(0x2E3A))
+	assert.Equal(t, classAL, lineBreakClass(0x00B7))
+}
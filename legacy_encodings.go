@@ -0,0 +1,121 @@
+package freetype
+
+import (
+	"sync"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// Legacy text encodings, of the names and charmaps of older fonts.
+
+// macRoman are the characters of the bytes 0x80 to 0xFF of the Mac OS Roman encoding.
+// The bytes 0x00 to 0x7F are ASCII.
+var macRoman = [128]rune{
+	0x00C4, 0x00C5, 0x00C7, 0x00C9, 0x00D1, 0x00D6, 0x00DC, 0x00E1, // 0x80
+	0x00E0, 0x00E2, 0x00E4, 0x00E3, 0x00E5, 0x00E7, 0x00E9, 0x00E8, // 0x88
+	0x00EA, 0x00EB, 0x00ED, 0x00EC, 0x00EE, 0x00EF, 0x00F1, 0x00F3, // 0x90
+	0x00F2, 0x00F4, 0x00F6, 0x00F5, 0x00FA, 0x00F9, 0x00FB, 0x00FC, // 0x98
+	0x2020, 0x00B0, 0x00A2, 0x00A3, 0x00A7, 0x2022, 0x00B6, 0x00DF, // 0xA0
+	0x00AE, 0x00A9, 0x2122, 0x00B4, 0x00A8, 0x2260, 0x00C6, 0x00D8, // 0xA8
+	0x221E, 0x00B1, 0x2264, 0x2265, 0x00A5, 0x00B5, 0x2202, 0x2211, // 0xB0
+	0x220F, 0x03C0, 0x222B, 0x00AA, 0x00BA, 0x03A9, 0x00E6, 0x00F8, // 0xB8
+	0x00BF, 0x00A1, 0x00AC, 0x221A, 0x0192, 0x2248, 0x2206, 0x00AB, // 0xC0
+	0x00BB, 0x2026, 0x00A0, 0x00C0, 0x00C3, 0x00D5, 0x0152, 0x0153, // 0xC8
+	0x2013, 0x2014, 0x201C, 0x201D, 0x2018, 0x2019, 0x00F7, 0x25CA, // 0xD0
+	0x00FF, 0x0178, 0x2044, 0x20AC, 0x2039, 0x203A, 0xFB01, 0xFB02, // 0xD8
+	0x2021, 0x00B7, 0x201A, 0x201E, 0x2030, 0x00C2, 0x00CA, 0x00C1, // 0xE0
+	0x00CB, 0x00C8, 0x00CD, 0x00CE, 0x00CF, 0x00CC, 0x00D3, 0x00D4, // 0xE8
+	0xF8FF, 0x00D2, 0x00DA, 0x00DB, 0x00D9, 0x0131, 0x02C6, 0x02DC, // 0xF0
+	0x00AF, 0x02D8, 0x02D9, 0x02DA, 0x00B8, 0x02DD, 0x02DB, 0x02C7, // 0xF8
+}
+
+// decodeMacRoman decodes Mac OS Roman text.
+func decodeMacRoman(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		if b < 0x80 {
+			runes[i] = rune(b)
+		} else {
+			runes[i] = macRoman[b-0x80]
+		}
+	}
+	return string(runes)
+}
+
+// decodeUTF16BE decodes big-endian UTF-16 text, as used by SFNT ‘name’ tables.
+func decodeUTF16BE(data []byte) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// macRomanCodes are the bytes of the characters of the Mac OS Roman encoding that are not ASCII.
+var macRomanCodes = sync.OnceValue(func() map[rune]byte {
+	codes := make(map[rune]byte, len(macRoman))
+	for i, r := range macRoman {
+		codes[r] = byte(0x80 + i)
+	}
+	return codes
+})
+
+// multiByteEncodings are the encodings of the legacy CJK charmaps, as used by their cmap subtables.
+var multiByteEncodings = map[Encoding]encoding.Encoding{
+	ENCODING_SJIS:    japanese.ShiftJIS,
+	ENCODING_PRC:     simplifiedchinese.GBK,
+	ENCODING_BIG5:    traditionalchinese.Big5,
+	ENCODING_WANSUNG: korean.EUCKR,
+}
+
+/*
+EncodeChar returns the character code of a Unicode character in the encoding of a charmap,
+and false if the encoding does not have the character, or is not supported.
+
+The supported encodings are
+  - ENCODING_UNICODE, for which the code is the character,
+  - ENCODING_APPLE_ROMAN, the Mac OS Roman encoding,
+  - ENCODING_SJIS, ENCODING_PRC, ENCODING_BIG5 and ENCODING_WANSUNG,
+    for which the bytes of a multi-byte character form a big-endian code, such as 0x82A0 for 'あ' in Shift JIS,
+  - and ENCODING_MS_SYMBOL, for which the characters U+0020 to U+00FF are mapped to U+F020 to U+F0FF,
+    where symbol fonts have their glyphs, and other characters are unchanged.
+*/
+func EncodeChar(enc Encoding, r rune) (ULong, bool) {
+	switch enc {
+	case ENCODING_UNICODE:
+		return ULong(r), true
+	case ENCODING_MS_SYMBOL:
+		if r >= 0x20 && r <= 0xFF {
+			return ULong(0xF000 + r), true
+		}
+		return ULong(r), true
+	case ENCODING_APPLE_ROMAN:
+		if r < 0x80 {
+			return ULong(r), true
+		}
+		code, ok := macRomanCodes()[r]
+		return ULong(code), ok
+	}
+
+	e, ok := multiByteEncodings[enc]
+	if !ok {
+		return 0, false
+	}
+	if r < 0x80 {
+		return ULong(r), true
+	}
+	encoded, err := e.NewEncoder().Bytes([]byte(string(r)))
+	if err != nil || len(encoded) == 0 || len(encoded) > 4 {
+		return 0, false
+	}
+	var code ULong
+	for _, b := range encoded {
+		code = code<<8 | ULong(b)
+	}
+	return code, true
+}
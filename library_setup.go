@@ -1,6 +1,8 @@
 package freetype
 
 import (
+	"runtime"
+
 	"modernc.org/libc"
 	"modernc.org/libfreetype"
 )
@@ -15,6 +17,11 @@ https://freetype.org/freetype2/docs/reference/ft2-library_setup.html#ft_library
 type Library struct {
 	library libfreetype.TFT_Library
 	tls     *libc.TLS
+	// customMemory is the library's memory manager if it was created with NewLibrary.
+	customMemory libfreetype.TFT_Memory
+
+	state *libraryState
+	owner *owner
 }
 
 /*
@@ -32,16 +39,44 @@ func Init() (Library, error) {
 	if err != Err_Ok {
 		return Library{}, newError(err, "failed to init library")
 	}
-	return *lib, nil
+	library := *lib
+	newLibraryState(&library)
+	return library, nil
 }
 
-// Done destroys the FreeType library object represented by Library,
-// and all of its children, including resources, drivers, faces, sizes, etc.
-//
-// https://freetype.org/freetype2/docs/reference/ft2-library_setup.html#ft_done_freetype
+/*
+Done destroys the FreeType library object represented by Library,
+and all of its children, including resources, drivers, faces, sizes, etc.
+
+Faces, and other objects, that have not been discarded are discarded,
+and are reported as leaks if TrackLeaks has been called.
+A library that is garbage collected without being discarded is not discarded then,
+as memory that FreeType allocated for it may still be referenced by pointers that the garbage collector does not see,
+such as those returned by Face.Rec. It is reported as a leak if TrackLeaks has been called.
+Using the library after it has been discarded returns an error wrapping ErrInvalidLibraryHandle.
+
+https://freetype.org/freetype2/docs/reference/ft2-library_setup.html#ft_done_freetype
+https://freetype.org/freetype2/docs/reference/ft2-module_management.html#ft_done_library
+*/
 func (lib Library) Done() error {
-	err := libfreetype.XFT_Done_FreeType(lib.tls, lib.library)
-	return newError(err, "failed to destroy library")
+	if lib.state == nil {
+		return newError(Err_Invalid_Library_Handle, "failed to destroy library")
+	}
+	lib.owner.cleanup.Stop()
+	return lib.state.destroy()
+}
+
+// Close discards the library, as Done does. It implements io.Closer.
+func (lib Library) Close() error {
+	return lib.Done()
+}
+
+// memory returns the memory manager used by the library.
+func (lib Library) memory() libfreetype.TFT_Memory {
+	if lib.handle() == 0 {
+		return 0
+	}
+	return fromUintptr[libfreetype.TFT_LibraryRec](lib.handle()).Fmemory
 }
 
 // Version returns the version of the FreeType library being used.
@@ -53,8 +88,9 @@ func (lib Library) Done() error {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-library_setup.html#ft_library_version
 func (lib Library) Version() (int, int, int) {
+	defer runtime.KeepAlive(lib.owner)
 	var major, minor, patch libfreetype.TFT_Int
-	libfreetype.XFT_Library_Version(lib.tls, lib.library,
+	libfreetype.XFT_Library_Version(lib.tls, lib.handle(),
 		toUintptr(&major), toUintptr(&minor), toUintptr(&patch))
 	return int(major), int(minor), int(patch)
 }
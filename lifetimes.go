@@ -0,0 +1,322 @@
+package freetype
+
+import (
+	"log"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"modernc.org/libc"
+	"modernc.org/libfreetype"
+)
+
+// Tracking of the lifetimes of libraries and their objects,
+// to discard objects that the application no longer references, and to find objects that are not discarded.
+
+/*
+owner is referenced by the copies of a Library or Face value, and by nothing else that the library references,
+so that it becomes unreachable when the application can no longer use the Library or Face.
+A cleanup attached to it deals with the object, if it was not discarded with Done.
+Methods that pass the object to FreeType keep its owner alive until they return.
+*/
+type owner struct {
+	// parent is the owner of the library that a face was created from, which must outlive the face.
+	parent  *owner
+	cleanup runtime.Cleanup
+}
+
+// libraryState is the state of a library, that is shared by the copies of its Library value.
+type libraryState struct {
+	// The library's handles are copied from its Library, so that it can be discarded by its cleanup.
+	library      libfreetype.TFT_Library
+	tls          *libc.TLS
+	customMemory libfreetype.TFT_Memory
+
+	mutex sync.Mutex
+	done  bool
+	// objects are the library's objects that have not been discarded, by id.
+	objects map[uint64]*object
+	nextID  uint64
+	// collected are the ids of objects that were garbage collected without being discarded.
+	// They are discarded by the next call that creates an object, or by Library.Done,
+	// as a library must only be used by one goroutine at a time.
+	collected []uint64
+	// mmVars are the ids of the MMVar structures returned by GetMMVar.
+	mmVars map[*MMVar]uint64
+	// forgetSVGRenderer removes the SVGRenderer that was set with SetSVGRenderer, if any,
+	// when the library is discarded.
+	forgetSVGRenderer func()
+
+	tracking bool
+	report   func(leaks []Leak)
+	leaks    []Leak
+	// stack is the stack trace of the call to TrackLeaks.
+	stack string
+}
+
+// object is an object that was created from a library, and that must be discarded.
+type object struct {
+	kind    string
+	stack   string
+	discard func()
+}
+
+/*
+Leak describes an object of a Library that was not discarded with its Done method.
+Leaks are reported when the library is discarded, if TrackLeaks has been called.
+*/
+type Leak struct {
+	// Kind is the type of the object, such as "Library", "Face" or "MMVar".
+	Kind string
+	// Stack is the stack trace of the goroutine that created the object,
+	// or that called TrackLeaks for a Library.
+	Stack string
+	// Collected is true if the object was discarded when it was garbage collected,
+	// rather than being discarded with the library.
+	Collected bool
+}
+
+// newLibraryState returns the state of a new library, and attaches a cleanup that reports it as leaked to its owner.
+func newLibraryState(lib *Library) {
+	lib.state = &libraryState{
+		library:      lib.library,
+		tls:          lib.tls,
+		customMemory: lib.customMemory,
+		objects:      map[uint64]*object{},
+		mmVars:       map[*MMVar]uint64{},
+	}
+	lib.owner = &owner{}
+	lib.owner.cleanup = runtime.AddCleanup(lib.owner, (*libraryState).leaked, lib.state)
+}
+
+/*
+TrackLeaks enables the tracking of the objects created from the library, such as faces,
+that are not discarded with their Done methods.
+The stack trace of the goroutine that creates each object is recorded, which makes creating objects slower.
+
+When the library is discarded with Done, report is called with the objects that were garbage collected
+without being discarded, and the objects that had not been discarded.
+If the library is garbage collected without being discarded, report is called, from another goroutine,
+with the library and the objects that had not been discarded.
+If report is nil, the objects are written to the standard logger.
+
+Only objects that are created after TrackLeaks is called are tracked.
+*/
+func (lib Library) TrackLeaks(report func(leaks []Leak)) {
+	if lib.state == nil {
+		return
+	}
+	lib.state.mutex.Lock()
+	defer lib.state.mutex.Unlock()
+	lib.state.tracking = true
+	lib.state.report = report
+	lib.state.stack = string(debug.Stack())
+}
+
+// handle returns the library's FreeType handle, or 0 (a null handle) if the library has been discarded,
+// so that FreeType returns Err_Invalid_Library_Handle rather than using freed memory.
+func (lib Library) handle() libfreetype.TFT_Library {
+	if lib.state != nil && lib.state.isDone() {
+		return 0
+	}
+	return lib.library
+}
+
+func (state *libraryState) isDone() bool {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	return state.done
+}
+
+// register records an object that has been created from the library, and returns its id.
+// discard is called to discard the object if the library is discarded before the object.
+func (state *libraryState) register(kind string, discard func()) uint64 {
+	obj := &object{kind: kind, discard: discard}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	if state.tracking {
+		obj.stack = string(debug.Stack())
+	}
+	state.nextID++
+	state.objects[state.nextID] = obj
+	return state.nextID
+}
+
+// unregister records that an object has been discarded.
+func (state *libraryState) unregister(id uint64) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	delete(state.objects, id)
+}
+
+// collect is the cleanup of an object that was garbage collected without being discarded.
+// It may be called from any goroutine, so the object is only discarded later, by discardCollected.
+func (state *libraryState) collect(id uint64) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.collected = append(state.collected, id)
+}
+
+// discardCollected discards the objects that were garbage collected without being discarded.
+func (state *libraryState) discardCollected() {
+	if state == nil {
+		return
+	}
+	state.mutex.Lock()
+	var discards []func()
+	for _, id := range state.collected {
+		if obj, ok := state.objects[id]; ok {
+			delete(state.objects, id)
+			if state.tracking {
+				state.leaks = append(state.leaks, Leak{Kind: obj.kind, Stack: obj.stack, Collected: true})
+			}
+			discards = append(discards, obj.discard)
+		}
+	}
+	state.collected = nil
+	state.mutex.Unlock()
+
+	for _, discard := range discards {
+		discard()
+	}
+}
+
+/*
+leaked is the cleanup of a library that was garbage collected without being discarded.
+It reports the library and its objects as leaks, if TrackLeaks has been called, but does not discard them.
+Memory that FreeType allocated for them, such as a glyph slot that a GlyphSlotRec points to,
+may still be in use, as the garbage collector does not see pointers to it.
+And the cleanup runs on another goroutine, which must not use the library's TLS.
+*/
+func (state *libraryState) leaked() {
+	state.mutex.Lock()
+	if !state.tracking {
+		state.mutex.Unlock()
+		return
+	}
+	leaks := append(state.leaks, Leak{Kind: "Library", Stack: state.stack, Collected: true})
+	for _, obj := range state.objects {
+		leaks = append(leaks, Leak{Kind: obj.kind, Stack: obj.stack})
+	}
+	state.leaks = nil
+	report := state.report
+	state.mutex.Unlock()
+
+	if report == nil {
+		report = logLeaks
+	}
+	report(leaks)
+}
+
+// destroy discards the library's remaining objects, reports any leaks, and discards the library.
+// It is called by Library.Done.
+func (state *libraryState) destroy() error {
+	state.discardCollected()
+
+	state.mutex.Lock()
+	if state.done {
+		state.mutex.Unlock()
+		return newError(Err_Invalid_Library_Handle, "the library has already been discarded")
+	}
+	leaks := state.leaks
+	var discards []func()
+	for _, obj := range state.objects {
+		if state.tracking {
+			leaks = append(leaks, Leak{Kind: obj.kind, Stack: obj.stack})
+		}
+		discards = append(discards, obj.discard)
+	}
+	state.objects = nil
+	state.leaks = nil
+	report := state.report
+	tracking := state.tracking
+	state.mutex.Unlock()
+
+	for _, discard := range discards {
+		discard()
+	}
+	if tracking && len(leaks) > 0 {
+		if report == nil {
+			report = logLeaks
+		}
+		report(leaks)
+	}
+
+	var err error
+	if state.customMemory != 0 {
+		if err_ := libfreetype.XFT_Done_Library(state.tls, state.library); err_ != Err_Ok {
+			err = newError(err_, "failed to destroy library")
+		} else {
+			doneMemoryRec(state.customMemory)
+		}
+	} else {
+		err = newError(libfreetype.XFT_Done_FreeType(state.tls, state.library), "failed to destroy library")
+	}
+
+	state.mutex.Lock()
+	state.done = true
+	forgetSVGRenderer := state.forgetSVGRenderer
+	state.mutex.Unlock()
+	if forgetSVGRenderer != nil {
+		forgetSVGRenderer()
+	}
+	return err
+}
+
+// mmVarLibraries are the states of the libraries of the MMVar structures returned by GetMMVar.
+var mmVarLibraries = struct {
+	sync.Mutex
+	states map[*MMVar]*libraryState
+}{
+	states: map[*MMVar]*libraryState{},
+}
+
+// registerMMVar records an MMVar returned by GetMMVar, which is freed with the library if it is not freed first.
+func (state *libraryState) registerMMVar(master *MMVar) {
+	id := state.register("MMVar", func() {
+		mmVarLibraries.Lock()
+		delete(mmVarLibraries.states, master)
+		mmVarLibraries.Unlock()
+		libfreetype.XFT_Done_MM_Var(state.tls, state.library, toUintptr(master))
+	})
+	state.mutex.Lock()
+	state.mmVars[master] = id
+	state.mutex.Unlock()
+
+	mmVarLibraries.Lock()
+	mmVarLibraries.states[master] = state
+	mmVarLibraries.Unlock()
+}
+
+// unregisterMMVar records that an MMVar is being freed, and reports whether it had been returned by GetMMVar.
+func (state *libraryState) unregisterMMVar(master *MMVar) bool {
+	state.mutex.Lock()
+	id, ok := state.mmVars[master]
+	delete(state.mmVars, master)
+	delete(state.objects, id)
+	state.mutex.Unlock()
+
+	mmVarLibraries.Lock()
+	delete(mmVarLibraries.states, master)
+	mmVarLibraries.Unlock()
+	return ok
+}
+
+// mmVarLibrary returns the state of the library of an MMVar, or nil if it is not allocated.
+func mmVarLibrary(master *MMVar) *libraryState {
+	mmVarLibraries.Lock()
+	defer mmVarLibraries.Unlock()
+	return mmVarLibraries.states[master]
+}
+
+func logLeaks(leaks []Leak) {
+	for _, leak := range leaks {
+		if leak.Collected {
+			log.Printf("freetype: a %s was garbage collected without being discarded, it was created by\n%s",
+				leak.Kind, leak.Stack)
+		} else {
+			log.Printf("freetype: a %s was not discarded before its library, it was created by\n%s",
+				leak.Kind, leak.Stack)
+		}
+	}
+}
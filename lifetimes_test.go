@@ -0,0 +1,147 @@
+package freetype
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+// collectGarbage runs the garbage collector until done returns true, and the cleanups have run.
+func collectGarbage(t *testing.T, done func() bool) {
+	t.Helper()
+	for range 100 {
+		runtime.GC()
+		if done() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("the garbage was not collected")
+}
+
+func TestFaceUseAfterDone(t *testing.T) {
+	lib, _ := Init()
+	face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+
+	assert.Nil(t, face.Close())
+	assert.ErrorIs(t, face.SetPixelSizes(0, 16), ErrInvalidFaceHandle)
+	assert.ErrorIs(t, face.LoadGlyph(1, LOAD_DEFAULT), ErrInvalidFaceHandle)
+	assert.Equal(t, UInt(0), face.GetCharIndex('a'))
+	assert.Nil(t, face.Rec())
+	assert.ErrorIs(t, face.Done(), ErrInvalidFaceHandle)
+}
+
+func TestFaceDoneWithReference(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, face.Reference())
+
+	assert.Nil(t, face.Done())
+	assert.Nil(t, face.SetPixelSizes(0, 16))
+	assert.Nil(t, face.Done())
+	assert.ErrorIs(t, face.SetPixelSizes(0, 16), ErrInvalidFaceHandle)
+}
+
+func TestLibraryUseAfterDone(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+
+	assert.Nil(t, lib.Close())
+	// The library's faces are discarded with it.
+	assert.ErrorIs(t, face.LoadGlyph(1, LOAD_DEFAULT), ErrInvalidFaceHandle)
+	_, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.ErrorIs(t, err, ErrInvalidLibraryHandle)
+	assert.ErrorIs(t, lib.Done(), ErrInvalidLibraryHandle)
+}
+
+func TestMMVarClose(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+	master, err := face.GetMMVar()
+	assert.Nil(t, err)
+
+	assert.Nil(t, master.Close())
+	assert.ErrorIs(t, master.Close(), ErrInvalidArgument)
+	assert.ErrorIs(t, lib.DoneMMVar(master), ErrInvalidArgument)
+}
+
+// newLeakedFace creates a face, and discards its only reference to it.
+func newLeakedFace(t *testing.T, lib Library) {
+	_, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+}
+
+func TestLibraryTrackLeaks(t *testing.T) {
+	lib, _ := Init()
+	var leaks []Leak
+	lib.TrackLeaks(func(l []Leak) { leaks = l })
+
+	newLeakedFace(t, lib)
+	collectGarbage(t, func() bool {
+		lib.state.mutex.Lock()
+		defer lib.state.mutex.Unlock()
+		return len(lib.state.collected) > 0
+	})
+
+	// The collected face is discarded by the next call that creates an object.
+	face, err := lib.NewMemoryFace(font.RobotoVariable, 0)
+	assert.Nil(t, err)
+	assert.Len(t, lib.state.objects, 1)
+	_, err = face.GetMMVar()
+	assert.Nil(t, err)
+	done, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, done.Done())
+
+	assert.Nil(t, lib.Done())
+	var got []Leak
+	for _, leak := range leaks {
+		assert.True(t, strings.Contains(leak.Stack, "TestLibraryTrackLeaks"), leak.Stack)
+		if leak.Collected {
+			assert.True(t, strings.Contains(leak.Stack, "newLeakedFace"), leak.Stack)
+		}
+		got = append(got, Leak{Kind: leak.Kind, Collected: leak.Collected})
+	}
+	assert.ElementsMatch(t, []Leak{{Kind: "Face", Collected: true}, {Kind: "Face"}, {Kind: "MMVar"}}, got)
+}
+
+func TestLibraryCollected(t *testing.T) {
+	memory := NewAccountingMemory(0)
+	reported := make(chan []Leak, 1)
+	slot := func() *GlyphSlotRec {
+		lib, err := NewLibrary(memory)
+		assert.Nil(t, err)
+		lib.TrackLeaks(func(leaks []Leak) { reported <- leaks })
+		face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, face.SetPixelSizes(0, 32))
+		assert.Nil(t, face.LoadChar('A', LOAD_DEFAULT))
+		return face.Rec().Glyph.Rec()
+	}()
+	allocated := memory.Current()
+	advance := slot.Advance
+
+	// The library is reported as a leak when it is garbage collected,
+	// but it is not discarded, as its memory may still be referenced, such as by the glyph slot.
+	var leaks []Leak
+	collectGarbage(t, func() bool {
+		select {
+		case leaks = <-reported:
+			return true
+		default:
+			return false
+		}
+	})
+	var got []Leak
+	for _, leak := range leaks {
+		got = append(got, Leak{Kind: leak.Kind, Collected: leak.Collected})
+	}
+	assert.ElementsMatch(t, []Leak{{Kind: "Library", Collected: true}, {Kind: "Face"}}, got)
+	assert.Equal(t, allocated, memory.Current())
+	assert.Equal(t, advance, slot.Advance)
+}
@@ -0,0 +1,72 @@
+package freetype
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+
+	"modernc.org/libfreetype"
+)
+
+// Using LZW-compressed font files.
+
+// lzwMagic is the header that identifies LZW-compressed (Unix compress, .Z) data.
+var lzwMagic = []byte{0x1f, 0x9d}
+
+// lzwChunkSize is the number of bytes that are read from an LZW stream at a time.
+const lzwChunkSize = 64 * 1024
+
+/*
+decompressLZW returns args that open the decompressed content of LZW-compressed font data,
+or the args unchanged if their data is not compressed with LZW.
+
+FreeType's LZW stream returns stale data when it seeks backwards after skipping forwards,
+which the glyph loading of TrueType fonts does, so the stream is not given to FT_Open_Face.
+Instead it is read from start to end, and the data is opened as memory that the face owns.
+
+https://freetype.org/freetype2/docs/reference/ft2-lzw.html#ft_stream_openlzw
+*/
+func (lib Library) decompressLZW(args OpenArgs) (OpenArgs, error) {
+	header, err := args.header()
+	if err != nil || !bytes.HasPrefix(header, lzwMagic) {
+		return args, err
+	}
+
+	compressed := header
+	if args.Flags&OPEN_MEMORY == 0 {
+		compressed, err = os.ReadFile(args.Pathname)
+		if err != nil {
+			return args, err
+		}
+	}
+	defer runtime.KeepAlive(compressed)
+	memory := lib.memory()
+	if memory == 0 {
+		return args, newError(Err_Invalid_Library_Handle, "failed to decompress LZW font data")
+	}
+
+	source, freeSource := alloc(lib.tls, libfreetype.TFT_StreamRec{})
+	defer freeSource()
+	*source = libfreetype.TFT_StreamRec{}
+	libfreetype.XFT_Stream_OpenMemory(lib.tls, toUintptr(source), toUintptr(&compressed[0]), ULong(len(compressed)))
+	source.Fmemory = memory
+
+	lzw, freeLZW := alloc(lib.tls, libfreetype.TFT_StreamRec{})
+	defer freeLZW()
+	*lzw = libfreetype.TFT_StreamRec{}
+	if err := libfreetype.XFT_Stream_OpenLZW(lib.tls, toUintptr(lzw), toUintptr(source)); err != Err_Ok {
+		return args, newError(err, "failed to open LZW stream")
+	}
+	defer libfreetype.XFT_Stream_Close(lib.tls, toUintptr(lzw))
+
+	var data []byte
+	chunk := make([]byte, lzwChunkSize)
+	for {
+		n := libfreetype.XFT_Stream_TryRead(lib.tls, toUintptr(lzw), toUintptr(&chunk[0]), ULong(len(chunk)))
+		data = append(data, chunk[:n]...)
+		if n < ULong(len(chunk)) {
+			break
+		}
+	}
+	return args.withMemory(data), nil
+}
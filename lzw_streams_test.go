@@ -0,0 +1,48 @@
+package freetype
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestLibraryNewMemoryFaceLZW(t *testing.T) {
+	lib, _ := Init()
+	defer lib.Done()
+	data, err := os.ReadFile("testdata/DejaVuSansMono.ttf.Z")
+	assert.Nil(t, err)
+
+	face, err := lib.NewMemoryFace(data, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadChar('A', LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
+
+	// The uncompressed data is the same as the font's.
+	table, err := face.TableBytes(MakeTag("glyf"))
+	assert.Nil(t, err)
+	plainFace, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+	plainTable, _ := plainFace.TableBytes(MakeTag("glyf"))
+	assert.Equal(t, plainTable, table)
+	assert.Nil(t, face.Done())
+
+	_, err = lib.NewMemoryFace([]byte{0x1f, 0x9d, 0x90}, 0)
+	assert.ErrorIs(t, err, ErrInvalidStreamOperation)
+}
+
+func TestLibraryNewFaceLZW(t *testing.T) {
+	lib, _ := Init()
+	defer lib.Done()
+	face, err := lib.NewFace("testdata/DejaVuSansMono.ttf.Z", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "DejaVu Sans Mono", face.Rec().FamilyName())
+	assert.Nil(t, face.Done())
+}
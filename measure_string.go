@@ -0,0 +1,193 @@
+package freetype
+
+import (
+	"runtime"
+
+	"modernc.org/libfreetype"
+)
+
+// Measurement of text, without rendering it.
+
+/*
+TextExtents describes the size of some text, as returned by MeasureString.
+
+All values are in 26.6 fixed-point format, and are relative to the text's origin.
+Like FreeType's other coordinates the y axis points up,
+so the ascender has a positive value and the descender has a negative value.
+*/
+type TextExtents struct {
+	// Advance is the displacement of the pen after the last glyph.
+	Advance Vector
+	// Logical is the box that is nominally occupied by the text.
+	// For horizontal text it spans the advance, and the size's ascender and descender.
+	// For vertical text it spans the advance, and half of the size's maximum advance on each side.
+	Logical BBox
+	// Ink is the control box of the glyphs' outlines, which contains all of the pixels
+	// that would be drawn. It is the zero BBox if there is no ink.
+	Ink BBox
+}
+
+/*
+MeasureString measures text, using a face at its current size.
+
+The glyphs are loaded with opts.LoadFlags, but are not rendered.
+Kerning and hinting corrections are applied in the same way as for DrawString,
+so the measurements are consistent with the text drawn with the same options.
+Horizontal bidirectional text is also reordered in the same way as by DrawString.
+
+If opts.LoadFlags includes LOAD_VERTICAL_LAYOUT, the text is measured as a vertical column,
+with the pen moving down and the origin at the top center of each glyph.
+Kerning is not applied to vertical text.
+
+The matrix of any transformation set with SetTransform is applied to all of the measurements.
+*/
+func (face Face) MeasureString(text string, opts DrawOptions) (TextExtents, error) {
+	matrix, _ := face.transform()
+	vertical := opts.LoadFlags&LOAD_VERTICAL_LAYOUT != 0
+	kerning := !vertical && !opts.NoKerning && face.HasKerning()
+
+	var extents TextExtents
+	hasInk := false
+	// The pen is untransformed. The transformation is applied to its positions as they are used.
+	var pen Vector
+	var previous UInt
+	var previousRsbDelta Pos
+
+	var glyphIndexes []UInt
+	if vertical {
+		for _, r := range text {
+			glyphIndexes = append(glyphIndexes, face.GetCharIndex(r))
+		}
+	} else {
+		glyphIndexes = face.visualGlyphs(text, opts.Direction)
+	}
+
+	for _, glyphIndex := range glyphIndexes {
+		if kerning && previous != 0 && glyphIndex != 0 {
+			kern, err := face.GetKerning(previous, glyphIndex, KERNING_DEFAULT)
+			if err != nil {
+				return TextExtents{}, err
+			}
+			pen.X += kern.X
+		}
+
+		if err := face.LoadGlyph(glyphIndex, opts.LoadFlags); err != nil {
+			return TextExtents{}, err
+		}
+		glyph := face.Rec().Glyph.Rec()
+
+		if !vertical {
+			if previousRsbDelta-glyph.LsbDelta > 32 {
+				pen.X -= 64
+			} else if previousRsbDelta-glyph.LsbDelta < -31 {
+				pen.X += 64
+			}
+			previousRsbDelta = glyph.RsbDelta
+		}
+		previous = glyphIndex
+
+		if cbox, ok := face.glyphCBox(glyph); ok {
+			origin := pen
+			if vertical {
+				// Move the glyph from its horizontal origin to its vertical origin.
+				origin.X += glyph.Metrics.VertBearingX - glyph.Metrics.HoriBearingX
+				origin.Y -= glyph.Metrics.VertBearingY + glyph.Metrics.HoriBearingY
+			}
+			origin = VectorTransform(origin, matrix)
+			cbox = BBox{
+				XMin: cbox.XMin + origin.X, YMin: cbox.YMin + origin.Y,
+				XMax: cbox.XMax + origin.X, YMax: cbox.YMax + origin.Y,
+			}
+			if hasInk {
+				extents.Ink = bboxUnion(extents.Ink, cbox)
+			} else {
+				extents.Ink = cbox
+				hasInk = true
+			}
+		}
+
+		if vertical {
+			// The vertical metrics are synthesized by FreeType for faces without them.
+			pen.Y -= glyph.Metrics.VertAdvance
+			continue
+		}
+		advance, err := face.GetAdvance(glyphIndex, opts.LoadFlags|LOAD_IGNORE_TRANSFORM)
+		if err != nil {
+			return TextExtents{}, err
+		}
+		// Convert from 16.16 to 26.6.
+		pen.X += Pos((advance + 1<<9) >> 10)
+	}
+
+	metrics := face.Rec().Size.Rec().Metrics
+	logical := BBox{XMin: min(0, pen.X), XMax: max(0, pen.X), YMin: metrics.Descender, YMax: metrics.Ascender}
+	if vertical {
+		logical = BBox{XMin: -metrics.MaxAdvance / 2, XMax: metrics.MaxAdvance / 2, YMin: pen.Y, YMax: 0}
+	}
+
+	extents.Advance = VectorTransform(pen, matrix)
+	extents.Logical = bboxTransform(logical, matrix)
+	return extents, nil
+}
+
+// glyphCBox returns the control box of the glyph in a glyph slot, relative to its origin.
+// It returns false if the glyph has no ink.
+func (face Face) glyphCBox(glyph *GlyphSlotRec) (BBox, bool) {
+	defer runtime.KeepAlive(face.owner)
+	switch glyph.Format {
+	case GLYPH_FORMAT_OUTLINE:
+		if glyph.Outline.Fn_points == 0 {
+			return BBox{}, false
+		}
+		var cbox BBox
+		libfreetype.XFT_Outline_Get_CBox(face.tls, toUintptr(&glyph.Outline), toUintptr(&cbox))
+		return cbox, true
+
+	case GLYPH_FORMAT_BITMAP:
+		if glyph.Bitmap.Width == 0 || glyph.Bitmap.Rows == 0 {
+			return BBox{}, false
+		}
+		xMin := Pos(glyph.BitmapLeft) * 64
+		yMax := Pos(glyph.BitmapTop) * 64
+		return BBox{
+			XMin: xMin, YMin: yMax - Pos(glyph.Bitmap.Rows)*64,
+			XMax: xMin + Pos(glyph.Bitmap.Width)*64, YMax: yMax,
+		}, true
+
+	default:
+		// For example SVG glyphs, that have no outline until they are rendered.
+		m := glyph.Metrics
+		if m.Width == 0 || m.Height == 0 {
+			return BBox{}, false
+		}
+		return BBox{
+			XMin: m.HoriBearingX, YMin: m.HoriBearingY - m.Height,
+			XMax: m.HoriBearingX + m.Width, YMax: m.HoriBearingY,
+		}, true
+	}
+}
+
+func bboxUnion(a BBox, b BBox) BBox {
+	return BBox{
+		XMin: min(a.XMin, b.XMin), YMin: min(a.YMin, b.YMin),
+		XMax: max(a.XMax, b.XMax), YMax: max(a.YMax, b.YMax),
+	}
+}
+
+// bboxTransform returns the bounding box of a transformed box.
+func bboxTransform(bbox BBox, matrix Matrix) BBox {
+	var transformed BBox
+	for i, corner := range []Vector{
+		{X: bbox.XMin, Y: bbox.YMin}, {X: bbox.XMax, Y: bbox.YMin},
+		{X: bbox.XMin, Y: bbox.YMax}, {X: bbox.XMax, Y: bbox.YMax},
+	} {
+		corner = VectorTransform(corner, matrix)
+		cornerBBox := BBox{XMin: corner.X, YMin: corner.Y, XMax: corner.X, YMax: corner.Y}
+		if i == 0 {
+			transformed = cornerBBox
+		} else {
+			transformed = bboxUnion(transformed, cornerBBox)
+		}
+	}
+	return transformed
+}
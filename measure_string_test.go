@@ -0,0 +1,97 @@
+package freetype
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaceMeasureString(t *testing.T) {
+	face := newDrawStringFace(t)
+
+	extents, err := face.MeasureString("FreeType", DrawOptions{})
+	assert.Nil(t, err)
+
+	dst := image.NewAlpha(image.Rect(0, 0, 300, 100))
+	origin := Vector{X: 10 * 64, Y: 60 * 64}
+	pen, err := DrawString(dst, face, "FreeType", origin, image.Opaque, DrawOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, pen.X-origin.X, extents.Advance.X)
+	assert.Equal(t, Pos(0), extents.Advance.Y)
+
+	metrics := face.Rec().Size.Rec().Metrics
+	assert.Equal(t, BBox{XMin: 0, YMin: metrics.Descender, XMax: extents.Advance.X, YMax: metrics.Ascender}, extents.Logical)
+
+	// The drawn pixels are within the ink box.
+	inked := image.Rectangle{}
+	for y := dst.Rect.Min.Y; y < dst.Rect.Max.Y; y++ {
+		for x := dst.Rect.Min.X; x < dst.Rect.Max.X; x++ {
+			if dst.AlphaAt(x, y).A != 0 {
+				inked = inked.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	ink := image.Rect(
+		int(origin.X+extents.Ink.XMin)>>6, int(origin.Y-extents.Ink.YMax)>>6,
+		int(origin.X+extents.Ink.XMax+63)>>6, int(origin.Y-extents.Ink.YMin+63)>>6,
+	)
+	assert.True(t, inked.In(ink), "%v in %v", inked, ink)
+	assert.Greater(t, extents.Ink.YMax, Pos(0))
+	assert.Less(t, extents.Ink.YMin, Pos(0)) // the descender of the y
+
+	empty, err := face.MeasureString(" ", DrawOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, BBox{}, empty.Ink)
+	assert.Greater(t, empty.Advance.X, Pos(0))
+}
+
+func TestFaceMeasureStringTransformed(t *testing.T) {
+	face := newDrawStringFace(t)
+
+	extents, err := face.MeasureString("AV", DrawOptions{})
+	assert.Nil(t, err)
+
+	// Rotate by 90 degrees counter-clockwise.
+	face.SetTransform(&Matrix{XX: 0, XY: -0x10000, YX: 0x10000, YY: 0}, nil)
+	rotated, err := face.MeasureString("AV", DrawOptions{})
+	assert.Nil(t, err)
+	face.SetTransform(nil, nil)
+
+	assert.Equal(t, Vector{X: 0, Y: extents.Advance.X}, rotated.Advance)
+	assert.Equal(t, -extents.Logical.YMax, rotated.Logical.XMin)
+	assert.Equal(t, extents.Logical.XMax, rotated.Logical.YMax)
+	assert.InDelta(t, extents.Ink.XMax-extents.Ink.XMin, rotated.Ink.YMax-rotated.Ink.YMin, 64)
+}
+
+func TestFaceMeasureStringVertical(t *testing.T) {
+	face := newDrawStringFace(t)
+
+	extents, err := face.MeasureString("AB", DrawOptions{LoadFlags: LOAD_VERTICAL_LAYOUT})
+	assert.Nil(t, err)
+
+	assert.Equal(t, Pos(0), extents.Advance.X)
+	assert.Less(t, extents.Advance.Y, Pos(0))
+	assert.Equal(t, extents.Advance.Y, extents.Logical.YMin)
+	assert.Equal(t, Pos(0), extents.Logical.YMax)
+	assert.LessOrEqual(t, extents.Ink.YMax, Pos(0))
+	assert.GreaterOrEqual(t, extents.Ink.YMin, extents.Advance.Y)
+	assert.Less(t, extents.Ink.XMin, Pos(0))
+	assert.Greater(t, extents.Ink.XMax, Pos(0))
+}
+
+func TestFaceGetAdvance(t *testing.T) {
+	face := newDrawStringFace(t)
+	glyphIndex := face.GetCharIndex('A')
+
+	advance, err := face.GetAdvance(glyphIndex, LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.LoadGlyph(glyphIndex, LOAD_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, face.Rec().Glyph.Rec().Advance.X, Pos(advance>>10))
+
+	advances, err := face.GetAdvances(glyphIndex, 2, LOAD_DEFAULT)
+	assert.Nil(t, err)
+	assert.Len(t, advances, 2)
+	assert.Equal(t, advance, advances[0])
+}
@@ -2,6 +2,7 @@ package freetype
 
 import (
 	"fmt"
+	"runtime"
 
 	"modernc.org/libc"
 	"modernc.org/libfreetype"
@@ -33,6 +34,7 @@ import (
 //
 // https://freetype.org/freetype2/docs/reference/ft2-module_management.html#ft_property_set
 func (lib Library) PropertySet(moduleName string, propertyName string, value uintptr) error {
+	defer runtime.KeepAlive(lib.owner)
 	cModuleName, err := libc.CString(moduleName)
 	if err != nil {
 		return fmt.Errorf("failed to set create C string for module name %s : %w", moduleName, err)
@@ -45,7 +47,7 @@ func (lib Library) PropertySet(moduleName string, propertyName string, value uin
 	}
 	defer libc.Xfree(nil, cPropertyName)
 
-	err_ := libfreetype.XFT_Property_Set(lib.tls, lib.library, cModuleName, cPropertyName, value)
+	err_ := libfreetype.XFT_Property_Set(lib.tls, lib.handle(), cModuleName, cPropertyName, value)
 	return newError(err_, "failed to set property %s for module %s", propertyName, moduleName)
 }
 
@@ -53,9 +55,35 @@ func (lib Library) PropertySet(moduleName string, propertyName string, value uin
 
 // FT_Set_Default_Properties
 
-// FT_New_Library
+/*
+NewLibrary creates a new library object that uses a custom memory manager.
+The default modules are added to it, and default properties are set from the FREETYPE_PROPERTIES environment variable,
+so it may be used in the same way as a Library returned by Init.
+
+AccountingMemory may be used to measure and limit the memory used by the library.
+
+https://freetype.org/freetype2/docs/reference/ft2-module_management.html#ft_new_library
+*/
+func NewLibrary(memory Memory) (Library, error) {
+	tls := libc.NewTLS()
+	lib, freeLib := alloc(tls, Library{})
+	defer freeLib()
+	lib.tls = tls
+	lib.customMemory = newMemoryRec(memory)
+
+	err := libfreetype.XFT_New_Library(tls, lib.customMemory, toUintptr(&lib.library))
+	if err != Err_Ok {
+		doneMemoryRec(lib.customMemory)
+		return Library{}, newError(err, "failed to create library")
+	}
+	libfreetype.XFT_Add_Default_Modules(tls, lib.library)
+	libfreetype.XFT_Set_Default_Properties(tls, lib.library)
+	library := *lib
+	newLibraryState(&library)
+	return library, nil
+}
 
-// FT_Done_Library
+// FT_Done_Library is used by Library.Done for a library created with NewLibrary.
 
 // FT_Reference_Library
 
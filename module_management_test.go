@@ -0,0 +1,78 @@
+package freetype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestNewLibrary(t *testing.T) {
+	memory := NewAccountingMemory(0)
+	lib, err := NewLibrary(memory)
+	assert.Nil(t, err)
+	afterInit := memory.Current()
+	assert.Greater(t, afterInit, 0)
+
+	face, err := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+	assert.Nil(t, err)
+	err = face.SetPixelSizes(0, 32)
+	assert.Nil(t, err)
+	err = face.LoadChar('A', LOAD_DEFAULT)
+	assert.Nil(t, err)
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.Nil(t, err)
+	assertGlyphRecFieldsForUppercaseA(t, *face.Rec().Glyph.Rec())
+	assert.Greater(t, memory.Current(), afterInit)
+
+	err = face.Done()
+	assert.Nil(t, err)
+	assert.Equal(t, afterInit, memory.Current())
+
+	peak := memory.Peak()
+	err = lib.Done()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, memory.Current())
+	assert.Equal(t, peak, memory.Peak())
+}
+
+func TestNewLibraryMemoryFaceData(t *testing.T) {
+	memory := NewAccountingMemory(0)
+	lib, err := NewLibrary(memory)
+	assert.Nil(t, err)
+	afterInit := memory.Current()
+
+	// The face's copy of the font data is allocated by the library's memory manager.
+	face, err := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, memory.Current()-afterInit, len(font.DejaVuSans))
+
+	// As is the copy shared by the faces that are opened to enumerate them.
+	_, err = lib.EnumerateFaces(font.RobotoVariable)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, memory.Peak()-afterInit, len(font.DejaVuSans)+len(font.RobotoVariable))
+
+	assert.Nil(t, face.Done())
+	assert.Equal(t, afterInit, memory.Current())
+	assert.Nil(t, lib.Done())
+}
+
+func TestNewLibraryMemoryLimit(t *testing.T) {
+	memory := NewAccountingMemory(0)
+	lib, _ := NewLibrary(memory)
+	limit := memory.Current() + 1024
+	_ = lib.Done()
+
+	memory = NewAccountingMemory(limit)
+	lib, err := NewLibrary(memory)
+	assert.Nil(t, err)
+
+	_, err = lib.NewMemoryFace(font.DejaVuSansMono, 0)
+	assert.True(t, errors.Is(err, ErrOutOfMemory))
+	assert.LessOrEqual(t, memory.Peak(), limit)
+
+	err = lib.Done()
+	assert.Nil(t, err)
+}
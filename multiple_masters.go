@@ -1,6 +1,9 @@
 package freetype
 
 import (
+	"math"
+	"runtime"
+	"slices"
 	"unsafe"
 
 	"modernc.org/libc"
@@ -18,6 +21,21 @@ const (
 	T1_MAX_MM_MAP_POINTS = 20
 )
 
+// The tags of the registered axes of OpenType variation fonts.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/dvaraxisreg
+const (
+	AXIS_TAG_ITALIC       Tag = 0x6974616c // ital
+	AXIS_TAG_OPTICAL_SIZE Tag = 0x6f70737a // opsz
+	AXIS_TAG_SLANT        Tag = 0x736c6e74 // slnt
+	AXIS_TAG_WIDTH        Tag = 0x77647468 // wdth
+	AXIS_TAG_WEIGHT       Tag = 0x77676874 // wght
+)
+
+// OpticalSizeAuto is the value of the optical size axis, for SetVariations,
+// that makes the optical size follow the face's size.
+const OpticalSizeAuto = -1
+
 // A structure to model a given axis in design space for Multiple Masters fonts.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_mm_axis
@@ -91,8 +109,9 @@ func (mmvar MMVar) NamedStyles() []VarNamedStyle {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_multi_master
 func (face Face) GetMultiMaster() (MultiMaster, error) {
+	defer runtime.KeepAlive(face.owner)
 	var master MultiMaster
-	err := libfreetype.XFT_Get_Multi_Master(face.tls, face.face, toUintptr(&master))
+	err := libfreetype.XFT_Get_Multi_Master(face.tls, face.handle(), toUintptr(&master))
 	return master, newError(err, "failed to get multi master")
 }
 
@@ -100,24 +119,48 @@ func (face Face) GetMultiMaster() (MultiMaster, error) {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_mm_var
 func (face Face) GetMMVar() (*MMVar, error) {
+	defer runtime.KeepAlive(face.owner)
 	var ptrMaster *MMVar
-	err := libfreetype.XFT_Get_MM_Var(face.tls, face.face, toUintptr(&ptrMaster))
+	if face.resources != nil && face.resources.state != nil {
+		face.resources.state.discardCollected()
+	}
+	err := libfreetype.XFT_Get_MM_Var(face.tls, face.handle(), toUintptr(&ptrMaster))
+	if err == Err_Ok && face.resources != nil && face.resources.state != nil {
+		face.resources.state.registerMMVar(ptrMaster)
+	}
 	return ptrMaster, newError(err, "failed to get multi master variation")
 }
 
 // Free the memory allocated by GetMMVar.
 //
+// Freeing an MMVar that has already been freed returns an error wrapping ErrInvalidArgument.
+//
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_done_mm_var
 func (library Library) DoneMMVar(master *MMVar) error {
-	err := libfreetype.XFT_Done_MM_Var(library.tls, library.library, toUintptr(master))
+	if library.state != nil && !library.state.unregisterMMVar(master) {
+		return newError(Err_Invalid_Argument, "failed to free a multi master variation that is not allocated")
+	}
+	err := libfreetype.XFT_Done_MM_Var(library.tls, library.handle(), toUintptr(master))
 	return newError(err, "failed to free a multi master variation")
 }
 
+// Close frees the memory allocated by GetMMVar, as the DoneMMVar method of its library does.
+// It implements io.Closer.
+func (mmvar *MMVar) Close() error {
+	state := mmVarLibrary(mmvar)
+	if state == nil {
+		return newError(Err_Invalid_Argument, "failed to free a multi master variation that is not allocated")
+	}
+	return Library{library: state.library, tls: state.tls, state: state}.DoneMMVar(mmvar)
+}
+
 // For Adobe MM fonts, choose an interpolated font design through design coordinates.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_set_mm_design_coordinates
 func (face Face) SetMMDesignCoordinates(coords []Long) error {
-	err := libfreetype.XFT_Set_MM_Design_Coordinates(face.tls, face.face, UInt(len(coords)), toUintptr(&coords[0]))
+	defer runtime.KeepAlive(face.owner)
+	face.setOpticalSizeAuto(false)
+	err := libfreetype.XFT_Set_MM_Design_Coordinates(face.tls, face.handle(), UInt(len(coords)), toUintptr(&coords[0]))
 	return newError(err, "failed to set multi master design coordinates")
 }
 
@@ -125,7 +168,13 @@ func (face Face) SetMMDesignCoordinates(coords []Long) error {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_set_var_design_coordinates
 func (face Face) SetVarDesignCoordinates(coords []Fixed) error {
-	err := libfreetype.XFT_Set_Var_Design_Coordinates(face.tls, face.face, UInt(len(coords)), toUintptr(&coords[0]))
+	face.setOpticalSizeAuto(false)
+	return face.setVarDesignCoordinates(coords)
+}
+
+func (face Face) setVarDesignCoordinates(coords []Fixed) error {
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Set_Var_Design_Coordinates(face.tls, face.handle(), UInt(len(coords)), toUintptr(&coords[0]))
 	return newError(err, "failed to set multi master variation design coordinates")
 }
 
@@ -133,8 +182,9 @@ func (face Face) SetVarDesignCoordinates(coords []Fixed) error {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_var_design_coordinates
 func (face Face) GetVarDesignCoordinates(numCoords UInt) ([]Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
 	coords := make([]Fixed, numCoords)
-	err := libfreetype.XFT_Get_Var_Design_Coordinates(face.tls, face.face, numCoords, toUintptr(&coords[0]))
+	err := libfreetype.XFT_Get_Var_Design_Coordinates(face.tls, face.handle(), numCoords, toUintptr(&coords[0]))
 	return coords, newError(err, "failed to get multi master variation design coordinates")
 }
 
@@ -142,7 +192,9 @@ func (face Face) GetVarDesignCoordinates(numCoords UInt) ([]Fixed, error) {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_set_mm_blend_coordinates
 func (face Face) SetMMBlendCoordinates(coords []Fixed) error {
-	err := libfreetype.XFT_Set_MM_Blend_Coordinates(face.tls, face.face, UInt(len(coords)), toUintptr(&coords[0]))
+	defer runtime.KeepAlive(face.owner)
+	face.setOpticalSizeAuto(false)
+	err := libfreetype.XFT_Set_MM_Blend_Coordinates(face.tls, face.handle(), UInt(len(coords)), toUintptr(&coords[0]))
 	return newError(err, "failed to set multi master blend coordinates")
 }
 
@@ -150,8 +202,9 @@ func (face Face) SetMMBlendCoordinates(coords []Fixed) error {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_mm_blend_coordinates
 func (face Face) GetMMBlendCoordinates(numCoords UInt) ([]Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
 	coords := make([]Fixed, numCoords)
-	err := libfreetype.XFT_Get_MM_Blend_Coordinates(face.tls, face.face, numCoords, toUintptr(&coords[0]))
+	err := libfreetype.XFT_Get_MM_Blend_Coordinates(face.tls, face.handle(), numCoords, toUintptr(&coords[0]))
 	return coords, newError(err, "failed to get multi master blend coordinates")
 }
 
@@ -173,7 +226,9 @@ func (face Face) GetVarBlendCoordinates(numCoords UInt) ([]Fixed, error) {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_set_mm_weightvector
 func (face Face) SetMMWeightVector(weightVector []Fixed) error {
-	err := libfreetype.XFT_Set_MM_WeightVector(face.tls, face.face, UInt(len(weightVector)), toUintptr(&weightVector[0]))
+	defer runtime.KeepAlive(face.owner)
+	face.setOpticalSizeAuto(false)
+	err := libfreetype.XFT_Set_MM_WeightVector(face.tls, face.handle(), UInt(len(weightVector)), toUintptr(&weightVector[0]))
 	return newError(err, "failed to set multi master weight vector")
 }
 
@@ -181,8 +236,9 @@ func (face Face) SetMMWeightVector(weightVector []Fixed) error {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_mm_weightvector
 func (face Face) GetMMWeightVector(length UInt) (UInt, []Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
 	weightVector := make([]Fixed, length)
-	err := libfreetype.XFT_Get_MM_WeightVector(face.tls, face.face, toUintptr(&length), toUintptr(&weightVector[0]))
+	err := libfreetype.XFT_Get_MM_WeightVector(face.tls, face.handle(), toUintptr(&length), toUintptr(&weightVector[0]))
 	return length, weightVector, newError(err, "failed to get multi master weight vector")
 }
 
@@ -195,6 +251,7 @@ const FT_VAR_AXIS_FLAG_HIDDEN = UInt(1)
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_var_axis_flags
 func (face Face) GetVarAxisFlags(master *MMVar, axisIndex UInt) (UInt, error) {
+	defer runtime.KeepAlive(face.owner)
 	var flags UInt
 	err := libfreetype.XFT_Get_Var_Axis_Flags(face.tls, toUintptr(master), axisIndex, toUintptr(&flags))
 	return flags, newError(err, "failed to get multi master flags")
@@ -204,15 +261,187 @@ func (face Face) GetVarAxisFlags(master *MMVar, axisIndex UInt) (UInt, error) {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_set_named_instance
 func (face Face) SetNamedInstance(instanceIndex UInt) error {
-	err := libfreetype.XFT_Set_Named_Instance(face.tls, face.face, instanceIndex)
+	defer runtime.KeepAlive(face.owner)
+	face.setOpticalSizeAuto(false)
+	err := libfreetype.XFT_Set_Named_Instance(face.tls, face.handle(), instanceIndex)
 	return newError(err, "failed to set named instance")
 }
 
-// Retrieve the index of the default named instance, to be used with SetNamedInstance.
-//
-// https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_default_named_instance
-// func (face Face) GetDefaultNamedInstance() (UInt, error) {
-// 	var instanceIndex UInt
-// 	err := libfreetype.XFT_Get_Default_Named_Instance(face.tls, face.face, toUintptr(&instanceIndex))
-// 	return instanceIndex, newError(err, "failed to get default named instance flags")
-// }
+/*
+GetDefaultNamedInstance retrieves the index of the default named instance, to be used with SetNamedInstance.
+The index is counted from 1, and is 0 if the face has no named instance with the default coordinates of its axes.
+
+This build of FreeType does not provide FT_Get_Default_Named_Instance,
+so the index is found by comparing the coordinates of the named instances with the defaults of the axes,
+as FreeType does.
+
+https://freetype.org/freetype2/docs/reference/ft2-multiple_masters.html#ft_get_default_named_instance
+*/
+func (face Face) GetDefaultNamedInstance() (UInt, error) {
+	mmVar, err := face.GetMMVar()
+	if err != nil {
+		return 0, err
+	}
+	defer mmVar.Close()
+
+	axes := mmVar.Axes()
+	for i, style := range mmVar.NamedStyles() {
+		coords := unsafe.Slice(style.Coords, len(axes))
+		isDefault := true
+		for j, axis := range axes {
+			if coords[j] != axis.Def {
+				isDefault = false
+				break
+			}
+		}
+		if isDefault {
+			return UInt(i + 1), nil
+		}
+	}
+	return 0, nil
+}
+
+/*
+NamedStyleName returns the name of a named instance of a variation font, such as "Bold Condensed",
+from the face's SFNT ‘name’ table.
+It returns an empty string if the name table has no such name.
+*/
+func (face Face) NamedStyleName(style VarNamedStyle) string {
+	return face.Name(TT_NameID(style.Strid))
+}
+
+/*
+NamedStylePostscriptName returns the PostScript name of a named instance of a variation font,
+from the face's SFNT ‘name’ table.
+It returns an empty string if the font does not name the instance's PostScript name.
+*/
+func (face Face) NamedStylePostscriptName(style VarNamedStyle) string {
+	if style.Psid == 0xFFFF {
+		return ""
+	}
+	return face.Name(TT_NameID(style.Psid))
+}
+
+/*
+SetVariations selects an instance of a variation font by the values of its axes, such as
+map[Tag]float64{AXIS_TAG_WEIGHT: 700, AXIS_TAG_WIDTH: 75}.
+
+Axes that are not in variations are set to their default values,
+and values are clamped to the minimum and maximum of their axes.
+Tags of axes that the face does not have are ignored,
+so the same variations may be used for the faces of a FaceChain.
+
+If the value of the optical size axis is OpticalSizeAuto, it is set to the face's current size in pixels per em,
+as CSS does for font-optical-sizing, or to its default if no size has been set.
+The optical size then follows the size of the face, as it is set again by SetCharSize, SetPixelSizes,
+RequestSize and SelectSize, until the coordinates are set by another call to SetVariations,
+or by SetVarDesignCoordinates or the other functions that set them.
+*/
+func (face Face) SetVariations(variations map[Tag]float64) error {
+	mmVar, err := face.GetMMVar()
+	if err != nil {
+		return err
+	}
+	defer mmVar.Close()
+
+	axes := mmVar.Axes()
+	ppem, hasPpem := face.ppem()
+	if err := face.SetVarDesignCoordinates(variationCoords(axes, variations, ppem, hasPpem)); err != nil {
+		return err
+	}
+	auto := variations[AXIS_TAG_OPTICAL_SIZE] == OpticalSizeAuto
+	face.setOpticalSizeAuto(auto && slices.ContainsFunc(axes, func(axis VarAxis) bool {
+		return Tag(axis.Tag) == AXIS_TAG_OPTICAL_SIZE
+	}))
+	return nil
+}
+
+func (face Face) setOpticalSizeAuto(auto bool) {
+	if face.resources != nil {
+		face.resources.opticalSizeAuto = auto
+	}
+}
+
+// updateOpticalSize sets the optical size axis to the face's size,
+// if SetVariations set it to OpticalSizeAuto.
+func (face Face) updateOpticalSize() error {
+	if face.resources == nil || !face.resources.opticalSizeAuto {
+		return nil
+	}
+	ppem, hasPpem := face.ppem()
+	if !hasPpem {
+		return nil
+	}
+	mmVar, err := face.GetMMVar()
+	if err != nil {
+		return err
+	}
+	defer mmVar.Close()
+
+	axes := mmVar.Axes()
+	coords, err := face.GetVarDesignCoordinates(UInt(len(axes)))
+	if err != nil {
+		return err
+	}
+	for i, axis := range axes {
+		if Tag(axis.Tag) == AXIS_TAG_OPTICAL_SIZE {
+			coords[i] = min(max(ppem, axis.Minimum), axis.Maximum)
+		}
+	}
+	return face.setVarDesignCoordinates(coords)
+}
+
+// variationCoords returns the design coordinates of axes for SetVariations,
+// with ppem as the face's size in pixels per em, if hasPpem is true.
+func variationCoords(axes []VarAxis, variations map[Tag]float64, ppem Fixed, hasPpem bool) []Fixed {
+	coords := make([]Fixed, len(axes))
+	for i, axis := range axes {
+		coords[i] = axis.Def
+		value, ok := variations[Tag(axis.Tag)]
+		if !ok {
+			continue
+		}
+		if Tag(axis.Tag) == AXIS_TAG_OPTICAL_SIZE && value == OpticalSizeAuto {
+			if hasPpem {
+				coords[i] = min(max(ppem, axis.Minimum), axis.Maximum)
+			}
+			continue
+		}
+		coords[i] = min(max(Fixed(math.Round(value*65536)), axis.Minimum), axis.Maximum)
+	}
+	return coords
+}
+
+// Variations returns the current values of the axes of a variation font, by their tags.
+func (face Face) Variations() (map[Tag]float64, error) {
+	mmVar, err := face.GetMMVar()
+	if err != nil {
+		return nil, err
+	}
+	defer mmVar.Close()
+
+	axes := mmVar.Axes()
+	coords, err := face.GetVarDesignCoordinates(UInt(len(axes)))
+	if err != nil {
+		return nil, err
+	}
+	variations := make(map[Tag]float64, len(axes))
+	for i, axis := range axes {
+		variations[Tag(axis.Tag)] = float64(coords[i]) / 65536
+	}
+	return variations, nil
+}
+
+// ppem returns the face's current size in pixels per em, as a 16.16 value,
+// and false if no size has been set.
+func (face Face) ppem() (Fixed, bool) {
+	rec := face.Rec()
+	if rec == nil || rec.Size == 0 || rec.Size.Rec().Metrics.Yppem == 0 {
+		return 0, false
+	}
+	if !face.IsScalable() {
+		return Fixed(rec.Size.Rec().Metrics.Yppem) << 16, true
+	}
+	// The y scale converts font units to 26.6 pixels, so the em is scaled to 26.6 pixels per em.
+	return Fixed(MulFix(Long(rec.UnitsPerEM), rec.Size.Rec().Metrics.YScale)) << 10, true
+}
@@ -1,6 +1,8 @@
 package freetype
 
 import (
+	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,9 +19,150 @@ func TestGetMMVar(t *testing.T) {
 	assert.Equal(t, 2, len(mmVar.Axes()))
 
 	expectedNames := []string{"Weight", "Width"}
-	expectedTags := []Tag{imageTag('w', 'g', 'h', 't'), imageTag('w', 'd', 't', 'h')}
+	expectedTags := []Tag{MakeTag("wght"), MakeTag("wdth")}
 	for i, axis := range mmVar.Axes() {
 		assert.Equal(t, expectedNames[i], axis.Name())
 		assert.Equal(t, expectedTags[i], Tag(axis.Tag))
 	}
 }
+
+func TestGetDefaultNamedInstance(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+
+	instanceIndex, err := face.GetDefaultNamedInstance()
+	assert.NoError(t, err)
+	assert.NoError(t, face.SetNamedInstance(instanceIndex))
+	assert.Equal(t, "Regular", face.Rec().StyleName())
+
+	face, _ = lib.NewMemoryFace(font.DejaVuSans, 0)
+	_, err = face.GetDefaultNamedInstance()
+	assert.Error(t, err)
+}
+
+func TestNamedStyleNames(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+	mmVar, _ := face.GetMMVar()
+	defer mmVar.Close()
+
+	styles := mmVar.NamedStyles()
+	assert.Equal(t, 18, len(styles))
+	assert.Equal(t, "Light", face.NamedStyleName(styles[2]))
+	assert.Equal(t, "Roboto-Light", face.NamedStylePostscriptName(styles[2]))
+}
+
+func TestFaceSetVariations(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+
+	err := face.SetVariations(map[Tag]float64{AXIS_TAG_WEIGHT: 700, AXIS_TAG_OPTICAL_SIZE: OpticalSizeAuto})
+	assert.NoError(t, err)
+	variations, err := face.Variations()
+	assert.NoError(t, err)
+	assert.Equal(t, map[Tag]float64{AXIS_TAG_WEIGHT: 700, AXIS_TAG_WIDTH: 100}, variations)
+
+	// Values are clamped, and unspecified axes are reset to their defaults.
+	err = face.SetVariations(map[Tag]float64{AXIS_TAG_WIDTH: 10})
+	assert.NoError(t, err)
+	variations, _ = face.Variations()
+	assert.Equal(t, map[Tag]float64{AXIS_TAG_WEIGHT: 400, AXIS_TAG_WIDTH: 75}, variations)
+
+	face, _ = lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Error(t, face.SetVariations(map[Tag]float64{AXIS_TAG_WEIGHT: 700}))
+	_, err = face.Variations()
+	assert.Error(t, err)
+}
+
+func TestAxisTags(t *testing.T) {
+	assert.Equal(t, MakeTag("ital"), AXIS_TAG_ITALIC)
+	assert.Equal(t, MakeTag("opsz"), AXIS_TAG_OPTICAL_SIZE)
+	assert.Equal(t, MakeTag("slnt"), AXIS_TAG_SLANT)
+	assert.Equal(t, MakeTag("wdth"), AXIS_TAG_WIDTH)
+	assert.Equal(t, MakeTag("wght"), AXIS_TAG_WEIGHT)
+}
+
+// withOpticalSizeAxis returns a copy of a font whose width axis is renamed to the optical size axis,
+// as none of the test fonts has an optical size axis.
+func withOpticalSizeAxis(data []byte) []byte {
+	data = bytes.Clone(data)
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := range numTables {
+		record := data[12+16*i:]
+		if Tag(binary.BigEndian.Uint32(record)) != MakeTag("fvar") {
+			continue
+		}
+		fvar := data[binary.BigEndian.Uint32(record[8:]):]
+		axesOffset := int(binary.BigEndian.Uint16(fvar[4:]))
+		axisCount := int(binary.BigEndian.Uint16(fvar[8:]))
+		axisSize := int(binary.BigEndian.Uint16(fvar[10:]))
+		for j := range axisCount {
+			axis := fvar[axesOffset+axisSize*j:]
+			if Tag(binary.BigEndian.Uint32(axis)) == AXIS_TAG_WIDTH {
+				binary.BigEndian.PutUint32(axis, uint32(AXIS_TAG_OPTICAL_SIZE))
+			}
+		}
+	}
+	return data
+}
+
+func TestFaceSetVariationsOpticalSizeAuto(t *testing.T) {
+	lib, _ := Init()
+	defer lib.Done()
+	// Roboto's width axis, with a range of 75 to 100, becomes the optical size axis.
+	data := withOpticalSizeAxis(font.RobotoVariable)
+	opticalSize := func(face Face) float64 {
+		variations, err := face.Variations()
+		assert.NoError(t, err)
+		return variations[AXIS_TAG_OPTICAL_SIZE]
+	}
+
+	// Without a size it is the axis's default.
+	face, _ := lib.NewMemoryFace(data, 0)
+	assert.NoError(t, face.SetVariations(map[Tag]float64{AXIS_TAG_OPTICAL_SIZE: OpticalSizeAuto}))
+	assert.Equal(t, 100.0, opticalSize(face))
+
+	// The optical size follows the size of the face.
+	assert.NoError(t, face.SetPixelSizes(0, 80))
+	assert.InDelta(t, 80, opticalSize(face), 0.05)
+	assert.NoError(t, face.SetCharSize(0, 90*64, 72, 72))
+	assert.InDelta(t, 90, opticalSize(face), 0.05)
+	assert.NoError(t, face.RequestSize(SizeRequestRec{Type: SIZE_REQUEST_TYPE_NOMINAL, Height: 85 * 64}))
+	assert.InDelta(t, 85, opticalSize(face), 0.05)
+
+	// It is clamped to the range of the axis, and other axes are kept.
+	assert.NoError(t, face.SetVariations(map[Tag]float64{AXIS_TAG_WEIGHT: 700, AXIS_TAG_OPTICAL_SIZE: OpticalSizeAuto}))
+	assert.NoError(t, face.SetPixelSizes(0, 20))
+	variations, _ := face.Variations()
+	assert.Equal(t, map[Tag]float64{AXIS_TAG_WEIGHT: 700, AXIS_TAG_OPTICAL_SIZE: 75}, variations)
+	assert.NoError(t, face.SetPixelSizes(0, 200))
+	assert.Equal(t, 100.0, opticalSize(face))
+
+	// An explicit optical size is clamped too, and does not follow the size.
+	assert.NoError(t, face.SetVariations(map[Tag]float64{AXIS_TAG_OPTICAL_SIZE: 90.5}))
+	assert.Equal(t, 90.5, opticalSize(face))
+	assert.NoError(t, face.SetPixelSizes(0, 80))
+	assert.Equal(t, 90.5, opticalSize(face))
+	assert.NoError(t, face.SetVariations(map[Tag]float64{AXIS_TAG_OPTICAL_SIZE: 200}))
+	assert.Equal(t, 100.0, opticalSize(face))
+
+	// Setting the coordinates directly stops the optical size from following the size.
+	assert.NoError(t, face.SetVariations(map[Tag]float64{AXIS_TAG_OPTICAL_SIZE: OpticalSizeAuto}))
+	assert.InDelta(t, 80, opticalSize(face), 0.05)
+	assert.NoError(t, face.SetNamedInstance(0))
+	assert.NoError(t, face.SetPixelSizes(0, 90))
+	assert.Equal(t, 100.0, opticalSize(face))
+}
+
+func TestFacePpem(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.RobotoVariable, 0)
+
+	_, ok := face.ppem()
+	assert.False(t, ok)
+
+	_ = face.SetPixelSizes(0, 32)
+	ppem, ok := face.ppem()
+	assert.True(t, ok)
+	assert.InDelta(t, 32<<16, int(ppem), 1<<10)
+}
@@ -4,6 +4,9 @@ package freetype
 
 import (
 	"fmt"
+	"image"
+	"runtime"
+	"sync"
 	"unsafe"
 
 	"modernc.org/libc"
@@ -60,6 +63,8 @@ type SVGRendererHooks struct {
 }
 
 // SetSVGHooks sets the four hooks needed to render OT-SVG glyphs properly.
+//
+// SetSVGRenderer is usually more convenient, as it does not require C-level plumbing.
 func (lib Library) SetSVGHooks(hooks SVGRendererHooks) error {
 	hooks_ := libfreetype.TSVG_RendererHooks{
 		Finit_svg:    __ccgo_fp(hooks.InitSVG),
@@ -74,6 +79,195 @@ func (lib Library) SetSVGHooks(hooks SVGRendererHooks) error {
 	return nil
 }
 
+/*
+SVGRenderer is a Go implementation of an external SVG rendering library,
+for use with Library.SetSVGRenderer.
+
+Init is called when the first OT-SVG glyph is rendered in the lifetime of the library,
+and Free is called when the library is discarded (only if Init was called).
+
+PresetSlot returns the bounding box of the rendered glyph in pixels, relative to the glyph's origin.
+The y axis points down, as is usual for images.
+So for example the top left corner of a glyph that extends 10 pixels above the baseline would have a y value of -10.
+It is called when a glyph is loaded, and right before Render is called.
+
+Render renders the document in to an image.
+The image's pixels that are within the bounding box returned by PresetSlot are copied to the glyph slot's bitmap.
+Pixels outside of the bounding box are ignored.
+
+The document's Transform and Delta, and its Metrics, should be applied by the renderer.
+*/
+type SVGRenderer interface {
+	Init() error
+	Free()
+	PresetSlot(doc SVGDocumentRec) (image.Rectangle, error)
+	Render(doc SVGDocumentRec, slot GlyphSlot) (*image.RGBA, error)
+}
+
+// svgRenderers maps the address of an ot-svg module's state to its renderer.
+var svgRenderers = struct {
+	sync.Mutex
+	renderers map[uintptr]SVGRenderer
+}{
+	renderers: make(map[uintptr]SVGRenderer),
+}
+
+func svgRenderer(state uintptr) SVGRenderer {
+	svgRenderers.Lock()
+	defer svgRenderers.Unlock()
+	return svgRenderers.renderers[state]
+}
+
+/*
+SetSVGRenderer sets an SVGRenderer as the library's external SVG rendering library.
+
+The renderer is called to render OT-SVG glyphs.
+The setting of glyph slot metrics, and the allocation of bitmap memory, are handled by SetSVGRenderer.
+*/
+func (lib Library) SetSVGRenderer(renderer SVGRenderer) error {
+	defer runtime.KeepAlive(lib.owner)
+	cModuleName, err := libc.CString("ot-svg")
+	if err != nil {
+		return err
+	}
+	defer libc.Xfree(nil, cModuleName)
+
+	module := libfreetype.XFT_Get_Module(lib.tls, lib.handle(), cModuleName)
+	if module == 0 {
+		return newError(Err_Missing_Module, "failed to set svg renderer for library")
+	}
+	state := module + unsafe.Offsetof(libfreetype.TSVG_RendererRec{}.Fstate)
+
+	svgRenderers.Lock()
+	previous, hasPrevious := svgRenderers.renderers[state]
+	svgRenderers.renderers[state] = renderer
+	svgRenderers.Unlock()
+
+	err = lib.SetSVGHooks(SVGRendererHooks{
+		InitSVG:    svgRendererInit,
+		FreeSvg:    svgRendererFree,
+		RenderSVG:  svgRendererRender,
+		PresetSlot: svgRendererPresetSlot,
+	})
+	if err != nil {
+		svgRenderers.Lock()
+		if hasPrevious {
+			svgRenderers.renderers[state] = previous
+		} else {
+			delete(svgRenderers.renderers, state)
+		}
+		svgRenderers.Unlock()
+		return err
+	}
+
+	lib.state.mutex.Lock()
+	lib.state.forgetSVGRenderer = func() { forgetSVGRenderer(state) }
+	lib.state.mutex.Unlock()
+	return nil
+}
+
+// forgetSVGRenderer removes the renderer of an ot-svg module's state.
+// It is called when the module frees the renderer, and when the module's library is discarded,
+// as the module only frees renderers that it has initialized.
+func forgetSVGRenderer(state uintptr) {
+	svgRenderers.Lock()
+	delete(svgRenderers.renderers, state)
+	svgRenderers.Unlock()
+}
+
+func svgRendererInit(_ *libc.TLS, state uintptr) FTError {
+	renderer := svgRenderer(state)
+	if renderer == nil {
+		return Err_Missing_SVG_Hooks
+	}
+	return ftErrorOf(renderer.Init(), Err_Invalid_SVG_Document)
+}
+
+func svgRendererFree(_ *libc.TLS, state uintptr) {
+	renderer := svgRenderer(state)
+	if renderer == nil {
+		return
+	}
+	renderer.Free()
+	forgetSVGRenderer(state)
+}
+
+func svgRendererPresetSlot(_ *libc.TLS, slot GlyphSlot, _ Bool, state uintptr) FTError {
+	renderer := svgRenderer(state)
+	if renderer == nil {
+		return Err_Missing_SVG_Hooks
+	}
+
+	rec := slot.Rec()
+	bbox, err := renderer.PresetSlot(*rec.SVGDocument())
+	if err != nil {
+		return ftErrorOf(err, Err_Invalid_SVG_Document)
+	}
+	presetSVGSlot(rec, bbox)
+	return Err_Ok
+}
+
+// presetSVGSlot sets a glyph slot's bitmap fields and metrics for an SVG glyph's bounding box.
+func presetSVGSlot(rec *GlyphSlotRec, bbox image.Rectangle) {
+	width := bbox.Dx()
+	height := bbox.Dy()
+
+	rec.Bitmap.Rows = uint32(height)
+	rec.Bitmap.Width = uint32(width)
+	rec.Bitmap.Pitch = int32(width * 4)
+	rec.Bitmap.PixelMode = PIXEL_MODE_BGRA
+	rec.Bitmap.NumGrays = 256
+	rec.BitmapLeft = Int(bbox.Min.X)
+	rec.BitmapTop = Int(-bbox.Min.Y)
+
+	rec.Metrics.Width = Pos(width * 64)
+	rec.Metrics.Height = Pos(height * 64)
+	rec.Metrics.HoriBearingX = Pos(bbox.Min.X * 64)
+	rec.Metrics.HoriBearingY = Pos(-bbox.Min.Y * 64)
+	if rec.Metrics.VertAdvance == 0 {
+		rec.Metrics.VertAdvance = Pos(float64(height) * 1.2 * 64)
+	}
+	rec.Metrics.VertBearingX = rec.Metrics.HoriBearingX - rec.Metrics.HoriAdvance/2
+	rec.Metrics.VertBearingY = (rec.Metrics.VertAdvance - rec.Metrics.Height) / 2
+}
+
+func svgRendererRender(_ *libc.TLS, slot GlyphSlot, state uintptr) FTError {
+	renderer := svgRenderer(state)
+	if renderer == nil {
+		return Err_Missing_SVG_Hooks
+	}
+
+	rec := slot.Rec()
+	img, err := renderer.Render(*rec.SVGDocument(), slot)
+	if err != nil {
+		return ftErrorOf(err, Err_Invalid_SVG_Document)
+	}
+	copyToSVGSlot(rec, img)
+	return Err_Ok
+}
+
+// copyToSVGSlot copies an image's pixels to a glyph slot's BGRA bitmap, that has been preset by presetSVGSlot.
+// Both image.RGBA and FreeType's BGRA bitmaps use premultiplied alpha.
+func copyToSVGSlot(rec *GlyphSlotRec, img *image.RGBA) {
+	bitmap := rec.Bitmap
+	bbox := image.Rect(0, 0, int(bitmap.Width), int(bitmap.Rows)).
+		Add(image.Pt(int(rec.BitmapLeft), -int(rec.BitmapTop)))
+	buffer := bitmap.Buffer()
+
+	area := bbox.Intersect(img.Bounds())
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		row := buffer[(y-bbox.Min.Y)*int(bitmap.Pitch):]
+		for x := area.Min.X; x < area.Max.X; x++ {
+			src := img.Pix[img.PixOffset(x, y):]
+			dst := row[(x-bbox.Min.X)*4:]
+			dst[0] = src[2] // B
+			dst[1] = src[1] // G
+			dst[2] = src[0] // R
+			dst[3] = src[3] // A
+		}
+	}
+}
+
 func init() {
 	assertSameSize(SVGDocumentRec{}, libfreetype.TFT_SVG_DocumentRec{})
 }
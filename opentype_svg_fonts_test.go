@@ -3,7 +3,11 @@
 package freetype
 
 import (
+	"image"
+	"image/color"
+	"image/draw"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"modernc.org/libc"
@@ -66,3 +70,108 @@ func TestLibrarySetSVGHooks(t *testing.T) {
 	assert.True(t, renderFuncCalled)
 	assert.Equal(t, 2, presetSlotFuncCalled)
 }
+
+type testSVGRenderer struct {
+	initCalled    bool
+	freeCalled    bool
+	presetCalls   int
+	renderCalled  bool
+	presetDocSize int
+}
+
+func (r *testSVGRenderer) Init() error {
+	r.initCalled = true
+	return nil
+}
+
+func (r *testSVGRenderer) Free() {
+	r.freeCalled = true
+}
+
+func (r *testSVGRenderer) PresetSlot(doc SVGDocumentRec) (image.Rectangle, error) {
+	r.presetCalls++
+	r.presetDocSize = len(doc.Document())
+	return image.Rect(1, -30, 31, 2), nil
+}
+
+func (r *testSVGRenderer) Render(_ SVGDocumentRec, _ GlyphSlot) (*image.RGBA, error) {
+	r.renderCalled = true
+	img := image.NewRGBA(image.Rect(0, -32, 32, 32))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}), image.Point{}, draw.Src)
+	return img, nil
+}
+
+func TestLibrarySetSVGRenderer(t *testing.T) {
+	lib, err := Init()
+	assert.NoError(t, err)
+
+	renderer := &testSVGRenderer{}
+	err = lib.SetSVGRenderer(renderer)
+	assert.NoError(t, err)
+
+	face, err := lib.NewMemoryFace(font.NotoColorEmoji, 0)
+	assert.NoError(t, err)
+
+	err = face.SetPixelSizes(0, 32)
+	assert.NoError(t, err)
+
+	err = face.LoadGlyph(face.GetCharIndex('😀'), LOAD_COLOR)
+	assert.NoError(t, err)
+
+	err = face.RenderGlyph(RENDER_MODE_NORMAL)
+	assert.NoError(t, err)
+
+	slot := face.Rec().Glyph.Rec()
+	bitmap := slot.Bitmap
+	assert.Equal(t, PIXEL_MODE_BGRA, bitmap.PixelMode)
+	assert.Equal(t, uint32(30), bitmap.Width)
+	assert.Equal(t, uint32(32), bitmap.Rows)
+	assert.Equal(t, Int(1), slot.BitmapLeft)
+	assert.Equal(t, Int(30), slot.BitmapTop)
+	assert.Equal(t, []byte{0x30, 0x20, 0x10, 0xff}, bitmap.Buffer()[:4])
+
+	err = lib.Done()
+	assert.NoError(t, err)
+
+	assert.True(t, renderer.initCalled)
+	assert.True(t, renderer.freeCalled)
+	assert.True(t, renderer.renderCalled)
+	assert.Equal(t, 2, renderer.presetCalls)
+	assert.Greater(t, renderer.presetDocSize, 0)
+}
+
+func TestSVGRendererForgottenWithLibrary(t *testing.T) {
+	svgRendererCount := func() int {
+		svgRenderers.Lock()
+		defer svgRenderers.Unlock()
+		return len(svgRenderers.renderers)
+	}
+	count := svgRendererCount()
+
+	lib, _ := Init()
+	renderer := &testSVGRenderer{}
+	assert.NoError(t, lib.SetSVGRenderer(renderer))
+	assert.NoError(t, lib.SetSVGRenderer(renderer))
+	assert.Equal(t, count+1, svgRendererCount())
+
+	// No SVG glyph was rendered, so the ot-svg module neither initialized nor freed the renderer.
+	assert.NoError(t, lib.Done())
+	assert.False(t, renderer.initCalled)
+	assert.False(t, renderer.freeCalled)
+	assert.Equal(t, count, svgRendererCount())
+}
+
+func TestCopyToSVGSlot(t *testing.T) {
+	buffer := make([]byte, 2*2*4)
+	rec := &GlyphSlotRec{}
+	presetSVGSlot(rec, image.Rect(-1, -1, 1, 1))
+	rec.Bitmap.buffer = unsafe.Pointer(&buffer[0])
+
+	img := image.NewRGBA(image.Rect(0, -1, 1, 0))
+	img.SetRGBA(0, -1, color.RGBA{R: 1, G: 2, B: 3, A: 4})
+	copyToSVGSlot(rec, img)
+
+	assert.Equal(t, []byte{0, 0, 0, 0, 3, 2, 1, 4, 0, 0, 0, 0, 0, 0, 0, 0}, buffer)
+	assert.Equal(t, Pos(64), rec.Metrics.HoriBearingY)
+	assert.Equal(t, Int(1), rec.BitmapTop)
+}
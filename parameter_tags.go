@@ -8,12 +8,12 @@ import "modernc.org/libfreetype"
 type ParamTag = libfreetype.TFT_ULong
 
 var (
-	PARAM_TAG_IGNORE_TYPOGRAPHIC_FAMILY    = ParamTag(imageTag('i', 'g', 'p', 'f'))
-	PARAM_TAG_IGNORE_TYPOGRAPHIC_SUBFAMILY = ParamTag(imageTag('i', 'g', 'p', 's'))
-	PARAM_TAG_INCREMENTAL                  = ParamTag(imageTag('i', 'n', 'c', 'r'))
-	PARAM_TAG_IGNORE_SBIX                  = ParamTag(imageTag('i', 's', 'b', 'x'))
-	PARAM_TAG_LCD_FILTER_WEIGHTS           = ParamTag(imageTag('l', 'c', 'd', 'f'))
-	PARAM_TAG_RANDOM_SEED                  = ParamTag(imageTag('s', 'e', 'e', 'd'))
-	PARAM_TAG_STEM_DARKENING               = ParamTag(imageTag('d', 'a', 'r', 'k'))
-	PARAM_TAG_UNPATENTED_HINTING           = ParamTag(imageTag('u', 'n', 'p', 'a'))
+	PARAM_TAG_IGNORE_TYPOGRAPHIC_FAMILY    = ParamTag(MakeTag("igpf"))
+	PARAM_TAG_IGNORE_TYPOGRAPHIC_SUBFAMILY = ParamTag(MakeTag("igps"))
+	PARAM_TAG_INCREMENTAL                  = ParamTag(MakeTag("incr"))
+	PARAM_TAG_IGNORE_SBIX                  = ParamTag(MakeTag("isbx"))
+	PARAM_TAG_LCD_FILTER_WEIGHTS           = ParamTag(MakeTag("lcdf"))
+	PARAM_TAG_RANDOM_SEED                  = ParamTag(MakeTag("seed"))
+	PARAM_TAG_STEM_DARKENING               = ParamTag(MakeTag("dark"))
+	PARAM_TAG_UNPATENTED_HINTING           = ParamTag(MakeTag("unpa"))
 )
@@ -0,0 +1,56 @@
+package freetype
+
+import (
+	"runtime"
+
+	"modernc.org/libfreetype"
+)
+
+// Functions to quickly extract advance values.
+
+// ADVANCE_FLAG_FAST_ONLY is a bit flag to be OR-ed with the loadFlags parameter of GetAdvance and GetAdvances.
+// If set, it indicates that you want these functions to fail if the corresponding hinting mode
+// or font driver doesn't allow for very quick advance computation.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-quick_advance.html#ft_advance_flag_fast_only
+const ADVANCE_FLAG_FAST_ONLY = LoadFlag(0x20000000)
+
+/*
+GetAdvance retrieves the advance value of a given glyph outline in a face.
+
+By default, the unhinted advance is returned in font units.
+If loadFlags does not include LOAD_NO_SCALE, the advance is scaled to the current size,
+and returned in 16.16 format.
+If LOAD_VERTICAL_LAYOUT is set, the vertical advance is returned.
+
+https://freetype.org/freetype2/docs/reference/ft2-quick_advance.html#ft_get_advance
+*/
+func (face Face) GetAdvance(glyphIndex UInt, loadFlags LoadFlag) (Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
+	// The advance is allocated by libc, as the stack of a goroutine may move during the call.
+	advance, free := alloc(face.tls, Fixed(0))
+	defer free()
+	err := libfreetype.XFT_Get_Advance(face.tls, face.handle(), glyphIndex, loadFlags, toUintptr(advance))
+	return *advance, newError(err, "failed to get advance for glyph index %d with flags %04x", glyphIndex, loadFlags)
+}
+
+/*
+GetAdvances retrieves the advance values of several glyph outlines in a face,
+starting with the glyph index start.
+
+The advances are in the same format as for GetAdvance.
+
+https://freetype.org/freetype2/docs/reference/ft2-quick_advance.html#ft_get_advances
+*/
+func (face Face) GetAdvances(start UInt, count UInt, loadFlags LoadFlag) ([]Fixed, error) {
+	defer runtime.KeepAlive(face.owner)
+	advances := make([]Fixed, count)
+	if count == 0 {
+		return advances, nil
+	}
+	err := libfreetype.XFT_Get_Advances(face.tls, face.handle(), start, count, loadFlags, toUintptr(&advances[0]))
+	if err != Err_Ok {
+		return nil, newError(err, "failed to get %d advances from glyph index %d with flags %04x", count, start, loadFlags)
+	}
+	return advances, nil
+}
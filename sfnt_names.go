@@ -1,6 +1,11 @@
 package freetype
 
 import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
 	"unsafe"
 
 	"modernc.org/libfreetype"
@@ -34,15 +39,172 @@ func (sn SfntName) String() string {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-sfnt_names.html#ft_get_sfnt_name_count
 func (face Face) GetSfntNameCount() int {
-	return int(libfreetype.XFT_Get_Sfnt_Name_Count(face.tls, face.face))
+	defer runtime.KeepAlive(face.owner)
+	return int(libfreetype.XFT_Get_Sfnt_Name_Count(face.tls, face.handle()))
 }
 
 func (face Face) GetSfntName(index UInt) (SfntName, error) {
+	defer runtime.KeepAlive(face.owner)
 	var sfntName SfntName
-	err := libfreetype.XFT_Get_Sfnt_Name(face.tls, face.face, index, toUintptr(&sfntName))
+	err := libfreetype.XFT_Get_Sfnt_Name(face.tls, face.handle(), index, toUintptr(&sfntName))
 	return sfntName, newError(err, "failed to get SFNT name table with index %d", index)
 }
 
+/*
+Names returns the decoded strings of the face's SFNT ‘name’ table, by name ID and BCP 47 language tag,
+such as names[NAME_ID_FULL_NAME]["en-US"].
+
+The languages of Windows and Macintosh records are converted to language tags,
+and the languages of records with language tags are looked up with GetSfntLangTag.
+Windows and Macintosh records in languages without a tag have private use tags, such as "x-lcid-0c01",
+and other records in an unknown language, or in no language, have the tag "und".
+Records in encodings that cannot be decoded are omitted.
+If there are records for the same name and language on more than one platform,
+the Windows or Unicode record is used.
+*/
+func (face Face) Names() map[TT_NameID]map[string]string {
+	names := map[TT_NameID]map[string]string{}
+	macintosh := map[TT_NameID]map[string]bool{}
+	for i := range face.GetSfntNameCount() {
+		sfntName, err := face.GetSfntName(UInt(i))
+		if err != nil {
+			continue
+		}
+		value, ok := sfntName.decode()
+		if !ok {
+			continue
+		}
+		nameID := TT_NameID(sfntName.NameID)
+		lang := face.sfntNameLanguage(sfntName)
+		if names[nameID] == nil {
+			names[nameID] = map[string]string{}
+			macintosh[nameID] = map[string]bool{}
+		}
+		isMacintosh := TT_Platform(sfntName.PlatformID) == PLATFORM_MACINTOSH
+		if _, exists := names[nameID][lang]; exists && (isMacintosh || !macintosh[nameID][lang]) {
+			continue
+		}
+		names[nameID][lang] = value
+		macintosh[nameID][lang] = isMacintosh
+	}
+	return names
+}
+
+/*
+Name returns a decoded string of the face's SFNT ‘name’ table, in the first of the preferred languages
+that it is available in, and an empty string if the table has no such name.
+
+A preferred language matches names in the same language with a more specific tag,
+so "en" matches "en-US", and then names in the same language with any tag, so "en-GB" matches "en-US".
+If the name is in none of the preferred languages, it is returned in English, or else in any language.
+
+If the table has no typographic family or subfamily name, the family or subfamily name is returned.
+The full name falls back to the Macintosh full name, the license to the license URL,
+and the designer to the manufacturer.
+*/
+func (face Face) Name(nameID TT_NameID, preferredLangs ...string) string {
+	names := face.Names()
+	for _, id := range nameFallbacks(nameID) {
+		if value, ok := matchNameLanguage(names[id], preferredLangs); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// nameFallbacks returns the name IDs that are used, in order, to look up a name.
+func nameFallbacks(nameID TT_NameID) []TT_NameID {
+	switch nameID {
+	case NAME_ID_TYPOGRAPHIC_FAMILY:
+		return []TT_NameID{nameID, NAME_ID_FONT_FAMILY}
+	case NAME_ID_TYPOGRAPHIC_SUBFAMILY:
+		return []TT_NameID{nameID, NAME_ID_FONT_SUBFAMILY}
+	case NAME_ID_FULL_NAME:
+		return []TT_NameID{nameID, NAME_ID_MAC_FULL_NAME}
+	case NAME_ID_LICENSE:
+		return []TT_NameID{nameID, NAME_ID_LICENSE_URL}
+	case NAME_ID_DESIGNER:
+		return []TT_NameID{nameID, NAME_ID_MANUFACTURER}
+	}
+	return []TT_NameID{nameID}
+}
+
+// matchNameLanguage returns the value of a name in the first of the preferred languages,
+// or else in English, or else in the language with the first tag in sorted order.
+func matchNameLanguage(values map[string]string, preferredLangs []string) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+	langs := slices.Sorted(maps.Keys(values))
+	for _, preferred := range append(slices.Clip(preferredLangs), "en-US", "en") {
+		preferred = strings.ToLower(preferred)
+		primary, _, _ := strings.Cut(preferred, "-")
+		var sameLanguage string
+		for _, lang := range langs {
+			tag := strings.ToLower(lang)
+			if tag == preferred || strings.HasPrefix(tag, preferred+"-") {
+				return values[lang], true
+			}
+			if sameLanguage == "" && (tag == primary || strings.HasPrefix(tag, primary+"-")) {
+				sameLanguage = lang
+			}
+		}
+		if sameLanguage != "" {
+			return values[sameLanguage], true
+		}
+	}
+	return values[langs[0]], true
+}
+
+// sfntNameLanguage returns the BCP 47 language tag of a name record.
+// Windows and Macintosh language IDs that are not in the tables have private use tags,
+// such as "x-lcid-0c01" and "x-mac-95", so that their records are kept apart.
+// It returns "und" if the record's language is otherwise unknown.
+func (face Face) sfntNameLanguage(sfntName SfntName) string {
+	var lang string
+	switch {
+	case sfntName.LanguageID >= 0x8000:
+		if langTag, err := face.GetSfntLangTag(UInt(sfntName.LanguageID)); err == nil {
+			lang = decodeUTF16BE([]byte(langTag.String()))
+		}
+	case TT_Platform(sfntName.PlatformID) == PLATFORM_MICROSOFT:
+		var ok bool
+		if lang, ok = windowsLanguages[sfntName.LanguageID]; !ok {
+			lang = fmt.Sprintf("x-lcid-%04x", sfntName.LanguageID)
+		}
+	case TT_Platform(sfntName.PlatformID) == PLATFORM_MACINTOSH:
+		var ok bool
+		if lang, ok = macintoshLanguages[sfntName.LanguageID]; !ok {
+			lang = fmt.Sprintf("x-mac-%d", sfntName.LanguageID)
+		}
+	}
+	if lang == "" {
+		return "und"
+	}
+	return lang
+}
+
+// decode returns the entry's string, decoded from the encoding of its platform,
+// and false if the encoding is not supported.
+func (sn SfntName) decode() (string, bool) {
+	data := []byte(sn.String())
+	switch TT_Platform(sn.PlatformID) {
+	case PLATFORM_APPLE_UNICODE:
+		return decodeUTF16BE(data), true
+	case PLATFORM_MICROSOFT:
+		// The symbol (0), Unicode BMP (1) and Unicode full repertoire (10) encodings are UTF-16BE.
+		if sn.EncodingID == 0 || sn.EncodingID == 1 || sn.EncodingID == 10 {
+			return decodeUTF16BE(data), true
+		}
+	case PLATFORM_MACINTOSH:
+		// The Roman encoding (0).
+		if sn.EncodingID == 0 {
+			return decodeMacRoman(data), true
+		}
+	}
+	return "", false
+}
+
 // SfntLangTag is a structure to model a language tag entry from an SFNT ‘name’ table.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-sfnt_names.html#ft_sfntlangtag
@@ -64,7 +226,8 @@ func (sn SfntLangTag) String() string {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-sfnt_names.html#ft_get_sfnt_langtag
 func (face Face) GetSfntLangTag(langID UInt) (SfntLangTag, error) {
+	defer runtime.KeepAlive(face.owner)
 	var langTag SfntLangTag
-	err := libfreetype.XFT_Get_Sfnt_LangTag(face.tls, face.face, langID, toUintptr(&langTag))
+	err := libfreetype.XFT_Get_Sfnt_LangTag(face.tls, face.handle(), langID, toUintptr(&langTag))
 	return langTag, newError(err, "failed to get SFNT language tage with langID %d", langID)
 }
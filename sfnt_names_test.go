@@ -23,3 +23,78 @@ func TestFaceGetSFNTName(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "Book", tableName.String())
 }
+
+func TestFaceNames(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+
+	names := face.Names()
+	assert.Equal(t, map[string]string{"en": "Book", "en-US": "Book"}, names[NAME_ID_FONT_SUBFAMILY])
+	assert.Equal(t, "DejaVu Sans", names[NAME_ID_FULL_NAME]["en-US"])
+}
+
+func TestFaceName(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	assert.Equal(t, "DejaVu Sans", face.Name(NAME_ID_FULL_NAME, "fr"))
+	assert.Equal(t, "Version 2.37", face.Name(NAME_ID_VERSION_STRING))
+	// DejaVu Sans does not name its designer, so the manufacturer is returned.
+	assert.Equal(t, "DejaVu fonts team", face.Name(NAME_ID_DESIGNER))
+	assert.Equal(t, "", face.Name(NAME_ID_SAMPLE_TEXT))
+
+	face, _ = lib.NewMemoryFace(font.RobotoVariable, 0)
+	// Roboto does not have a typographic family name, so the family name is returned.
+	assert.Equal(t, "Roboto", face.Name(NAME_ID_TYPOGRAPHIC_FAMILY))
+	assert.Equal(t, "Christian Robertson", face.Name(NAME_ID_DESIGNER))
+}
+
+func TestSfntNameLanguage(t *testing.T) {
+	var face Face
+	windows := func(id UShort) string {
+		return face.sfntNameLanguage(SfntName{PlatformID: UShort(PLATFORM_MICROSOFT), LanguageID: id})
+	}
+	assert.Equal(t, "en-US", windows(0x0409))
+	assert.Equal(t, "es-ES-u-co-trad", windows(0x040A))
+	assert.Equal(t, "es-ES", windows(0x0C0A))
+	// Arabic (Egypt) and Arabic (Syria) are not in the table, and have distinct tags.
+	assert.Equal(t, "x-lcid-0c01", windows(0x0C01))
+	assert.Equal(t, "x-lcid-2801", windows(0x2801))
+
+	assert.Equal(t, "fr", face.sfntNameLanguage(SfntName{PlatformID: UShort(PLATFORM_MACINTOSH), LanguageID: 1}))
+	assert.Equal(t, "x-mac-95", face.sfntNameLanguage(SfntName{PlatformID: UShort(PLATFORM_MACINTOSH), LanguageID: 95}))
+	assert.Equal(t, "und", face.sfntNameLanguage(SfntName{PlatformID: UShort(PLATFORM_APPLE_UNICODE)}))
+}
+
+func TestMatchNameLanguage(t *testing.T) {
+	values := map[string]string{"de-DE": "Fett", "en-GB": "Bold (GB)", "en-US": "Bold", "zh-Hant-TW": "粗體"}
+
+	tests := []struct {
+		langs    []string
+		expected string
+	}{
+		{nil, "Bold"},
+		{[]string{"de"}, "Fett"},
+		{[]string{"de-AT"}, "Fett"},
+		{[]string{"en-GB"}, "Bold (GB)"},
+		{[]string{"en-AU"}, "Bold (GB)"},
+		{[]string{"zh-Hant"}, "粗體"},
+		{[]string{"fr", "de-DE"}, "Fett"},
+		{[]string{"fr"}, "Bold"},
+	}
+	for _, test := range tests {
+		value, ok := matchNameLanguage(values, test.langs)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, value, test.langs)
+	}
+
+	value, ok := matchNameLanguage(map[string]string{"ja-JP": "太字", "de-DE": "Fett"}, nil)
+	assert.True(t, ok)
+	assert.Equal(t, "Fett", value)
+
+	_, ok = matchNameLanguage(nil, nil)
+	assert.False(t, ok)
+}
+
+func TestDecodeMacRoman(t *testing.T) {
+	assert.Equal(t, "Café © †", decodeMacRoman([]byte{'C', 'a', 'f', 0x8E, ' ', 0xA9, ' ', 0xA0}))
+}
@@ -0,0 +1,123 @@
+package shape
+
+import (
+	"sort"
+	"unicode"
+
+	"github.com/pekim/freetype"
+)
+
+// Shaping of Arabic, in which letters take a form that depends on whether they join the letters around them.
+//
+// https://learn.microsoft.com/en-us/typography/script-development/arabic
+// https://www.unicode.org/versions/latest/core-spec/chapter-9/#G7462
+
+// joiningType is a Unicode joining type.
+type joiningType uint8
+
+const (
+	// joiningNone is the type of characters that do not join (U).
+	joiningNone joiningType = iota
+	// joiningRight is the type of characters that join to the preceding character only (R).
+	joiningRight
+	// joiningLeft is the type of characters that join to the following character only (L).
+	joiningLeft
+	// joiningDual is the type of characters that join on both sides (D).
+	joiningDual
+	// joiningCausing is the type of characters that cause the characters around them to join (C).
+	joiningCausing
+	// joiningTransparent is the type of characters that do not affect joining, such as marks (T).
+	joiningTransparent
+)
+
+//go:generate go run gen_joining.go
+
+type joiningRange struct {
+	lo, hi rune
+	kind   joiningType
+}
+
+func joiningTypeOf(r rune) joiningType {
+	i := sort.Search(len(joiningRanges), func(i int) bool { return joiningRanges[i].hi >= r })
+	if i < len(joiningRanges) && joiningRanges[i].lo <= r {
+		return joiningRanges[i].kind
+	}
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return joiningTransparent
+	}
+	return joiningNone
+}
+
+// joinsFollowing reports whether a character of a joining type can join the character that follows it.
+func (t joiningType) joinsFollowing() bool {
+	return t == joiningDual || t == joiningLeft || t == joiningCausing
+}
+
+// joinsPreceding reports whether a character of a joining type can join the character that precedes it.
+func (t joiningType) joinsPreceding() bool {
+	return t == joiningDual || t == joiningRight || t == joiningCausing
+}
+
+// Masks of the features that select the forms of joining characters.
+const (
+	isolMask = 1 << (iota + 1)
+	finaMask
+	mediMask
+	initMask
+)
+
+type arabicShaper struct{}
+
+func (arabicShaper) decompose(r rune) []rune {
+	return nil
+}
+
+// setup selects the form of each joining character, from whether it joins the characters around it.
+func (arabicShaper) setup(b *buffer) {
+	joinsPrevious := make([]bool, len(b.glyphs))
+	joinsNext := make([]bool, len(b.glyphs))
+	previous := -1
+	for i, glyph := range b.glyphs {
+		t := joiningTypeOf(glyph.char)
+		if t == joiningTransparent {
+			continue
+		}
+		if previous >= 0 && joiningTypeOf(b.glyphs[previous].char).joinsFollowing() && t.joinsPreceding() {
+			joinsNext[previous] = true
+			joinsPrevious[i] = true
+		}
+		previous = i
+	}
+
+	for i := range b.glyphs {
+		var mask uint32
+		switch joiningTypeOf(b.glyphs[i].char) {
+		case joiningDual, joiningRight, joiningLeft:
+			switch {
+			case joinsPrevious[i] && joinsNext[i]:
+				mask = mediMask
+			case joinsPrevious[i]:
+				mask = finaMask
+			case joinsNext[i]:
+				mask = initMask
+			default:
+				mask = isolMask
+			}
+		}
+		b.glyphs[i].mask |= mask
+	}
+}
+
+// stages returns the Arabic features, each in its own stage so that they are applied in order.
+func (arabicShaper) stages() []stage {
+	stages := []stage{{features: []featureMask{
+		{tag: freetype.MakeTag("ccmp"), mask: globalMask, value: 1},
+		{tag: freetype.MakeTag("locl"), mask: globalMask, value: 1},
+	}}}
+	stages = append(stages, stageFeatures(isolMask, "isol")...)
+	stages = append(stages, stageFeatures(finaMask, "fina")...)
+	stages = append(stages, stageFeatures(mediMask, "medi")...)
+	stages = append(stages, stageFeatures(initMask, "init")...)
+	stages = append(stages, stageFeatures(globalMask, "rlig", "calt")...)
+	return stages
+}
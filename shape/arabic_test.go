@@ -0,0 +1,73 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+func TestJoiningType(t *testing.T) {
+	assert.Equal(t, joiningDual, joiningTypeOf('ب'))
+	assert.Equal(t, joiningRight, joiningTypeOf('ا'))
+	assert.Equal(t, joiningNone, joiningTypeOf('ء'))
+	assert.Equal(t, joiningCausing, joiningTypeOf('ـ'))
+	assert.Equal(t, joiningTransparent, joiningTypeOf(0x064E))
+	assert.Equal(t, joiningNone, joiningTypeOf(0x200C))
+	assert.Equal(t, joiningNone, joiningTypeOf('a'))
+	// Beh with small meem above, from the Arabic Extended-A block.
+	assert.Equal(t, joiningDual, joiningTypeOf(0x08B6))
+	// The Arabic number sign is a format character that does not join.
+	assert.Equal(t, joiningNone, joiningTypeOf(0x0600))
+	assert.Equal(t, joiningDual, joiningTypeOf(0x0712))
+}
+
+func TestArabicForms(t *testing.T) {
+	b := &buffer{}
+	for _, r := range "بببَ دب" {
+		b.glyphs = append(b.glyphs, glyphInfo{char: r, mask: globalMask})
+	}
+	arabicShaper{}.setup(b)
+
+	var masks []uint32
+	for _, glyph := range b.glyphs {
+		masks = append(masks, glyph.mask&^globalMask)
+	}
+	// The fatha is transparent, and the dal does not join the following beh.
+	assert.Equal(t, []uint32{initMask, mediMask, finaMask, 0, 0, isolMask, isolMask}, masks)
+}
+
+func TestArabicFormsExtended(t *testing.T) {
+	b := &buffer{}
+	for _, r := range "ب\u08B6ب" {
+		b.glyphs = append(b.glyphs, glyphInfo{char: r, mask: globalMask})
+	}
+	arabicShaper{}.setup(b)
+
+	var masks []uint32
+	for _, glyph := range b.glyphs {
+		masks = append(masks, glyph.mask&^globalMask)
+	}
+	assert.Equal(t, []uint32{initMask, mediMask, finaMask}, masks)
+}
+
+func TestShapeArabic(t *testing.T) {
+	shaper, face := newShaper(t, font.DejaVuSans)
+
+	isolated := face.GetCharIndex('ب')
+	glyphs := shape(t, shaper, "ببب")
+	assert.Len(t, glyphs, 3)
+	assert.Equal(t, []int{0, 2, 4}, []int{glyphs[0].Cluster, glyphs[1].Cluster, glyphs[2].Cluster})
+	for _, glyph := range glyphs {
+		assert.NotEqual(t, isolated, glyph.Index)
+	}
+	assert.NotEqual(t, glyphs[0].Index, glyphs[1].Index)
+	assert.NotEqual(t, glyphs[1].Index, glyphs[2].Index)
+
+	assert.Equal(t, isolated, shape(t, shaper, "ب")[0].Index)
+	assert.Equal(t, charGlyphs(face, "ببب"), unpositioned(shape(t, shaper, "ببب", "-init", "-medi", "-fina")))
+
+	// Lam and alef form a ligature.
+	assert.Len(t, shape(t, shaper, "لا"), 1)
+}
@@ -0,0 +1,175 @@
+package shape
+
+import "github.com/pekim/freetype"
+
+// The buffer of glyphs that lookups are applied to.
+
+// glyphInfo is a glyph in a buffer.
+type glyphInfo struct {
+	index   glyphID
+	cluster int
+	// char is the character that the glyph was mapped from, or for a glyph formed from several glyphs,
+	// the character of the first of them.
+	char rune
+	// mask has the bits of the features that are enabled for the glyph.
+	mask  uint32
+	class uint16
+	// ligatureID is non-zero for a ligature formed by a ligature substitution, and for the marks
+	// that were attached to its components.
+	ligatureID int
+	// component is, for a mark with a ligatureID, the 1-based index of the ligature's component
+	// that it was attached to. For a ligature it is the number of components.
+	component int
+	// syllable is the 1-based index of the glyph's syllable, for scripts that are shaped by syllables.
+	syllable int
+
+	// The glyph's position, set by GPOS lookups.
+	xAdvance, yAdvance freetype.Pos
+	xOffset, yOffset   freetype.Pos
+	// attachChain is, for a glyph attached to another glyph, the other glyph's position
+	// relative to this glyph's position.
+	attachChain int
+	attachType  attachType
+}
+
+// attachType is the kind of attachment of a glyph to another glyph.
+type attachType uint8
+
+const (
+	attachNone attachType = iota
+	attachMark
+	attachCursive
+)
+
+type buffer struct {
+	glyphs []glyphInfo
+	gdef   gdef
+	// nextLigatureID is the ligature ID for the next ligature that is formed.
+	nextLigatureID int
+	// maxLength limits the growth of the buffer by multiple substitutions.
+	maxLength int
+	// rightToLeft is true if the glyphs are in a right-to-left run.
+	rightToLeft bool
+}
+
+// setGlyph replaces the glyph at a position, keeping its cluster and mask.
+// If the font classifies glyphs, the position's class is updated.
+func (b *buffer) setGlyph(i int, glyph glyphID) {
+	b.glyphs[i].index = glyph
+	if b.gdef.glyphClasses != nil {
+		b.glyphs[i].class = b.gdef.glyphClass(glyph)
+	}
+}
+
+// ignored reports whether the glyph at a position is skipped by a lookup, due to its flags.
+func (b *buffer) ignored(i int, l *lookup) bool {
+	g := b.glyphs[i]
+	switch g.class {
+	case classBase:
+		return l.flag&lookupIgnoreBaseGlyphs != 0
+	case classLigature:
+		return l.flag&lookupIgnoreLigatures != 0
+	case classMark:
+		if l.flag&lookupIgnoreMarks != 0 {
+			return true
+		}
+		if l.flag&lookupUseMarkFilteringSet != 0 {
+			return !b.gdef.inMarkGlyphSet(l.markFilteringSet, g.index)
+		}
+		if attachType := l.flag & lookupMarkAttachmentType >> 8; attachType != 0 {
+			return b.gdef.markAttachClass(g.index) != attachType
+		}
+	}
+	return false
+}
+
+// next returns the position of the next glyph after i that is not ignored by a lookup, or -1.
+func (b *buffer) next(i int, l *lookup) int {
+	for i++; i < len(b.glyphs); i++ {
+		if !b.ignored(i, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// previous returns the position of the previous glyph before i that is not ignored by a lookup, or -1.
+func (b *buffer) previous(i int, l *lookup) int {
+	for i--; i >= 0; i-- {
+		if !b.ignored(i, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matcher reports whether a glyph matches the k'th element of a sequence.
+type matcher func(k int, glyph glyphID) bool
+
+/*
+matchInput matches the glyph at position i, and the glyphs that follow it, to an input sequence
+of count glyphs. Only the glyphs following i are tested with matches.
+Glyphs ignored by the lookup are skipped, and the matched glyphs must have a bit of the mask.
+
+The positions of the matched glyphs are returned, starting with i.
+*/
+func (b *buffer) matchInput(i int, l *lookup, mask uint32, count int, matches matcher) ([]int, bool) {
+	positions := make([]int, 1, count)
+	positions[0] = i
+	for k := 1; k < count; k++ {
+		i = b.next(i, l)
+		if i < 0 || b.glyphs[i].mask&mask == 0 || !matches(k, b.glyphs[i].index) {
+			return nil, false
+		}
+		positions = append(positions, i)
+	}
+	return positions, true
+}
+
+// matchBacktrack matches the glyphs before position i to a backtrack sequence of count glyphs,
+// in which the first element is the glyph closest to i.
+func (b *buffer) matchBacktrack(i int, l *lookup, count int, matches matcher) bool {
+	for k := range count {
+		i = b.previous(i, l)
+		if i < 0 || !matches(k, b.glyphs[i].index) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLookahead matches the glyphs after position i to a lookahead sequence of count glyphs.
+func (b *buffer) matchLookahead(i int, l *lookup, count int, matches matcher) bool {
+	for k := range count {
+		i = b.next(i, l)
+		if i < 0 || !matches(k, b.glyphs[i].index) {
+			return false
+		}
+	}
+	return true
+}
+
+// replace replaces the glyph at a position with a sequence of glyphs, that take its cluster and mask.
+func (b *buffer) replace(i int, glyphs []glyphID) {
+	original := b.glyphs[i]
+	replacements := make([]glyphInfo, len(glyphs))
+	for k, glyph := range glyphs {
+		replacements[k] = original
+		replacements[k].index = glyph
+	}
+	b.glyphs = append(b.glyphs[:i], append(replacements, b.glyphs[i+1:]...)...)
+	for k := range glyphs {
+		b.setGlyph(i+k, glyphs[k])
+	}
+}
+
+// mergeClusters sets the clusters of the glyphs from start up to end to the lowest of their clusters.
+func (b *buffer) mergeClusters(start, end int) {
+	cluster := b.glyphs[start].cluster
+	for i := start + 1; i < end; i++ {
+		cluster = min(cluster, b.glyphs[i].cluster)
+	}
+	for i := start; i < end; i++ {
+		b.glyphs[i].cluster = cluster
+	}
+}
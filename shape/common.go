@@ -0,0 +1,222 @@
+package shape
+
+import (
+	"sort"
+
+	"github.com/pekim/freetype"
+)
+
+// Structures common to the GSUB and GPOS tables.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/chapter2
+
+// coverage returns the coverage index of a glyph in a coverage table,
+// or -1 if the glyph is not covered.
+func (t table) coverage(glyph glyphID) int {
+	switch t.u16(0) {
+	case 1:
+		count := int(t.u16(2))
+		i := sort.Search(count, func(i int) bool { return t.u16(4+2*i) >= glyph })
+		if i < count && t.u16(4+2*i) == glyph {
+			return i
+		}
+
+	case 2:
+		count := int(t.u16(2))
+		i := sort.Search(count, func(i int) bool { return t.u16(4+6*i+2) >= glyph })
+		if i < count {
+			record := 4 + 6*i
+			start := t.u16(record)
+			if start <= glyph {
+				return int(t.u16(record+4)) + int(glyph-start)
+			}
+		}
+	}
+	return -1
+}
+
+// class returns the class of a glyph in a class definition table.
+// Glyphs that are not assigned a class are in class 0.
+func (t table) class(glyph glyphID) uint16 {
+	switch t.u16(0) {
+	case 1:
+		start := t.u16(2)
+		count := t.u16(4)
+		if glyph >= start && glyph-start < count {
+			return t.u16(6 + 2*int(glyph-start))
+		}
+
+	case 2:
+		count := int(t.u16(2))
+		i := sort.Search(count, func(i int) bool { return t.u16(4+6*i+2) >= glyph })
+		if i < count {
+			record := 4 + 6*i
+			if t.u16(record) <= glyph {
+				return t.u16(record + 4)
+			}
+		}
+	}
+	return 0
+}
+
+// Lookup flags.
+const (
+	lookupRightToLeft         = 0x0001
+	lookupIgnoreBaseGlyphs    = 0x0002
+	lookupIgnoreLigatures     = 0x0004
+	lookupIgnoreMarks         = 0x0008
+	lookupUseMarkFilteringSet = 0x0010
+	lookupMarkAttachmentType  = 0xFF00
+)
+
+// lookup is a lookup table, with any extension subtables resolved.
+type lookup struct {
+	kind             uint16
+	flag             uint16
+	markFilteringSet uint16
+	subtables        []table
+}
+
+// layoutTable is the header of a GSUB or GPOS table, and its lookups.
+type layoutTable struct {
+	scripts  table
+	features table
+	lookups  []lookup
+}
+
+// parseLayoutTable parses a GSUB or GPOS table.
+// Lookups of the extensionKind have their subtables replaced by the extension subtables.
+func parseLayoutTable(data table, extensionKind uint16) *layoutTable {
+	if data == nil {
+		return nil
+	}
+	t := &layoutTable{
+		scripts:  data.offset16(4),
+		features: data.offset16(6),
+	}
+
+	lookupList := data.offset16(8)
+	t.lookups = make([]lookup, lookupList.u16(0))
+	for i := range t.lookups {
+		l := lookupList.offset16(2 + 2*i)
+		lk := lookup{kind: l.u16(0), flag: l.u16(2)}
+		count := int(l.u16(4))
+		for s := range count {
+			subtable := l.offset16(6 + 2*s)
+			if lk.kind == extensionKind {
+				lk.kind = subtable.u16(2)
+				subtable = subtable.offset32(4)
+			}
+			lk.subtables = append(lk.subtables, subtable)
+		}
+		if lk.kind == extensionKind {
+			// An extension lookup without any subtables.
+			lk.kind = 0
+		}
+		if lk.flag&lookupUseMarkFilteringSet != 0 {
+			lk.markFilteringSet = l.u16(6 + 2*count)
+		}
+		t.lookups[i] = lk
+	}
+	return t
+}
+
+var (
+	tagDFLT = freetype.MakeTag("DFLT")
+	tagDflt = freetype.MakeTag("dflt")
+	tagLatn = freetype.MakeTag("latn")
+)
+
+// findRecord returns the subtable of the record with a tag, in a list of tag and offset records.
+func (t table) findRecord(countOffset int, tag freetype.Tag) table {
+	count := int(t.u16(countOffset))
+	for i := range count {
+		record := countOffset + 2 + 6*i
+		if freetype.Tag(t.u32(record)) == tag {
+			return t.offset16(record + 4)
+		}
+	}
+	return nil
+}
+
+// langSys returns the language system table for the first of the scripts that the table supports,
+// and a language. The default language system is used if language is 0 or is not supported.
+func (t *layoutTable) langSys(scripts []freetype.Tag, language freetype.Tag) table {
+	var script table
+	candidates := append(append([]freetype.Tag(nil), scripts...), tagDFLT, tagDflt, tagLatn)
+	for _, tag := range candidates {
+		if script = t.scripts.findRecord(0, tag); script != nil {
+			break
+		}
+	}
+	if script == nil {
+		return nil
+	}
+	if language != 0 {
+		if langSys := script.findRecord(2, language); langSys != nil {
+			return langSys
+		}
+	}
+	return script.offset16(0)
+}
+
+// featureMask enables a feature for the glyphs that have any of the bits in a mask.
+type featureMask struct {
+	tag   freetype.Tag
+	mask  uint32
+	value uint32
+}
+
+// lookupPlan is a lookup to be applied to the glyphs that have any of the bits in a mask.
+type lookupPlan struct {
+	index int
+	mask  uint32
+	// value is the value of the feature that enabled the lookup, used to select alternates.
+	value uint32
+}
+
+// plan returns the lookups of the enabled features of a language system, in the order in which they are applied.
+// The language system's required feature, if any, is applied to all glyphs.
+func (t *layoutTable) plan(scripts []freetype.Tag, language freetype.Tag, features []featureMask) []lookupPlan {
+	langSys := t.langSys(scripts, language)
+	if langSys == nil {
+		return nil
+	}
+
+	plans := map[int]lookupPlan{}
+	addFeature := func(featureIndex int, mask uint32, value uint32) {
+		feature := t.features.offset16(2 + 6*featureIndex + 4)
+		for i := range int(feature.u16(2)) {
+			index := int(feature.u16(4 + 2*i))
+			if index >= len(t.lookups) {
+				continue
+			}
+			p, ok := plans[index]
+			if !ok {
+				p = lookupPlan{index: index, value: value}
+			}
+			p.mask |= mask
+			plans[index] = p
+		}
+	}
+
+	if required := langSys.u16(2); required != 0xFFFF {
+		addFeature(int(required), ^uint32(0), 1)
+	}
+	for i := range int(langSys.u16(4)) {
+		featureIndex := int(langSys.u16(6 + 2*i))
+		tag := freetype.Tag(t.features.u32(2 + 6*featureIndex))
+		for _, feature := range features {
+			if feature.tag == tag && feature.value != 0 {
+				addFeature(featureIndex, feature.mask, feature.value)
+			}
+		}
+	}
+
+	lookups := make([]lookupPlan, 0, len(plans))
+	for _, p := range plans {
+		lookups = append(lookups, p)
+	}
+	sort.Slice(lookups, func(i, j int) bool { return lookups[i].index < lookups[j].index })
+	return lookups
+}
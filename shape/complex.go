@@ -0,0 +1,86 @@
+package shape
+
+import "github.com/pekim/freetype"
+
+// Shaping of scripts with features that apply to some glyphs only, or with glyphs that are reordered.
+
+// stage is a set of features, whose GSUB lookups are applied together.
+type stage struct {
+	features []featureMask
+	// pause, if not nil, processes the buffer after the stage's lookups have been applied.
+	pause func(b *buffer)
+}
+
+// complexShaper shapes the text of a script that needs more than the default features.
+type complexShaper interface {
+	// decompose returns the characters that a character is decomposed in to, or nil if it is not decomposed.
+	decompose(r rune) []rune
+	// setup assigns masks to the glyphs of a buffer, and reorders them, before any lookups are applied.
+	setup(b *buffer)
+	// stages returns the stages of the script's features, which are applied before the default features.
+	stages() []stage
+}
+
+// complexShaperFor returns the shaper for the first of the OpenType script tags that needs one,
+// or nil if the script can be shaped with the default features.
+func complexShaperFor(scripts []freetype.Tag) complexShaper {
+	for _, script := range scripts {
+		switch script {
+		case freetype.MakeTag("arab"):
+			return arabicShaper{}
+		case freetype.MakeTag("dev2"), freetype.MakeTag("deva"):
+			return indicShaper{script: &devanagari}
+		case freetype.MakeTag("bng2"), freetype.MakeTag("beng"):
+			return indicShaper{script: &bengali}
+		}
+	}
+	return nil
+}
+
+// stageFeatures returns a stage for each feature, with a mask.
+func stageFeatures(mask uint32, tags ...string) []stage {
+	stages := make([]stage, len(tags))
+	for i, tag := range tags {
+		stages[i].features = []featureMask{{tag: freetype.MakeTag(tag), mask: mask, value: 1}}
+	}
+	return stages
+}
+
+/*
+shapingStages returns the stages of a complex shaper's features, if any,
+followed by a stage with the default features and the features of opts.
+
+The features of opts also change the values of, or disable, the complex shaper's features.
+Default features that are in the complex shaper's stages are not repeated.
+*/
+func shapingStages(shaper complexShaper, features []Feature) []stage {
+	var stages []stage
+	inStages := map[freetype.Tag]bool{}
+	if shaper != nil {
+		values := map[freetype.Tag]uint32{}
+		for _, feature := range features {
+			values[feature.Tag] = feature.Value
+		}
+		for _, st := range shaper.stages() {
+			enabled := st.features[:0:0]
+			for _, feature := range st.features {
+				inStages[feature.tag] = true
+				if value, ok := values[feature.tag]; ok {
+					feature.value = value
+				}
+				if feature.value != 0 {
+					enabled = append(enabled, feature)
+				}
+			}
+			stages = append(stages, stage{features: enabled, pause: st.pause})
+		}
+	}
+
+	var final stage
+	for _, feature := range featureMasks(features) {
+		if !inStages[feature.tag] {
+			final.features = append(final.features, feature)
+		}
+	}
+	return append(stages, final)
+}
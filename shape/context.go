@@ -0,0 +1,242 @@
+package shape
+
+// Sequence context and chained sequence context subtables, which are common to the GSUB and GPOS tables.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/chapter2#sequence-context-format-1-simple-glyph-contexts
+
+// at returns the part of t from an offset. Unlike sub, an offset of 0 returns all of t.
+func (t table) at(offset int) table {
+	if offset < 0 || offset >= len(t) {
+		return nil
+	}
+	return t[offset:]
+}
+
+// sequenceRule is a rule of a sequence context or chained sequence context subtable.
+// Its sequences are arrays of glyphs, classes, or coverage table offsets, depending on the subtable's format.
+type sequenceRule struct {
+	backtrack      table
+	backtrackCount int
+	input          table
+	// inputCount is the number of glyphs in the input sequence, including the first glyph.
+	inputCount int
+	// inputOmitted is 1 if the input array omits the input sequence's first glyph,
+	// which is matched by the subtable's coverage table, and 0 if it does not.
+	inputOmitted   int
+	lookahead      table
+	lookaheadCount int
+	records        table
+	recordCount    int
+}
+
+// parseSequenceRule parses a sequence rule, or a format 3 sequence context subtable from offset 2.
+func parseSequenceRule(rule table, offset int, includesFirst bool) sequenceRule {
+	r := sequenceRule{inputCount: int(rule.u16(offset)), recordCount: int(rule.u16(offset + 2)), inputOmitted: 1}
+	if includesFirst {
+		r.inputOmitted = 0
+	}
+	inputLength := r.inputCount - r.inputOmitted
+	r.input = rule.at(offset + 4)
+	r.records = rule.at(offset + 4 + 2*inputLength)
+	return r
+}
+
+// parseChainedSequenceRule parses a chained sequence rule, or a format 3 chained sequence context subtable from offset 2.
+func parseChainedSequenceRule(rule table, offset int, includesFirst bool) sequenceRule {
+	var r sequenceRule
+	r.backtrackCount = int(rule.u16(offset))
+	r.backtrack = rule.at(offset + 2)
+	offset += 2 + 2*r.backtrackCount
+
+	r.inputCount = int(rule.u16(offset))
+	r.input = rule.at(offset + 2)
+	r.inputOmitted = 1
+	if includesFirst {
+		r.inputOmitted = 0
+	}
+	inputLength := r.inputCount - r.inputOmitted
+	offset += 2 + 2*max(0, inputLength)
+
+	r.lookaheadCount = int(rule.u16(offset))
+	r.lookahead = rule.at(offset + 2)
+	offset += 2 + 2*r.lookaheadCount
+
+	r.recordCount = int(rule.u16(offset))
+	r.records = rule.at(offset + 2)
+	return r
+}
+
+// valueMatcher reports whether a glyph matches a value in a sequence.
+type valueMatcher func(value uint16, glyph glyphID) bool
+
+// match matches a rule at position i, where the first glyph of its input sequence has already been matched.
+// It returns the positions of the input sequence's glyphs.
+func (b *buffer) match(l *lookup, i int, r sequenceRule, backtrack, input, lookahead valueMatcher) ([]int, bool) {
+	if r.inputCount < 1 {
+		return nil, false
+	}
+	positions, ok := b.matchInput(i, l, ^uint32(0), r.inputCount, func(k int, glyph glyphID) bool {
+		return input(r.input.u16(2*(k-r.inputOmitted)), glyph)
+	})
+	if !ok {
+		return nil, false
+	}
+	if !b.matchBacktrack(i, l, r.backtrackCount, func(k int, glyph glyphID) bool {
+		return backtrack(r.backtrack.u16(2*k), glyph)
+	}) {
+		return nil, false
+	}
+	if !b.matchLookahead(positions[len(positions)-1], l, r.lookaheadCount, func(k int, glyph glyphID) bool {
+		return lookahead(r.lookahead.u16(2*k), glyph)
+	}) {
+		return nil, false
+	}
+	return positions, true
+}
+
+// nestedApplier applies a lookup, from a sequence lookup record, at a position.
+type nestedApplier func(lookupIndex int, position int)
+
+// applyRecords applies the sequence lookup records of a matched rule, and returns the position following the input sequence.
+func (b *buffer) applyRecords(positions []int, r sequenceRule, nested nestedApplier) int {
+	end := positions[len(positions)-1] + 1
+	for k := range r.recordCount {
+		sequenceIndex := int(r.records.u16(4 * k))
+		lookupIndex := int(r.records.u16(4*k + 2))
+		if sequenceIndex >= len(positions) {
+			continue
+		}
+
+		length := len(b.glyphs)
+		nested(lookupIndex, positions[sequenceIndex])
+		if delta := len(b.glyphs) - length; delta != 0 {
+			// Keep the positions of the rest of the input sequence in step with the buffer.
+			for p := sequenceIndex + 1; p < len(positions); p++ {
+				positions[p] += delta
+			}
+			end += delta
+		}
+	}
+	return end
+}
+
+func matchGlyph(value uint16, glyph glyphID) bool {
+	return value == glyph
+}
+
+func matchClass(classDef table) valueMatcher {
+	return func(value uint16, glyph glyphID) bool {
+		return classDef.class(glyph) == value
+	}
+}
+
+func matchCoverage(subtable table) valueMatcher {
+	return func(value uint16, glyph glyphID) bool {
+		return subtable.sub(int(value)).coverage(glyph) >= 0
+	}
+}
+
+// applyContext applies a sequence context subtable at position i.
+func (b *buffer) applyContext(l *lookup, subtable table, i int, nested nestedApplier) (int, bool) {
+	glyph := b.glyphs[i].index
+
+	var rules table
+	var input valueMatcher
+	switch subtable.u16(0) {
+	case 1:
+		c := subtable.offset16(2).coverage(glyph)
+		if c < 0 || c >= int(subtable.u16(4)) {
+			return 0, false
+		}
+		rules = subtable.offset16(6 + 2*c)
+		input = matchGlyph
+
+	case 2:
+		if subtable.offset16(2).coverage(glyph) < 0 {
+			return 0, false
+		}
+		classDef := subtable.offset16(4)
+		class := int(classDef.class(glyph))
+		if class >= int(subtable.u16(6)) {
+			return 0, false
+		}
+		rules = subtable.offset16(8 + 2*class)
+		input = matchClass(classDef)
+
+	case 3:
+		r := parseSequenceRule(subtable, 2, true)
+		if r.inputCount < 1 || subtable.sub(int(r.input.u16(0))).coverage(glyph) < 0 {
+			return 0, false
+		}
+		positions, ok := b.match(l, i, r, nil, matchCoverage(subtable), nil)
+		if !ok {
+			return 0, false
+		}
+		return b.applyRecords(positions, r, nested), true
+
+	default:
+		return 0, false
+	}
+
+	for k := range int(rules.u16(0)) {
+		r := parseSequenceRule(rules.offset16(2+2*k), 0, false)
+		if positions, ok := b.match(l, i, r, nil, input, nil); ok {
+			return b.applyRecords(positions, r, nested), true
+		}
+	}
+	return 0, false
+}
+
+// applyChainedContext applies a chained sequence context subtable at position i.
+func (b *buffer) applyChainedContext(l *lookup, subtable table, i int, nested nestedApplier) (int, bool) {
+	glyph := b.glyphs[i].index
+
+	var rules table
+	var backtrack, input, lookahead valueMatcher
+	switch subtable.u16(0) {
+	case 1:
+		c := subtable.offset16(2).coverage(glyph)
+		if c < 0 || c >= int(subtable.u16(4)) {
+			return 0, false
+		}
+		rules = subtable.offset16(6 + 2*c)
+		backtrack, input, lookahead = matchGlyph, matchGlyph, matchGlyph
+
+	case 2:
+		if subtable.offset16(2).coverage(glyph) < 0 {
+			return 0, false
+		}
+		inputClassDef := subtable.offset16(6)
+		class := int(inputClassDef.class(glyph))
+		if class >= int(subtable.u16(10)) {
+			return 0, false
+		}
+		rules = subtable.offset16(12 + 2*class)
+		backtrack = matchClass(subtable.offset16(4))
+		input = matchClass(inputClassDef)
+		lookahead = matchClass(subtable.offset16(8))
+
+	case 3:
+		r := parseChainedSequenceRule(subtable, 2, true)
+		if r.inputCount < 1 || subtable.sub(int(r.input.u16(0))).coverage(glyph) < 0 {
+			return 0, false
+		}
+		coverage := matchCoverage(subtable)
+		positions, ok := b.match(l, i, r, coverage, coverage, coverage)
+		if !ok {
+			return 0, false
+		}
+		return b.applyRecords(positions, r, nested), true
+
+	default:
+		return 0, false
+	}
+
+	for k := range int(rules.u16(0)) {
+		r := parseChainedSequenceRule(rules.offset16(2+2*k), 0, false)
+		if positions, ok := b.match(l, i, r, backtrack, input, lookahead); ok {
+			return b.applyRecords(positions, r, nested), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package shape
+
+// The glyph definition (GDEF) table.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/gdef
+
+// Glyph classes, as defined by the GDEF table's glyph class definition table.
+const (
+	classUnassigned = 0
+	classBase       = 1
+	classLigature   = 2
+	classMark       = 3
+	classComponent  = 4
+)
+
+type gdef struct {
+	glyphClasses      table
+	markAttachClasses table
+	markGlyphSets     table
+	// varStore is the item variation store of the deltas of GPOS values, in variable fonts.
+	varStore table
+}
+
+func parseGDEF(data table) gdef {
+	g := gdef{
+		glyphClasses:      data.offset16(4),
+		markAttachClasses: data.offset16(10),
+	}
+	if data.u16(0) == 1 && data.u16(2) >= 2 {
+		g.markGlyphSets = data.offset16(12)
+	}
+	if data.u16(0) == 1 && data.u16(2) >= 3 {
+		g.varStore = data.offset32(14)
+	}
+	return g
+}
+
+// glyphClass returns the class of a glyph, or classUnassigned if the font does not classify glyphs.
+func (g gdef) glyphClass(glyph glyphID) uint16 {
+	if g.glyphClasses == nil {
+		return classUnassigned
+	}
+	return g.glyphClasses.class(glyph)
+}
+
+func (g gdef) markAttachClass(glyph glyphID) uint16 {
+	return g.markAttachClasses.class(glyph)
+}
+
+// inMarkGlyphSet reports whether a glyph is in one of the mark glyph sets.
+func (g gdef) inMarkGlyphSet(set uint16, glyph glyphID) bool {
+	if int(set) >= int(g.markGlyphSets.u16(2)) {
+		return false
+	}
+	return g.markGlyphSets.offset32(4+4*int(set)).coverage(glyph) >= 0
+}
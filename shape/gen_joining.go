@@ -0,0 +1,133 @@
+//go:build ignore
+
+// gen_joining generates joining_table.go from ArabicShaping.txt of the Unicode Character Database.
+//
+// Usage:
+//
+//	go run gen_joining.go [ArabicShaping.txt]
+//
+// The file is downloaded from unicode.org if it is not given.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// unicodeVersion is the version of the Unicode Character Database.
+const unicodeVersion = "15.0.0"
+
+var url = "https://www.unicode.org/Public/" + unicodeVersion + "/ucd/ArabicShaping.txt"
+
+// joiningTypes are the names of the joiningType constants, by their short names in the file.
+var joiningTypes = map[string]string{
+	"U": "joiningNone",
+	"R": "joiningRight",
+	"L": "joiningLeft",
+	"D": "joiningDual",
+	"C": "joiningCausing",
+	"T": "joiningTransparent",
+}
+
+type joining struct {
+	r    rune
+	kind string
+}
+
+func main() {
+	data, err := readUCD()
+	if err != nil {
+		log.Fatal(err)
+	}
+	joinings, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(joinings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("joining_table.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readUCD() ([]byte, error) {
+	if len(os.Args) > 1 {
+		return os.ReadFile(os.Args[1])
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s : %s", url, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// parse returns the joining types of the characters in the file, sorted by character.
+func parse(data []byte) ([]joining, error) {
+	var joinings []joining
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		// Fields are code point, name, joining type and joining group.
+		fields := strings.Split(text, ";")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected at least 3 fields", line)
+		}
+		r, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		kind, ok := joiningTypes[strings.TrimSpace(fields[2])]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown joining type %q", line, strings.TrimSpace(fields[2]))
+		}
+		joinings = append(joinings, joining{rune(r), kind})
+	}
+	sort.Slice(joinings, func(i, j int) bool { return joinings[i].r < joinings[j].r })
+	return joinings, scanner.Err()
+}
+
+func generate(joinings []joining) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_joining.go from %s. DO NOT EDIT.\n\n", url)
+	b.WriteString("package shape\n\n")
+	b.WriteString("// joiningRanges are the joining types of characters, from the Unicode Character Database.\n")
+	b.WriteString("// Characters that are not in the table are transparent if they are marks or format characters,\n")
+	b.WriteString("// and otherwise do not join.\n")
+	b.WriteString("// It is sorted, and its ranges do not overlap.\n")
+	b.WriteString("var joiningRanges = []joiningRange{\n")
+
+	for i := 0; i < len(joinings); {
+		j := i + 1
+		for j < len(joinings) && joinings[j].r == joinings[j-1].r+1 && joinings[j].kind == joinings[i].kind {
+			j++
+		}
+		fmt.Fprintf(&b, "\t{0x%04X, 0x%04X, %s},\n", joinings[i].r, joinings[j-1].r, joinings[i].kind)
+		i = j
+	}
+
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
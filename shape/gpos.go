@@ -0,0 +1,465 @@
+package shape
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/pekim/freetype"
+)
+
+// The glyph positioning (GPOS) table.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/gpos
+
+// GPOS lookup types.
+const (
+	gposSingle         = 1
+	gposPair           = 2
+	gposCursive        = 3
+	gposMarkToBase     = 4
+	gposMarkToLigature = 5
+	gposMarkToMark     = 6
+	gposContext        = 7
+	gposChainedContext = 8
+	gposExtension      = 9
+)
+
+// Value record formats.
+const (
+	valueXPlacement       = 0x0001
+	valueYPlacement       = 0x0002
+	valueXAdvance         = 0x0004
+	valueYAdvance         = 0x0008
+	valueXPlacementDevice = 0x0010
+	valueYPlacementDevice = 0x0020
+	valueXAdvanceDevice   = 0x0040
+	valueYAdvanceDevice   = 0x0080
+)
+
+type gpos struct {
+	*layoutTable
+}
+
+// apply applies the lookups of a plan to a buffer, in order.
+func (g gpos) apply(b *buffer, plan []lookupPlan, s *scaler) {
+	for _, p := range plan {
+		l := &g.lookups[p.index]
+		for i := 0; i < len(b.glyphs); {
+			if b.glyphs[i].mask&p.mask != 0 && !b.ignored(i, l) {
+				if next, ok := g.applyLookup(b, l, i, s, 0); ok {
+					i = max(next, i+1)
+					continue
+				}
+			}
+			i++
+		}
+	}
+}
+
+// applyLookup applies the first of a lookup's subtables that applies at position i.
+// It returns the position from which the lookup continues to be applied.
+func (g gpos) applyLookup(b *buffer, l *lookup, i int, s *scaler, depth int) (int, bool) {
+	nested := func(lookupIndex int, position int) {
+		if depth < maxNesting && lookupIndex < len(g.lookups) && position < len(b.glyphs) {
+			g.applyLookup(b, &g.lookups[lookupIndex], position, s, depth+1)
+		}
+	}
+
+	for _, subtable := range l.subtables {
+		var next int
+		var ok bool
+		switch l.kind {
+		case gposSingle:
+			next, ok = i+1, b.positionSingle(subtable, i, s)
+		case gposPair:
+			next, ok = b.positionPair(l, subtable, i, s)
+		case gposCursive:
+			next, ok = b.positionCursive(l, subtable, i, s)
+		case gposMarkToBase:
+			next, ok = i+1, b.positionMarkToBase(subtable, i, s)
+		case gposMarkToLigature:
+			next, ok = i+1, b.positionMarkToLigature(subtable, i, s)
+		case gposMarkToMark:
+			next, ok = i+1, b.positionMarkToMark(l, subtable, i, s)
+		case gposContext:
+			next, ok = b.applyContext(l, subtable, i, nested)
+		case gposChainedContext:
+			next, ok = b.applyChainedContext(l, subtable, i, nested)
+		}
+		if ok {
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+// valueRecordSize returns the size of a value record of a format.
+func valueRecordSize(format uint16) int {
+	return 2 * bits.OnesCount16(format)
+}
+
+// adjust adds the values of a value record to a glyph's position.
+// The offsets of the record's device tables are from the start of parent.
+func (g *glyphInfo) adjust(parent table, record table, format uint16, s *scaler) {
+	offset := 0
+	next := func() uint16 {
+		value := record.u16(offset)
+		offset += 2
+		return value
+	}
+	if format&valueXPlacement != 0 {
+		g.xOffset += s.x(int16(next()))
+	}
+	if format&valueYPlacement != 0 {
+		g.yOffset += s.y(int16(next()))
+	}
+	if format&valueXAdvance != 0 {
+		g.xAdvance += s.x(int16(next()))
+	}
+	if format&valueYAdvance != 0 {
+		g.yAdvance += s.y(int16(next()))
+	}
+	if format&valueXPlacementDevice != 0 {
+		g.xOffset += s.xDevice(parent.sub(int(next())))
+	}
+	if format&valueYPlacementDevice != 0 {
+		g.yOffset += s.yDevice(parent.sub(int(next())))
+	}
+	if format&valueXAdvanceDevice != 0 {
+		g.xAdvance += s.xDevice(parent.sub(int(next())))
+	}
+	if format&valueYAdvanceDevice != 0 {
+		g.yAdvance += s.yDevice(parent.sub(int(next())))
+	}
+}
+
+// positionSingle adjusts the position of a glyph (lookup type 1).
+func (b *buffer) positionSingle(subtable table, i int, s *scaler) bool {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if c < 0 {
+		return false
+	}
+
+	format := subtable.u16(4)
+	switch subtable.u16(0) {
+	case 1:
+		b.glyphs[i].adjust(subtable, subtable.at(6), format, s)
+		return true
+	case 2:
+		if c >= int(subtable.u16(6)) {
+			return false
+		}
+		b.glyphs[i].adjust(subtable, subtable.at(8+c*valueRecordSize(format)), format, s)
+		return true
+	}
+	return false
+}
+
+// positionPair adjusts the positions of a pair of glyphs, such as for kerning (lookup type 2).
+// It returns the position of the second glyph, or the position following it if the second glyph was adjusted.
+func (b *buffer) positionPair(l *lookup, subtable table, i int, s *scaler) (int, bool) {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if c < 0 {
+		return 0, false
+	}
+	j := b.next(i, l)
+	if j < 0 {
+		return 0, false
+	}
+	second := b.glyphs[j].index
+
+	format1, format2 := subtable.u16(4), subtable.u16(6)
+	size1, size2 := valueRecordSize(format1), valueRecordSize(format2)
+	var record table
+	switch subtable.u16(0) {
+	case 1:
+		if c >= int(subtable.u16(8)) {
+			return 0, false
+		}
+		pairSet := subtable.offset16(10 + 2*c)
+		count := int(pairSet.u16(0))
+		recordSize := 2 + size1 + size2
+		k := sort.Search(count, func(k int) bool { return pairSet.u16(2+k*recordSize) >= second })
+		if k >= count || pairSet.u16(2+k*recordSize) != second {
+			return 0, false
+		}
+		record = pairSet.at(2 + k*recordSize + 2)
+
+	case 2:
+		class1 := int(subtable.offset16(8).class(b.glyphs[i].index))
+		class2 := int(subtable.offset16(10).class(second))
+		class1Count, class2Count := int(subtable.u16(12)), int(subtable.u16(14))
+		if class1 >= class1Count || class2 >= class2Count {
+			return 0, false
+		}
+		record = subtable.at(16 + (class1*class2Count+class2)*(size1+size2))
+
+	default:
+		return 0, false
+	}
+
+	b.glyphs[i].adjust(subtable, record, format1, s)
+	b.glyphs[j].adjust(subtable, record.at(size1), format2, s)
+	if format2 != 0 {
+		return j + 1, true
+	}
+	return j, true
+}
+
+// anchor returns the position of an anchor point.
+func (s *scaler) anchor(anchor table) (x, y freetype.Pos) {
+	x, y = s.x(anchor.i16(2)), s.y(anchor.i16(4))
+	if anchor.u16(0) == 3 {
+		x += s.xDevice(anchor.sub(int(anchor.u16(6))))
+		y += s.yDevice(anchor.sub(int(anchor.u16(8))))
+	}
+	return x, y
+}
+
+/*
+positionCursive connects the exit point of a glyph to the entry point of the next glyph (lookup type 3),
+such as for the joining glyphs of a script written in cursive.
+It returns the position of the next glyph.
+
+The glyphs' advances are adjusted so that the points meet horizontally.
+Vertically, one glyph is attached to the other; the last glyph is attached to the first,
+unless the lookup is for right-to-left text, in which case the first glyph is attached to the last.
+*/
+func (b *buffer) positionCursive(l *lookup, subtable table, i int, s *scaler) (int, bool) {
+	if subtable.u16(0) != 1 {
+		return 0, false
+	}
+	coverage := subtable.offset16(2)
+	count := int(subtable.u16(4))
+	c := coverage.coverage(b.glyphs[i].index)
+	if c < 0 || c >= count {
+		return 0, false
+	}
+	exit := subtable.sub(int(subtable.u16(6 + 4*c + 2)))
+	if exit == nil {
+		return 0, false
+	}
+	j := b.next(i, l)
+	if j < 0 {
+		return 0, false
+	}
+	c = coverage.coverage(b.glyphs[j].index)
+	if c < 0 || c >= count {
+		return 0, false
+	}
+	entry := subtable.sub(int(subtable.u16(6 + 4*c)))
+	if entry == nil {
+		return 0, false
+	}
+
+	exitX, exitY := s.anchor(exit)
+	entryX, entryY := s.anchor(entry)
+	first, last := &b.glyphs[i], &b.glyphs[j]
+	if b.rightToLeft {
+		d := exitX + first.xOffset
+		first.xAdvance -= d
+		first.xOffset -= d
+		last.xAdvance = entryX + last.xOffset
+	} else {
+		first.xAdvance = exitX + first.xOffset
+		d := entryX + last.xOffset
+		last.xAdvance -= d
+		last.xOffset -= d
+	}
+
+	child, parent := i, j
+	yOffset := entryY - exitY
+	if l.flag&lookupRightToLeft == 0 {
+		child, parent = j, i
+		yOffset = -yOffset
+	}
+	if b.glyphs[parent].attachChain == child-parent {
+		// Do not attach the glyphs to each other.
+		b.glyphs[parent].attachChain = 0
+	}
+	b.glyphs[child].attachType = attachCursive
+	b.glyphs[child].attachChain = parent - child
+	b.glyphs[child].yOffset = yOffset
+	return j, true
+}
+
+// markAnchor returns the class and anchor of a mark, from a mark array.
+func markAnchor(markArray table, markIndex int) (uint16, table) {
+	if markIndex >= int(markArray.u16(0)) {
+		return 0, nil
+	}
+	record := 2 + 4*markIndex
+	return markArray.u16(record), markArray.sub(int(markArray.u16(record + 2)))
+}
+
+// attachMark attaches the mark at position i to the glyph at position j, so that their anchors coincide.
+func (b *buffer) attachMark(i, j int, mark table, base table, s *scaler) bool {
+	if mark == nil || base == nil {
+		return false
+	}
+	markX, markY := s.anchor(mark)
+	baseX, baseY := s.anchor(base)
+	g := &b.glyphs[i]
+	g.xOffset = baseX - markX
+	g.yOffset = baseY - markY
+	g.attachType = attachMark
+	g.attachChain = j - i
+	return true
+}
+
+// previousBase returns the position of the glyph before position i that is not a mark, or -1.
+func (b *buffer) previousBase(i int) int {
+	for i--; i >= 0; i-- {
+		if b.glyphs[i].class != classMark {
+			return i
+		}
+	}
+	return -1
+}
+
+// baseAnchor returns an anchor, for a mark class, from the base array of a mark-to-base
+// or mark-to-mark subtable.
+func baseAnchor(baseArray table, baseIndex int, classCount int, class uint16) table {
+	if baseIndex >= int(baseArray.u16(0)) || int(class) >= classCount {
+		return nil
+	}
+	return baseArray.sub(int(baseArray.u16(2 + 2*(baseIndex*classCount+int(class)))))
+}
+
+// positionMarkToBase attaches a mark to the preceding base glyph (lookup type 4).
+func (b *buffer) positionMarkToBase(subtable table, i int, s *scaler) bool {
+	markIndex := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || markIndex < 0 {
+		return false
+	}
+	j := b.previousBase(i)
+	if j < 0 {
+		return false
+	}
+	baseIndex := subtable.offset16(4).coverage(b.glyphs[j].index)
+	if baseIndex < 0 {
+		return false
+	}
+
+	class, mark := markAnchor(subtable.offset16(8), markIndex)
+	base := baseAnchor(subtable.offset16(10), baseIndex, int(subtable.u16(6)), class)
+	return b.attachMark(i, j, mark, base, s)
+}
+
+/*
+positionMarkToLigature attaches a mark to a component of the preceding ligature (lookup type 5).
+
+The component is the one that the mark followed before the ligature was formed,
+or the last component for a mark that followed the ligature's glyphs.
+*/
+func (b *buffer) positionMarkToLigature(subtable table, i int, s *scaler) bool {
+	markIndex := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || markIndex < 0 {
+		return false
+	}
+	j := b.previousBase(i)
+	if j < 0 {
+		return false
+	}
+	ligatureIndex := subtable.offset16(4).coverage(b.glyphs[j].index)
+	ligatureArray := subtable.offset16(10)
+	if ligatureIndex < 0 || ligatureIndex >= int(ligatureArray.u16(0)) {
+		return false
+	}
+	ligature := ligatureArray.offset16(2 + 2*ligatureIndex)
+	componentCount := int(ligature.u16(0))
+	if componentCount == 0 {
+		return false
+	}
+
+	component := componentCount
+	if g := b.glyphs[i]; g.ligatureID != 0 && g.ligatureID == b.glyphs[j].ligatureID && g.component > 0 {
+		component = min(g.component, componentCount)
+	}
+	class, mark := markAnchor(subtable.offset16(8), markIndex)
+	base := baseAnchor(ligature, component-1, int(subtable.u16(6)), class)
+	return b.attachMark(i, j, mark, base, s)
+}
+
+// positionMarkToMark attaches a mark to the preceding mark (lookup type 6).
+func (b *buffer) positionMarkToMark(l *lookup, subtable table, i int, s *scaler) bool {
+	markIndex := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || markIndex < 0 {
+		return false
+	}
+	j := b.previous(i, l)
+	if j < 0 || b.glyphs[j].class != classMark {
+		return false
+	}
+
+	// The marks must be attached to the same base, or the same component of a ligature,
+	// unless one of them is itself a ligature.
+	id1, id2 := b.glyphs[i].ligatureID, b.glyphs[j].ligatureID
+	component1, component2 := b.glyphs[i].component, b.glyphs[j].component
+	if id1 == id2 {
+		if id1 != 0 && component1 != component2 {
+			return false
+		}
+	} else if !(id1 > 0 && component1 == 0) && !(id2 > 0 && component2 == 0) {
+		return false
+	}
+
+	mark2Index := subtable.offset16(4).coverage(b.glyphs[j].index)
+	if mark2Index < 0 {
+		return false
+	}
+	class, mark := markAnchor(subtable.offset16(8), markIndex)
+	base := baseAnchor(subtable.offset16(10), mark2Index, int(subtable.u16(6)), class)
+	return b.attachMark(i, j, mark, base, s)
+}
+
+// zeroMarkAdvances sets the advances of marks to zero, so that marks do not move the pen.
+func (b *buffer) zeroMarkAdvances() {
+	for i := range b.glyphs {
+		if b.glyphs[i].class == classMark {
+			b.glyphs[i].xAdvance = 0
+			b.glyphs[i].yAdvance = 0
+		}
+	}
+}
+
+// propagateAttachments makes the offsets of attached glyphs relative to the pen position,
+// rather than to the glyphs that they are attached to.
+func (b *buffer) propagateAttachments() {
+	for i := range b.glyphs {
+		b.propagateAttachment(i)
+	}
+}
+
+func (b *buffer) propagateAttachment(i int) {
+	g := &b.glyphs[i]
+	j := i + g.attachChain
+	if g.attachChain == 0 || j < 0 || j >= len(b.glyphs) {
+		g.attachChain = 0
+		return
+	}
+	// Resetting the chain first also breaks any cycle of attachments.
+	g.attachChain = 0
+	b.propagateAttachment(j)
+
+	parent := b.glyphs[j]
+	g.yOffset += parent.yOffset
+	if g.attachType == attachCursive {
+		return
+	}
+	g.xOffset += parent.xOffset
+	if j > i {
+		return
+	}
+	// Glyphs are drawn in reverse order in right-to-left text,
+	// so the pen advances from the mark to the glyph that it is attached to.
+	if b.rightToLeft {
+		for k := j + 1; k <= i; k++ {
+			g.xOffset += b.glyphs[k].xAdvance
+		}
+	} else {
+		for k := j; k < i; k++ {
+			g.xOffset -= b.glyphs[k].xAdvance
+		}
+	}
+}
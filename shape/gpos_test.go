@@ -0,0 +1,112 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+)
+
+// unitScaler returns a scaler that leaves values in font units.
+func unitScaler() *scaler {
+	return &scaler{xScale: 0x10000, yScale: 0x10000}
+}
+
+// i16 returns the 16-bit representation of a signed value, for makeTable.
+func i16(value int16) uint16 {
+	return uint16(value)
+}
+
+func TestPositionPair(t *testing.T) {
+	// Format 1: the glyph 5 followed by 6 or 7 has its advance reduced.
+	subtable := makeTable(1, 12, valueXAdvance, 0, 1, 18, 1, 1, 5, 2, 6, i16(-50), 7, i16(-20))
+	l := &lookup{kind: gposPair, flag: lookupIgnoreMarks}
+
+	b := makeBuffer([]glyphID{5, 10, 7, 8}, 10)
+	next, ok := b.positionPair(l, subtable, 0, unitScaler())
+	assert.True(t, ok)
+	assert.Equal(t, 2, next)
+	assert.Equal(t, freetype.Pos(-20), b.glyphs[0].xAdvance)
+
+	_, ok = b.positionPair(l, subtable, 2, unitScaler())
+	assert.False(t, ok)
+}
+
+func TestPositionMarkToBase(t *testing.T) {
+	// The mark 10 is attached to the base 5, with anchors at (100, 200) and (300, 500).
+	subtable := makeTable(1, 12, 18, 1, 24, 36, 1, 1, 10, 1, 1, 5, 1, 0, 6, 1, 100, 200, 1, 4, 1, 300, 500)
+
+	for _, rightToLeft := range []bool{false, true} {
+		b := makeBuffer([]glyphID{5, 10}, 10)
+		b.rightToLeft = rightToLeft
+		b.glyphs[0].xAdvance = 400
+		b.glyphs[1].xAdvance = 50
+		assert.True(t, b.positionMarkToBase(subtable, 1, unitScaler()))
+		b.zeroMarkAdvances()
+		b.propagateAttachments()
+
+		// The offset is from the pen position after the base, or before it for right-to-left text.
+		xOffset := freetype.Pos(-200)
+		if rightToLeft {
+			xOffset = 200
+		}
+		assert.Equal(t, xOffset, b.glyphs[1].xOffset)
+		assert.Equal(t, freetype.Pos(300), b.glyphs[1].yOffset)
+		assert.Equal(t, freetype.Pos(0), b.glyphs[1].xAdvance)
+	}
+
+	b := makeBuffer([]glyphID{6, 10}, 10)
+	assert.False(t, b.positionMarkToBase(subtable, 1, unitScaler()))
+}
+
+func TestPositionCursive(t *testing.T) {
+	// The exit of glyph 5 is at (500, 100), and the entry of glyph 6 is at (20, 0).
+	subtable := makeTable(1, 14, 2, 0, 22, 28, 0, 1, 2, 5, 6, 1, 500, 100, 1, 20, 0)
+	l := &lookup{kind: gposCursive}
+
+	b := makeBuffer([]glyphID{5, 6})
+	b.glyphs[0].xAdvance = 600
+	b.glyphs[1].xAdvance = 600
+	next, ok := b.positionCursive(l, subtable, 0, unitScaler())
+	assert.True(t, ok)
+	assert.Equal(t, 1, next)
+	b.propagateAttachments()
+
+	assert.Equal(t, freetype.Pos(500), b.glyphs[0].xAdvance)
+	assert.Equal(t, freetype.Pos(580), b.glyphs[1].xAdvance)
+	assert.Equal(t, freetype.Pos(-20), b.glyphs[1].xOffset)
+	assert.Equal(t, freetype.Pos(100), b.glyphs[1].yOffset)
+	assert.Equal(t, freetype.Pos(0), b.glyphs[0].yOffset)
+
+	// Glyph 6 has no exit.
+	_, ok = b.positionCursive(l, subtable, 1, unitScaler())
+	assert.False(t, ok)
+}
+
+func TestItemVariationStore(t *testing.T) {
+	// One region, peaking at the maximum of one axis, with a delta of 100.
+	store := itemVariationStore{data: makeTable(1, 0, 12, 1, 0, 22, 1, 1, 0, 16384, 16384, 1, 1, 1, 0, 100)}
+	assert.Equal(t, 1, store.axisCount())
+	assert.Equal(t, 0.0, store.delta(0, 0))
+
+	for coord, delta := range map[int]float64{0: 0, 8192: 50, 16384: 100, -8192: 0} {
+		store.coords = []int{coord}
+		assert.Equal(t, delta, store.delta(0, 0), coord)
+	}
+	assert.Equal(t, 0.0, store.delta(1, 0))
+	assert.Equal(t, 0.0, store.delta(0, 1))
+
+	store.coords = []int{16384}
+	s := &scaler{xScale: 0x20000, store: store}
+	assert.Equal(t, freetype.Pos(200), s.xDevice(makeTable(0, 0, deltaFormatVariationIndex)))
+}
+
+func TestDeviceTable(t *testing.T) {
+	// Deltas of 1, -1, and 2 pixels, at 10 to 12 pixels per em.
+	device := makeTable(10, 12, 2, 0x1F20)
+	for ppem, delta := range map[int]freetype.Pos{9: 0, 10: 64, 11: -64, 12: 128, 13: 0} {
+		s := &scaler{xPpem: ppem}
+		assert.Equal(t, delta, s.xDevice(device), ppem)
+	}
+}
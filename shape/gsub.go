@@ -0,0 +1,236 @@
+package shape
+
+// The glyph substitution (GSUB) table.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/gsub
+
+// GSUB lookup types.
+const (
+	gsubSingle             = 1
+	gsubMultiple           = 2
+	gsubAlternate          = 3
+	gsubLigature           = 4
+	gsubContext            = 5
+	gsubChainedContext     = 6
+	gsubExtension          = 7
+	gsubReverseChainSingle = 8
+)
+
+// maxNesting limits the depth of the lookups applied by contextual lookups.
+const maxNesting = 64
+
+type gsub struct {
+	*layoutTable
+}
+
+// apply applies the lookups of a plan to a buffer, in order.
+func (g gsub) apply(b *buffer, plan []lookupPlan) {
+	for _, p := range plan {
+		l := &g.lookups[p.index]
+
+		if l.kind == gsubReverseChainSingle {
+			for i := len(b.glyphs) - 1; i >= 0; i-- {
+				if b.glyphs[i].mask&p.mask != 0 && !b.ignored(i, l) {
+					g.applyLookup(b, l, i, p, 0)
+				}
+			}
+			continue
+		}
+
+		for i := 0; i < len(b.glyphs); {
+			if b.glyphs[i].mask&p.mask != 0 && !b.ignored(i, l) {
+				if next, ok := g.applyLookup(b, l, i, p, 0); ok {
+					i = next
+					continue
+				}
+			}
+			i++
+		}
+	}
+}
+
+// applyLookup applies the first of a lookup's subtables that applies at position i.
+// It returns the position following the glyphs that were substituted.
+func (g gsub) applyLookup(b *buffer, l *lookup, i int, p lookupPlan, depth int) (int, bool) {
+	nested := func(lookupIndex int, position int) {
+		if depth < maxNesting && lookupIndex < len(g.lookups) && position < len(b.glyphs) {
+			g.applyLookup(b, &g.lookups[lookupIndex], position, p, depth+1)
+		}
+	}
+
+	for _, subtable := range l.subtables {
+		var next int
+		var ok bool
+		switch l.kind {
+		case gsubSingle:
+			next, ok = i+1, b.substituteSingle(subtable, i)
+		case gsubMultiple:
+			next, ok = b.substituteMultiple(subtable, i)
+		case gsubAlternate:
+			next, ok = i+1, b.substituteAlternate(subtable, i, p.value)
+		case gsubLigature:
+			next, ok = i+1, b.substituteLigature(l, subtable, i, p.mask)
+		case gsubContext:
+			next, ok = b.applyContext(l, subtable, i, nested)
+		case gsubChainedContext:
+			next, ok = b.applyChainedContext(l, subtable, i, nested)
+		case gsubReverseChainSingle:
+			next, ok = i, b.substituteReverseChainSingle(l, subtable, i)
+		}
+		if ok {
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+// substituteSingle replaces a glyph with another glyph (lookup type 1).
+func (b *buffer) substituteSingle(subtable table, i int) bool {
+	glyph := b.glyphs[i].index
+	c := subtable.offset16(2).coverage(glyph)
+	if c < 0 {
+		return false
+	}
+
+	switch subtable.u16(0) {
+	case 1:
+		// The addition is modulo 65536.
+		b.setGlyph(i, glyph+glyphID(subtable.i16(4)))
+		return true
+	case 2:
+		if c >= int(subtable.u16(4)) {
+			return false
+		}
+		b.setGlyph(i, subtable.u16(6+2*c))
+		return true
+	}
+	return false
+}
+
+// substituteMultiple replaces a glyph with a sequence of glyphs (lookup type 2).
+// It returns the position following the sequence.
+func (b *buffer) substituteMultiple(subtable table, i int) (int, bool) {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || c < 0 || c >= int(subtable.u16(4)) {
+		return 0, false
+	}
+	sequence := subtable.offset16(6 + 2*c)
+	count := int(sequence.u16(0))
+	if sequence == nil || len(b.glyphs)+count-1 > b.maxLength {
+		return 0, false
+	}
+
+	glyphs := make([]glyphID, count)
+	for k := range glyphs {
+		glyphs[k] = sequence.u16(2 + 2*k)
+	}
+	b.replace(i, glyphs)
+	return i + count, true
+}
+
+// substituteAlternate replaces a glyph with one of a set of alternates (lookup type 3).
+// The alternate is selected with a feature value, where 1 is the first alternate.
+func (b *buffer) substituteAlternate(subtable table, i int, value uint32) bool {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || c < 0 || c >= int(subtable.u16(4)) {
+		return false
+	}
+	alternates := subtable.offset16(6 + 2*c)
+	if value < 1 || value > uint32(alternates.u16(0)) {
+		return false
+	}
+	b.setGlyph(i, alternates.u16(2+2*int(value-1)))
+	return true
+}
+
+// substituteLigature replaces a sequence of glyphs with a ligature (lookup type 4).
+func (b *buffer) substituteLigature(l *lookup, subtable table, i int, mask uint32) bool {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || c < 0 || c >= int(subtable.u16(4)) {
+		return false
+	}
+
+	ligatures := subtable.offset16(6 + 2*c)
+	for k := range int(ligatures.u16(0)) {
+		ligature := ligatures.offset16(2 + 2*k)
+		count := int(ligature.u16(2))
+		if count < 1 {
+			continue
+		}
+		positions, ok := b.matchInput(i, l, mask, count, func(k int, glyph glyphID) bool {
+			return ligature.u16(4+2*(k-1)) == glyph
+		})
+		if ok {
+			b.ligate(positions, ligature.u16(0))
+			return true
+		}
+	}
+	return false
+}
+
+// ligate replaces the glyphs at a sequence of positions with a ligature.
+// Any marks between the positions, or that immediately follow them, are kept after the ligature,
+// and record the ligature component that they are attached to.
+func (b *buffer) ligate(positions []int, ligature glyphID) {
+	first, last := positions[0], positions[len(positions)-1]
+	for last+1 < len(b.glyphs) && b.glyphs[last+1].class == classMark && b.glyphs[last+1].ligatureID == 0 {
+		last++
+	}
+	b.mergeClusters(first, last+1)
+
+	b.nextLigatureID++
+	id := b.nextLigatureID
+	component := 0
+	p := 0
+	for i := first; i <= last; i++ {
+		if p < len(positions) && positions[p] == i {
+			component++
+			p++
+			continue
+		}
+		if b.glyphs[i].class == classMark {
+			b.glyphs[i].ligatureID = id
+			b.glyphs[i].component = component
+		}
+	}
+
+	b.setGlyph(first, ligature)
+	if b.gdef.glyphClasses == nil {
+		b.glyphs[first].class = classLigature
+	}
+	b.glyphs[first].ligatureID = id
+	b.glyphs[first].component = len(positions)
+
+	for k := len(positions) - 1; k >= 1; k-- {
+		b.glyphs = append(b.glyphs[:positions[k]], b.glyphs[positions[k]+1:]...)
+	}
+}
+
+// substituteReverseChainSingle replaces a glyph with another glyph, in a context (lookup type 8).
+func (b *buffer) substituteReverseChainSingle(l *lookup, subtable table, i int) bool {
+	c := subtable.offset16(2).coverage(b.glyphs[i].index)
+	if subtable.u16(0) != 1 || c < 0 {
+		return false
+	}
+
+	backtrackCount := int(subtable.u16(4))
+	lookaheadOffset := 6 + 2*backtrackCount
+	lookaheadCount := int(subtable.u16(lookaheadOffset))
+	substitutesOffset := lookaheadOffset + 2 + 2*lookaheadCount
+	if c >= int(subtable.u16(substitutesOffset)) {
+		return false
+	}
+
+	matches := func(offset int) matcher {
+		return func(k int, glyph glyphID) bool {
+			return subtable.offset16(offset+2*k).coverage(glyph) >= 0
+		}
+	}
+	if !b.matchBacktrack(i, l, backtrackCount, matches(6)) ||
+		!b.matchLookahead(i, l, lookaheadCount, matches(lookaheadOffset+2)) {
+		return false
+	}
+
+	b.setGlyph(i, subtable.u16(substitutesOffset+2+2*c))
+	return true
+}
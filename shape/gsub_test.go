@@ -0,0 +1,148 @@
+package shape
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeTable returns a table of 16-bit values.
+func makeTable(values ...uint16) table {
+	t := make(table, 2*len(values))
+	for i, value := range values {
+		binary.BigEndian.PutUint16(t[2*i:], value)
+	}
+	return t
+}
+
+// makeBuffer returns a buffer of glyphs, in which the glyphs in marks are marks.
+func makeBuffer(glyphs []glyphID, marks ...glyphID) *buffer {
+	b := &buffer{maxLength: 100}
+	for i, glyph := range glyphs {
+		info := glyphInfo{index: glyph, cluster: i, mask: globalMask}
+		for _, mark := range marks {
+			if glyph == mark {
+				info.class = classMark
+			}
+		}
+		b.glyphs = append(b.glyphs, info)
+	}
+	return b
+}
+
+func bufferGlyphs(b *buffer) []glyphID {
+	var glyphs []glyphID
+	for _, glyph := range b.glyphs {
+		glyphs = append(glyphs, glyph.index)
+	}
+	return glyphs
+}
+
+func TestCoverageAndClass(t *testing.T) {
+	coverage := makeTable(1, 3, 2, 5, 9)
+	assert.Equal(t, 1, coverage.coverage(5))
+	assert.Equal(t, -1, coverage.coverage(4))
+
+	coverage = makeTable(2, 2, 10, 12, 0, 20, 20, 3)
+	assert.Equal(t, 2, coverage.coverage(12))
+	assert.Equal(t, 3, coverage.coverage(20))
+	assert.Equal(t, -1, coverage.coverage(13))
+
+	classDef := makeTable(1, 4, 2, 7, 8)
+	assert.Equal(t, uint16(8), classDef.class(5))
+	assert.Equal(t, uint16(0), classDef.class(6))
+
+	classDef = makeTable(2, 1, 10, 19, 3)
+	assert.Equal(t, uint16(3), classDef.class(15))
+	assert.Equal(t, uint16(0), classDef.class(20))
+
+	// Malformed tables do not panic.
+	assert.Equal(t, -1, makeTable(1, 100).coverage(5))
+	assert.Equal(t, uint16(0), makeTable(2, 100).class(5))
+}
+
+func TestSubstituteMultiple(t *testing.T) {
+	subtable := makeTable(1, 8, 1, 14, 1, 1, 5, 2, 7, 8)
+	b := makeBuffer([]glyphID{5, 9})
+
+	next, ok := b.substituteMultiple(subtable, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 2, next)
+	assert.Equal(t, []glyphID{7, 8, 9}, bufferGlyphs(b))
+	assert.Equal(t, []int{0, 0, 1}, []int{b.glyphs[0].cluster, b.glyphs[1].cluster, b.glyphs[2].cluster})
+
+	_, ok = b.substituteMultiple(subtable, 2)
+	assert.False(t, ok)
+}
+
+func TestSubstituteLigatureSkipsMarks(t *testing.T) {
+	subtable := makeTable(1, 8, 1, 14, 1, 1, 5, 1, 4, 50, 2, 6)
+	l := &lookup{kind: gsubLigature, flag: lookupIgnoreMarks}
+	b := makeBuffer([]glyphID{5, 10, 6, 11}, 10, 11)
+
+	assert.True(t, b.substituteLigature(l, subtable, 0, globalMask))
+	assert.Equal(t, []glyphID{50, 10, 11}, bufferGlyphs(b))
+	assert.Equal(t, []int{0, 0, 0}, []int{b.glyphs[0].cluster, b.glyphs[1].cluster, b.glyphs[2].cluster})
+	assert.Equal(t, classLigature, int(b.glyphs[0].class))
+	// The marks are attached to the ligature's components.
+	assert.Equal(t, 2, b.glyphs[0].component)
+	assert.Equal(t, []int{1, 1}, []int{b.glyphs[1].ligatureID, b.glyphs[1].component})
+	assert.Equal(t, []int{1, 2}, []int{b.glyphs[2].ligatureID, b.glyphs[2].component})
+
+	// Marks are not skipped without the lookup flag.
+	b = makeBuffer([]glyphID{5, 10, 6}, 10)
+	assert.False(t, b.substituteLigature(&lookup{kind: gsubLigature}, subtable, 0, globalMask))
+}
+
+func TestApplyContext(t *testing.T) {
+	// Format 3: the glyphs 5 and 6, with lookup 7 applied to the second of them.
+	subtable := makeTable(3, 2, 1, 14, 20, 1, 7, 1, 1, 5, 1, 1, 6)
+	l := &lookup{kind: gsubContext, flag: lookupIgnoreMarks}
+
+	var applied [][2]int
+	nested := func(lookupIndex int, position int) {
+		applied = append(applied, [2]int{lookupIndex, position})
+	}
+
+	b := makeBuffer([]glyphID{5, 10, 6, 5}, 10)
+	next, ok := b.applyContext(l, subtable, 0, nested)
+	assert.True(t, ok)
+	assert.Equal(t, 3, next)
+	assert.Equal(t, [][2]int{{7, 2}}, applied)
+
+	_, ok = b.applyContext(l, subtable, 3, nested)
+	assert.False(t, ok)
+}
+
+func TestApplyChainedContext(t *testing.T) {
+	// Format 1: the glyph 5 after 4 and before 6, with lookup 2 applied to it.
+	subtable := makeTable(1, 8, 1, 14, 1, 1, 5, 1, 4, 1, 4, 1, 1, 6, 1, 0, 2)
+	l := &lookup{kind: gsubChainedContext}
+
+	var applied [][2]int
+	nested := func(lookupIndex int, position int) {
+		applied = append(applied, [2]int{lookupIndex, position})
+	}
+
+	b := makeBuffer([]glyphID{4, 5, 6})
+	next, ok := b.applyChainedContext(l, subtable, 1, nested)
+	assert.True(t, ok)
+	assert.Equal(t, 2, next)
+	assert.Equal(t, [][2]int{{2, 1}}, applied)
+
+	b = makeBuffer([]glyphID{3, 5, 6})
+	_, ok = b.applyChainedContext(l, subtable, 1, nested)
+	assert.False(t, ok)
+}
+
+func TestSubstituteReverseChainSingle(t *testing.T) {
+	// The glyph 5 after the glyph 3 is replaced with 99.
+	subtable := makeTable(1, 14, 1, 20, 0, 1, 99, 1, 1, 5, 1, 1, 3)
+	l := &lookup{kind: gsubReverseChainSingle}
+
+	b := makeBuffer([]glyphID{3, 5, 5})
+	assert.True(t, b.substituteReverseChainSingle(l, subtable, 1))
+	assert.False(t, b.substituteReverseChainSingle(l, subtable, 2))
+	assert.Equal(t, []glyphID{3, 99, 5}, bufferGlyphs(b))
+}
@@ -0,0 +1,325 @@
+package shape
+
+import (
+	"sort"
+
+	"github.com/pekim/freetype"
+)
+
+// Shaping of Indic scripts, in which the characters of a syllable are reordered,
+// and consonants take forms that depend on their position in the syllable.
+//
+// https://learn.microsoft.com/en-us/typography/script-development/devanagari
+// https://learn.microsoft.com/en-us/typography/script-development/bengali
+
+// indicCategory is the category of a character in a syllable.
+type indicCategory uint8
+
+const (
+	indicOther indicCategory = iota
+	indicConsonant
+	indicVowel
+	indicNukta
+	indicHalant
+	indicMatra
+	// indicModifier is the category of syllable modifiers, such as anusvara and visarga.
+	indicModifier
+	indicZWJ
+	indicZWNJ
+)
+
+// indicPosition is the position of a dependent vowel sign (matra), relative to its consonant.
+type indicPosition uint8
+
+const (
+	positionNone indicPosition = iota
+	positionPre
+	positionAbove
+	positionBelow
+	positionPost
+)
+
+type indicRange struct {
+	lo, hi   rune
+	category indicCategory
+	position indicPosition
+}
+
+// indicScript is the data for an Indic script.
+type indicScript struct {
+	ra     rune
+	ranges []indicRange
+	// decompositions are the two-part matras, that are decomposed in to their parts.
+	decompositions map[rune][]rune
+}
+
+var devanagari = indicScript{
+	ra: 0x0930,
+	ranges: []indicRange{
+		{0x0900, 0x0903, indicModifier, positionNone},
+		{0x0904, 0x0914, indicVowel, positionNone},
+		{0x0915, 0x0939, indicConsonant, positionNone},
+		{0x093A, 0x093A, indicMatra, positionAbove},
+		{0x093B, 0x093B, indicMatra, positionPost},
+		{0x093C, 0x093C, indicNukta, positionNone},
+		{0x093E, 0x093E, indicMatra, positionPost},
+		{0x093F, 0x093F, indicMatra, positionPre},
+		{0x0940, 0x0940, indicMatra, positionPost},
+		{0x0941, 0x0944, indicMatra, positionBelow},
+		{0x0945, 0x0948, indicMatra, positionAbove},
+		{0x0949, 0x094C, indicMatra, positionPost},
+		{0x094D, 0x094D, indicHalant, positionNone},
+		{0x094E, 0x094E, indicMatra, positionPre},
+		{0x094F, 0x094F, indicMatra, positionPost},
+		{0x0951, 0x0954, indicModifier, positionNone},
+		{0x0955, 0x0955, indicMatra, positionAbove},
+		{0x0956, 0x0957, indicMatra, positionBelow},
+		{0x0958, 0x095F, indicConsonant, positionNone},
+		{0x0960, 0x0961, indicVowel, positionNone},
+		{0x0962, 0x0963, indicMatra, positionBelow},
+		{0x0972, 0x0977, indicVowel, positionNone},
+		{0x0978, 0x097F, indicConsonant, positionNone},
+	},
+}
+
+var bengali = indicScript{
+	ra: 0x09B0,
+	ranges: []indicRange{
+		{0x0981, 0x0983, indicModifier, positionNone},
+		{0x0985, 0x0994, indicVowel, positionNone},
+		{0x0995, 0x09B9, indicConsonant, positionNone},
+		{0x09BC, 0x09BC, indicNukta, positionNone},
+		{0x09BE, 0x09BE, indicMatra, positionPost},
+		{0x09BF, 0x09BF, indicMatra, positionPre},
+		{0x09C0, 0x09C0, indicMatra, positionPost},
+		{0x09C1, 0x09C4, indicMatra, positionBelow},
+		{0x09C7, 0x09C8, indicMatra, positionPre},
+		{0x09CD, 0x09CD, indicHalant, positionNone},
+		{0x09CE, 0x09CE, indicConsonant, positionNone},
+		{0x09D7, 0x09D7, indicMatra, positionPost},
+		{0x09DC, 0x09DF, indicConsonant, positionNone},
+		{0x09E0, 0x09E1, indicVowel, positionNone},
+		{0x09E2, 0x09E3, indicMatra, positionBelow},
+		{0x09F0, 0x09F1, indicConsonant, positionNone},
+	},
+	decompositions: map[rune][]rune{
+		0x09CB: {0x09C7, 0x09BE},
+		0x09CC: {0x09C7, 0x09D7},
+	},
+}
+
+// category returns the category of a character, and its position if it is a matra.
+func (s *indicScript) category(r rune) (indicCategory, indicPosition) {
+	switch r {
+	case 0x200C:
+		return indicZWNJ, positionNone
+	case 0x200D:
+		return indicZWJ, positionNone
+	}
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].hi >= r })
+	if i < len(s.ranges) && s.ranges[i].lo <= r {
+		return s.ranges[i].category, s.ranges[i].position
+	}
+	return indicOther, positionNone
+}
+
+// Masks of the features that apply to parts of a syllable.
+const (
+	rphfMask = 1 << (iota + 1)
+	halfMask
+	// postBaseMask is the mask of the features for the forms of consonants that follow the base consonant.
+	postBaseMask
+)
+
+type indicShaper struct {
+	script *indicScript
+}
+
+func (s indicShaper) decompose(r rune) []rune {
+	return s.script.decompositions[r]
+}
+
+/*
+syllable returns the end of the syllable that starts at position start, and whether it is a consonant syllable.
+
+A consonant syllable is a sequence of consonants, each with an optional nukta, that are joined by halants,
+followed by an optional halant, or by matras and syllable modifiers.
+*/
+func (s indicShaper) syllable(b *buffer, start int) (int, bool) {
+	i := start
+	category := func() indicCategory {
+		if i >= len(b.glyphs) {
+			return indicOther
+		}
+		c, _ := s.script.category(b.glyphs[i].char)
+		return c
+	}
+	skip := func(c indicCategory) bool {
+		if category() == c {
+			i++
+			return true
+		}
+		return false
+	}
+
+	switch category() {
+	case indicConsonant:
+		for skip(indicConsonant) {
+			skip(indicNukta)
+			if !skip(indicHalant) {
+				break
+			}
+			if !skip(indicZWJ) {
+				skip(indicZWNJ)
+			}
+		}
+		if c := category(); c == indicMatra || c == indicModifier {
+			for skip(indicMatra) || skip(indicNukta) {
+			}
+			skip(indicHalant)
+			for skip(indicModifier) {
+			}
+		}
+		return i, true
+
+	case indicVowel:
+		i++
+		skip(indicNukta)
+		skip(indicZWJ)
+		for skip(indicMatra) || skip(indicNukta) {
+		}
+		for skip(indicModifier) {
+		}
+		return i, false
+	}
+	return start + 1, false
+}
+
+/*
+setup finds the syllables of the text, and for each consonant syllable finds its base consonant
+and assigns masks to the consonants before and after it.
+
+An initial ra and halant, that is followed by another consonant, forms a reph,
+that is moved after the base consonant by reorderReph.
+Matras that are written before the base consonant are moved to the start of the syllable, after any reph.
+*/
+func (s indicShaper) setup(b *buffer) {
+	syllable := 0
+	for start := 0; start < len(b.glyphs); {
+		end, consonants := s.syllable(b, start)
+		syllable++
+		for i := start; i < end; i++ {
+			b.glyphs[i].syllable = syllable
+		}
+		if consonants {
+			s.setupSyllable(b, start, end)
+		}
+		start = end
+	}
+}
+
+func (s indicShaper) setupSyllable(b *buffer, start, end int) {
+	category := func(i int) indicCategory {
+		c, _ := s.script.category(b.glyphs[i].char)
+		return c
+	}
+
+	// The reph is not a candidate for the base consonant.
+	first := start
+	if end-start >= 3 && b.glyphs[start].char == s.script.ra && category(start+1) == indicHalant &&
+		category(start+2) == indicConsonant {
+		first = start + 2
+		b.glyphs[start].mask |= rphfMask
+		b.glyphs[start+1].mask |= rphfMask
+	}
+
+	base := -1
+	for i := first; i < end; i++ {
+		if category(i) == indicConsonant {
+			base = i
+		}
+	}
+	if base < 0 {
+		return
+	}
+	// A final ra that follows a halant takes a form below the preceding consonant, which is the base.
+	if b.glyphs[base].char == s.script.ra && base-1 > first && category(base-1) == indicHalant {
+		for i := base - 2; i >= first; i-- {
+			if category(i) == indicConsonant {
+				base = i
+				break
+			}
+		}
+	}
+
+	for i := first; i < base; i++ {
+		b.glyphs[i].mask |= halfMask
+	}
+	for i := base + 1; i < end; i++ {
+		b.glyphs[i].mask |= postBaseMask
+	}
+
+	// Move pre-base matras to the start of the syllable, keeping their order.
+	var pre, rest []glyphInfo
+	for _, glyph := range b.glyphs[first:end] {
+		if _, position := s.script.category(glyph.char); position == positionPre {
+			pre = append(pre, glyph)
+		} else {
+			rest = append(rest, glyph)
+		}
+	}
+	if len(pre) > 0 || first != start {
+		copy(b.glyphs[first:], append(pre, rest...))
+		b.mergeClusters(start, end)
+	}
+}
+
+// reorderReph moves each reph, that was formed by the 'rphf' feature, after the base consonant of its syllable,
+// before any matras that follow the base and the syllable modifiers.
+func (s indicShaper) reorderReph(b *buffer) {
+	for start := 0; start < len(b.glyphs); {
+		end := start + 1
+		for end < len(b.glyphs) && b.glyphs[end].syllable == b.glyphs[start].syllable {
+			end++
+		}
+
+		// The ra and halant of a reph are ligated, so only the first glyph of the syllable has the mask.
+		if end-start >= 2 && b.glyphs[start].mask&rphfMask != 0 && b.glyphs[start+1].mask&rphfMask == 0 {
+			target := end
+			for i := start + 1; i < end; i++ {
+				category, position := s.script.category(b.glyphs[i].char)
+				if (category == indicMatra && position == positionPost) || category == indicModifier {
+					target = i
+					break
+				}
+			}
+			reph := b.glyphs[start]
+			copy(b.glyphs[start:target-1], b.glyphs[start+1:target])
+			b.glyphs[target-1] = reph
+		}
+		start = end
+	}
+}
+
+// stages returns the features for the forms of consonants, each in its own stage so that they are applied in order,
+// followed by the reordering of rephs, and the features for the presentation forms of the syllables.
+func (s indicShaper) stages() []stage {
+	stages := []stage{{features: []featureMask{
+		{tag: freetype.MakeTag("locl"), mask: globalMask, value: 1},
+		{tag: freetype.MakeTag("ccmp"), mask: globalMask, value: 1},
+	}}}
+	stages = append(stages, stageFeatures(globalMask, "nukt", "akhn")...)
+	stages = append(stages, stageFeatures(rphfMask, "rphf")...)
+	stages = append(stages, stageFeatures(globalMask, "rkrf")...)
+	stages = append(stages, stageFeatures(postBaseMask, "pref", "blwf", "abvf")...)
+	stages = append(stages, stageFeatures(halfMask, "half")...)
+	stages = append(stages, stageFeatures(postBaseMask, "pstf")...)
+	stages = append(stages, stageFeatures(globalMask, "vatu", "cjct")...)
+	stages[len(stages)-1].pause = s.reorderReph
+
+	var presentation stage
+	for _, tag := range []string{"pres", "abvs", "blws", "psts", "haln"} {
+		presentation.features = append(presentation.features, featureMask{tag: freetype.MakeTag(tag), mask: globalMask, value: 1})
+	}
+	return append(stages, presentation)
+}
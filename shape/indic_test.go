@@ -0,0 +1,111 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype/internal/font"
+)
+
+// charBuffer returns a buffer of the characters of text, without their glyphs.
+func charBuffer(text string) *buffer {
+	b := &buffer{}
+	for cluster, r := range text {
+		b.glyphs = append(b.glyphs, glyphInfo{char: r, cluster: cluster, mask: globalMask})
+	}
+	return b
+}
+
+func bufferChars(b *buffer) string {
+	var chars []rune
+	for _, glyph := range b.glyphs {
+		chars = append(chars, glyph.char)
+	}
+	return string(chars)
+}
+
+func TestIndicSyllables(t *testing.T) {
+	s := indicShaper{script: &devanagari}
+	b := charBuffer("क्षत्रिय अं")
+
+	var ends []int
+	var consonants []bool
+	for start := 0; start < len(b.glyphs); {
+		end, c := s.syllable(b, start)
+		ends = append(ends, end)
+		consonants = append(consonants, c)
+		start = end
+	}
+	assert.Equal(t, []int{3, 7, 8, 9, 11}, ends)
+	assert.Equal(t, []bool{true, true, true, false, false}, consonants)
+}
+
+func TestIndicSetup(t *testing.T) {
+	s := indicShaper{script: &devanagari}
+
+	// The i matra moves before the consonant.
+	b := charBuffer("कि")
+	s.setup(b)
+	assert.Equal(t, "िक", bufferChars(b))
+	assert.Equal(t, []int{0, 0}, []int{b.glyphs[0].cluster, b.glyphs[1].cluster})
+
+	// The consonants before the base take half forms, and a final ra takes a form below the base.
+	b = charBuffer("स्क्र")
+	s.setup(b)
+	masks := make([]uint32, len(b.glyphs))
+	for i, glyph := range b.glyphs {
+		masks[i] = glyph.mask &^ globalMask
+	}
+	assert.Equal(t, []uint32{halfMask, halfMask, 0, postBaseMask, postBaseMask}, masks)
+
+	// The ra and halant of a reph stay at the start, before the pre-base matra.
+	b = charBuffer("र्कि")
+	s.setup(b)
+	assert.Equal(t, "र्िक", bufferChars(b))
+	assert.Equal(t, uint32(rphfMask), b.glyphs[0].mask&rphfMask)
+	assert.Equal(t, uint32(rphfMask), b.glyphs[1].mask&rphfMask)
+	assert.Equal(t, uint32(0), b.glyphs[2].mask&rphfMask)
+}
+
+func TestIndicReorderReph(t *testing.T) {
+	s := indicShaper{script: &devanagari}
+
+	for text, expected := range map[string]string{
+		"र्क":  "कर",
+		"र्कि": "िकर",
+		"र्का": "करा",
+		"र्कं": "करं",
+	} {
+		b := charBuffer(text)
+		s.setup(b)
+		// The 'rphf' feature ligates the ra and halant.
+		b.ligate([]int{0, 1}, 1)
+		s.reorderReph(b)
+		assert.Equal(t, expected, bufferChars(b), text)
+	}
+
+	// Without a reph ligature, the ra and halant are not moved.
+	b := charBuffer("र्क")
+	s.setup(b)
+	s.reorderReph(b)
+	assert.Equal(t, "र्क", bufferChars(b))
+}
+
+func TestShapeBengaliDecomposition(t *testing.T) {
+	shaper, _ := newShaper(t, font.DejaVuSans)
+
+	// The two-part o matra is decomposed, and its first part moves before the consonant.
+	glyphs := shape(t, shaper, "কো")
+	assert.Len(t, glyphs, 3)
+	for _, glyph := range glyphs {
+		assert.Equal(t, 0, glyph.Cluster)
+	}
+
+	s := indicShaper{script: &bengali}
+	assert.Equal(t, []rune{0x09C7, 0x09BE}, s.decompose('ো'))
+	assert.Nil(t, s.decompose('ক'))
+	b := &buffer{glyphs: []glyphInfo{{char: 'ক'}, {char: 0x09C7}, {char: 0x09BE}}}
+	s.setup(b)
+	assert.Equal(t, "েকা", bufferChars(b))
+}
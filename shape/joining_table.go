@@ -0,0 +1,191 @@
+// Code generated by gen_joining.go from https://www.unicode.org/Public/15.0.0/ucd/ArabicShaping.txt. DO NOT EDIT.
+
+package shape
+
+// joiningRanges are the joining types of characters, from the Unicode Character Database.
+// Characters that are not in the table are transparent if they are marks or format characters,
+// and otherwise do not join.
+// It is sorted, and its ranges do not overlap.
+var joiningRanges = []joiningRange{
+	{0x0600, 0x0605, joiningNone},
+	{0x0608, 0x0608, joiningNone},
+	{0x060B, 0x060B, joiningNone},
+	{0x0620, 0x0620, joiningDual},
+	{0x0621, 0x0621, joiningNone},
+	{0x0622, 0x0625, joiningRight},
+	{0x0626, 0x0626, joiningDual},
+	{0x0627, 0x0627, joiningRight},
+	{0x0628, 0x0628, joiningDual},
+	{0x0629, 0x0629, joiningRight},
+	{0x062A, 0x062E, joiningDual},
+	{0x062F, 0x0632, joiningRight},
+	{0x0633, 0x063F, joiningDual},
+	{0x0640, 0x0640, joiningCausing},
+	{0x0641, 0x0647, joiningDual},
+	{0x0648, 0x0648, joiningRight},
+	{0x0649, 0x064A, joiningDual},
+	{0x066E, 0x066F, joiningDual},
+	{0x0671, 0x0673, joiningRight},
+	{0x0674, 0x0674, joiningNone},
+	{0x0675, 0x0677, joiningRight},
+	{0x0678, 0x0687, joiningDual},
+	{0x0688, 0x0699, joiningRight},
+	{0x069A, 0x06BF, joiningDual},
+	{0x06C0, 0x06C0, joiningRight},
+	{0x06C1, 0x06C2, joiningDual},
+	{0x06C3, 0x06CB, joiningRight},
+	{0x06CC, 0x06CC, joiningDual},
+	{0x06CD, 0x06CD, joiningRight},
+	{0x06CE, 0x06CE, joiningDual},
+	{0x06CF, 0x06CF, joiningRight},
+	{0x06D0, 0x06D1, joiningDual},
+	{0x06D2, 0x06D3, joiningRight},
+	{0x06D5, 0x06D5, joiningRight},
+	{0x06DD, 0x06DD, joiningNone},
+	{0x06EE, 0x06EF, joiningRight},
+	{0x06FA, 0x06FC, joiningDual},
+	{0x06FF, 0x06FF, joiningDual},
+	{0x070F, 0x070F, joiningTransparent},
+	{0x0712, 0x0714, joiningDual},
+	{0x0717, 0x0719, joiningRight},
+	{0x071A, 0x071D, joiningDual},
+	{0x071E, 0x071E, joiningRight},
+	{0x071F, 0x0727, joiningDual},
+	{0x0728, 0x0728, joiningRight},
+	{0x0729, 0x0729, joiningDual},
+	{0x072B, 0x072B, joiningDual},
+	{0x072C, 0x072C, joiningRight},
+	{0x072D, 0x072E, joiningDual},
+	{0x074D, 0x074D, joiningRight},
+	{0x074E, 0x0758, joiningDual},
+	{0x0759, 0x075B, joiningRight},
+	{0x075C, 0x076A, joiningDual},
+	{0x076B, 0x076C, joiningRight},
+	{0x076D, 0x0770, joiningDual},
+	{0x0771, 0x0771, joiningRight},
+	{0x0772, 0x0772, joiningDual},
+	{0x0773, 0x0774, joiningRight},
+	{0x0775, 0x0777, joiningDual},
+	{0x0778, 0x0779, joiningRight},
+	{0x077A, 0x077F, joiningDual},
+	{0x07CA, 0x07EA, joiningDual},
+	{0x07FA, 0x07FA, joiningCausing},
+	{0x0840, 0x0840, joiningRight},
+	{0x0841, 0x0845, joiningDual},
+	{0x0846, 0x0847, joiningRight},
+	{0x0848, 0x0848, joiningDual},
+	{0x0849, 0x0849, joiningRight},
+	{0x084A, 0x0853, joiningDual},
+	{0x0854, 0x0854, joiningRight},
+	{0x0855, 0x0855, joiningDual},
+	{0x0856, 0x0858, joiningRight},
+	{0x0860, 0x0860, joiningDual},
+	{0x0861, 0x0861, joiningNone},
+	{0x0862, 0x0865, joiningDual},
+	{0x0866, 0x0866, joiningNone},
+	{0x0867, 0x0867, joiningRight},
+	{0x0868, 0x0868, joiningDual},
+	{0x0869, 0x086A, joiningRight},
+	{0x0870, 0x0882, joiningRight},
+	{0x0883, 0x0885, joiningCausing},
+	{0x0886, 0x0886, joiningDual},
+	{0x0887, 0x0888, joiningNone},
+	{0x0889, 0x088D, joiningDual},
+	{0x088E, 0x088E, joiningRight},
+	{0x0890, 0x0891, joiningNone},
+	{0x08A0, 0x08A9, joiningDual},
+	{0x08AA, 0x08AC, joiningRight},
+	{0x08AD, 0x08AD, joiningNone},
+	{0x08AE, 0x08AE, joiningRight},
+	{0x08AF, 0x08B0, joiningDual},
+	{0x08B1, 0x08B2, joiningRight},
+	{0x08B3, 0x08B8, joiningDual},
+	{0x08B9, 0x08B9, joiningRight},
+	{0x08BA, 0x08C8, joiningDual},
+	{0x08E2, 0x08E2, joiningNone},
+	{0x1806, 0x1806, joiningNone},
+	{0x1807, 0x1807, joiningDual},
+	{0x180A, 0x180A, joiningCausing},
+	{0x180E, 0x180E, joiningNone},
+	{0x1820, 0x1878, joiningDual},
+	{0x1880, 0x1884, joiningNone},
+	{0x1885, 0x1886, joiningTransparent},
+	{0x1887, 0x18A8, joiningDual},
+	{0x18AA, 0x18AA, joiningDual},
+	{0x200C, 0x200C, joiningNone},
+	{0x200D, 0x200D, joiningCausing},
+	{0x202F, 0x202F, joiningNone},
+	{0x2066, 0x2069, joiningNone},
+	{0xA840, 0xA871, joiningDual},
+	{0xA872, 0xA872, joiningLeft},
+	{0xA873, 0xA873, joiningNone},
+	{0x10AC0, 0x10AC4, joiningDual},
+	{0x10AC5, 0x10AC5, joiningRight},
+	{0x10AC6, 0x10AC6, joiningNone},
+	{0x10AC7, 0x10AC7, joiningRight},
+	{0x10AC8, 0x10AC8, joiningNone},
+	{0x10AC9, 0x10ACA, joiningRight},
+	{0x10ACB, 0x10ACC, joiningNone},
+	{0x10ACD, 0x10ACD, joiningLeft},
+	{0x10ACE, 0x10AD2, joiningRight},
+	{0x10AD3, 0x10AD6, joiningDual},
+	{0x10AD7, 0x10AD7, joiningLeft},
+	{0x10AD8, 0x10ADC, joiningDual},
+	{0x10ADD, 0x10ADD, joiningRight},
+	{0x10ADE, 0x10AE0, joiningDual},
+	{0x10AE1, 0x10AE1, joiningRight},
+	{0x10AE2, 0x10AE3, joiningNone},
+	{0x10AE4, 0x10AE4, joiningRight},
+	{0x10AEB, 0x10AEE, joiningDual},
+	{0x10AEF, 0x10AEF, joiningRight},
+	{0x10B80, 0x10B80, joiningDual},
+	{0x10B81, 0x10B81, joiningRight},
+	{0x10B82, 0x10B82, joiningDual},
+	{0x10B83, 0x10B85, joiningRight},
+	{0x10B86, 0x10B88, joiningDual},
+	{0x10B89, 0x10B89, joiningRight},
+	{0x10B8A, 0x10B8B, joiningDual},
+	{0x10B8C, 0x10B8C, joiningRight},
+	{0x10B8D, 0x10B8D, joiningDual},
+	{0x10B8E, 0x10B8F, joiningRight},
+	{0x10B90, 0x10B90, joiningDual},
+	{0x10B91, 0x10B91, joiningRight},
+	{0x10BA9, 0x10BAC, joiningRight},
+	{0x10BAD, 0x10BAE, joiningDual},
+	{0x10BAF, 0x10BAF, joiningNone},
+	{0x10D00, 0x10D00, joiningLeft},
+	{0x10D01, 0x10D21, joiningDual},
+	{0x10D22, 0x10D22, joiningRight},
+	{0x10D23, 0x10D23, joiningDual},
+	{0x10F30, 0x10F32, joiningDual},
+	{0x10F33, 0x10F33, joiningRight},
+	{0x10F34, 0x10F44, joiningDual},
+	{0x10F45, 0x10F45, joiningNone},
+	{0x10F51, 0x10F53, joiningDual},
+	{0x10F54, 0x10F54, joiningRight},
+	{0x10F70, 0x10F73, joiningDual},
+	{0x10F74, 0x10F75, joiningRight},
+	{0x10F76, 0x10F81, joiningDual},
+	{0x10FB0, 0x10FB0, joiningDual},
+	{0x10FB1, 0x10FB1, joiningNone},
+	{0x10FB2, 0x10FB3, joiningDual},
+	{0x10FB4, 0x10FB6, joiningRight},
+	{0x10FB7, 0x10FB7, joiningNone},
+	{0x10FB8, 0x10FB8, joiningDual},
+	{0x10FB9, 0x10FBA, joiningRight},
+	{0x10FBB, 0x10FBC, joiningDual},
+	{0x10FBD, 0x10FBD, joiningRight},
+	{0x10FBE, 0x10FBF, joiningDual},
+	{0x10FC0, 0x10FC0, joiningNone},
+	{0x10FC1, 0x10FC1, joiningDual},
+	{0x10FC2, 0x10FC3, joiningRight},
+	{0x10FC4, 0x10FC4, joiningDual},
+	{0x10FC5, 0x10FC8, joiningNone},
+	{0x10FC9, 0x10FC9, joiningRight},
+	{0x10FCA, 0x10FCA, joiningDual},
+	{0x10FCB, 0x10FCB, joiningLeft},
+	{0x110BD, 0x110BD, joiningNone},
+	{0x110CD, 0x110CD, joiningNone},
+	{0x1E900, 0x1E943, joiningDual},
+	{0x1E94B, 0x1E94B, joiningTransparent},
+}
@@ -0,0 +1,344 @@
+/*
+Package shape converts text in to glyphs, using the OpenType layout tables of a face.
+
+FreeType maps characters to glyphs one at a time, with a face's cmap.
+A Shaper additionally applies the substitutions of the face's GSUB table,
+for features such as ligatures, small capitals, and stylistic sets,
+and the positioning of the face's GPOS table, for features such as kerning and mark attachment.
+
+Arabic text takes the joining forms of its letters, and Devanagari and Bengali text is reordered
+and shaped by syllables.
+
+https://learn.microsoft.com/en-us/typography/opentype/spec/ttochap1
+*/
+package shape
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pekim/freetype"
+	"github.com/pekim/freetype/bidi"
+)
+
+// Feature enables or disables an OpenType feature, such as 'liga' or 'smcp'.
+type Feature struct {
+	Tag freetype.Tag
+	// Value is 0 to disable the feature, and 1 to enable it.
+	// For features that select from alternate glyphs, such as 'aalt' and 'salt',
+	// it is the 1-based index of the alternate.
+	Value uint32
+}
+
+/*
+ParseFeature parses a feature from a string, such as "smcp", "+smcp", "-liga", or "aalt=2".
+
+A tag preceded by '-' disables the feature, and a tag alone or preceded by '+' enables it.
+A tag may instead be followed by '=' and a value.
+*/
+func ParseFeature(s string) (Feature, error) {
+	feature := Feature{Value: 1}
+	tag := s
+	switch {
+	case strings.HasPrefix(s, "-"):
+		feature.Value = 0
+		tag = s[1:]
+	case strings.HasPrefix(s, "+"):
+		tag = s[1:]
+	case strings.Contains(s, "="):
+		var value string
+		tag, value, _ = strings.Cut(s, "=")
+		v, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return Feature{}, fmt.Errorf("invalid value for feature %q: %w", s, err)
+		}
+		feature.Value = uint32(v)
+	}
+	if len(tag) < 1 || len(tag) > 4 {
+		return Feature{}, fmt.Errorf("invalid feature tag in %q", s)
+	}
+	feature.Tag = freetype.MakeTag(tag)
+	return feature, nil
+}
+
+// defaultFeatures are the features that are enabled unless they are disabled by Options.Features.
+var defaultFeatures = []string{
+	"ccmp", "locl", "rlig", "rclt", "calt", "clig", "liga",
+	"kern", "mark", "mkmk", "curs", "dist", "abvm", "blwm",
+}
+
+// Options control the shaping of text.
+// The zero value shapes text with the default features, and the script determined from the text.
+type Options struct {
+	// Script is the OpenType script tag of the text, such as 'latn' or 'cyrl'.
+	// If it is 0, the script is determined from the first character of the text that has a specific script.
+	Script freetype.Tag
+	// Language is the OpenType language system tag of the text, such as 'TRK ' or 'NLD '.
+	// If it is 0, or the face does not support the language, the script's default language system is used.
+	Language freetype.Tag
+	// Features enable or disable features.
+	// The features 'ccmp', 'locl', 'rlig', 'rclt', 'calt', 'clig', and 'liga' are enabled by default,
+	// as are the positioning features 'kern', 'mark', 'mkmk', 'curs', 'dist', 'abvm', and 'blwm'.
+	Features []Feature
+	// Direction is the direction of the text.
+	// If it is bidi.Auto, the direction is determined from the first strong character of the text.
+	Direction bidi.Direction
+}
+
+// Glyph is a glyph produced by shaping text.
+type Glyph struct {
+	// Index is the glyph's index in the face.
+	Index freetype.UInt
+	// Cluster is the byte offset in the text of the first character that the glyph represents.
+	// Glyphs that represent the same characters, such as a ligature and the marks attached to it,
+	// have the same cluster.
+	Cluster int
+	// XAdvance and YAdvance are the distances, in 26.6 pixels, that the pen moves after drawing the glyph.
+	XAdvance, YAdvance freetype.Pos
+	// XOffset and YOffset are the offsets, in 26.6 pixels, of the glyph from the pen position.
+	// YOffset is positive upwards.
+	XOffset, YOffset freetype.Pos
+}
+
+// Shaper shapes text with a face.
+// The face's layout tables are loaded when the Shaper is created.
+type Shaper struct {
+	face freetype.Face
+	gdef gdef
+	gsub *layoutTable
+	gpos *layoutTable
+}
+
+// New returns a Shaper for a face.
+// A face without layout tables can still be shaped, with its glyphs taken from its cmap.
+func New(face freetype.Face) (*Shaper, error) {
+	s := &Shaper{face: face}
+
+	gdefData, err := loadTable(face, freetype.MakeTag("GDEF"))
+	if err != nil {
+		return nil, err
+	}
+	s.gdef = parseGDEF(gdefData)
+
+	gsubData, err := loadTable(face, freetype.MakeTag("GSUB"))
+	if err != nil {
+		return nil, err
+	}
+	s.gsub = parseLayoutTable(gsubData, gsubExtension)
+
+	gposData, err := loadTable(face, freetype.MakeTag("GPOS"))
+	if err != nil {
+		return nil, err
+	}
+	s.gpos = parseLayoutTable(gposData, gposExtension)
+
+	return s, nil
+}
+
+/*
+Shape converts text in to glyphs, and positions them.
+
+The text should be a single run of text, in one script and one direction.
+It is shaped in logical order, so right-to-left text should be reordered after it has been shaped;
+the positions of the glyphs of right-to-left text are for drawing them in reverse order.
+
+The glyphs are positioned at the face's current size, as set by SetCharSize or SetPixelSizes,
+and for a variable font at its current design coordinates, as set by SetVarDesignCoordinates.
+Glyphs are positioned horizontally, with their unhinted advances.
+*/
+func (s *Shaper) Shape(text string, opts Options) ([]Glyph, error) {
+	rightToLeft := opts.Direction == bidi.RightToLeft
+	if opts.Direction == bidi.Auto {
+		rightToLeft = bidi.Analyze(text, bidi.Auto).ParagraphLevel(0).RightToLeft()
+	}
+	scripts := scriptTags(opts.Script, text)
+	shaper := complexShaperFor(scripts)
+
+	b := &buffer{gdef: s.gdef, rightToLeft: rightToLeft}
+	for cluster, r := range text {
+		chars := []rune{r}
+		if shaper != nil {
+			if decomposed := shaper.decompose(r); decomposed != nil {
+				chars = decomposed
+			}
+		}
+		for _, char := range chars {
+			b.glyphs = append(b.glyphs, s.glyph(char, cluster))
+		}
+	}
+	b.maxLength = max(64, 32*len(b.glyphs))
+
+	if shaper != nil {
+		shaper.setup(b)
+	}
+	var features []featureMask
+	for _, st := range shapingStages(shaper, opts.Features) {
+		if s.gsub != nil {
+			gsub{s.gsub}.apply(b, s.gsub.plan(scripts, opts.Language, st.features))
+		}
+		if st.pause != nil {
+			st.pause(b)
+		}
+		features = append(features, st.features...)
+	}
+	if err := s.position(b, scripts, opts.Language, features); err != nil {
+		return nil, err
+	}
+
+	glyphs := make([]Glyph, len(b.glyphs))
+	for i, glyph := range b.glyphs {
+		glyphs[i] = Glyph{
+			Index:    freetype.UInt(glyph.index),
+			Cluster:  glyph.cluster,
+			XAdvance: glyph.xAdvance,
+			YAdvance: glyph.yAdvance,
+			XOffset:  glyph.xOffset,
+			YOffset:  glyph.yOffset,
+		}
+	}
+	return glyphs, nil
+}
+
+// glyph returns the glyph for a character, from the face's cmap.
+func (s *Shaper) glyph(r rune, cluster int) glyphInfo {
+	glyph := glyphInfo{index: glyphID(s.face.GetCharIndex(r)), cluster: cluster, char: r, mask: globalMask}
+	if s.gdef.glyphClasses != nil {
+		glyph.class = s.gdef.glyphClass(glyph.index)
+	} else if unicode.In(r, unicode.Mn, unicode.Me) {
+		glyph.class = classMark
+	}
+	return glyph
+}
+
+// position sets the glyphs' advances from the face, and applies the GPOS lookups of the enabled features.
+func (s *Shaper) position(b *buffer, scripts []freetype.Tag, language freetype.Tag, features []featureMask) error {
+	for i := range b.glyphs {
+		advance, err := s.face.GetAdvance(freetype.UInt(b.glyphs[i].index), freetype.LOAD_NO_HINTING|freetype.LOAD_IGNORE_TRANSFORM)
+		if err != nil {
+			return err
+		}
+		// Convert from 16.16 to 26.6.
+		b.glyphs[i].xAdvance = (advance + 1<<9) >> 10
+	}
+	if s.gpos == nil {
+		return nil
+	}
+
+	gpos{s.gpos}.apply(b, s.gpos.plan(scripts, language, features), s.scaler())
+	b.zeroMarkAdvances()
+	b.propagateAttachments()
+	return nil
+}
+
+// scaler returns a scaler for the face's current size and design coordinates.
+func (s *Shaper) scaler() *scaler {
+	metrics := s.face.Rec().Size.Rec().Metrics
+	sc := &scaler{
+		xScale: metrics.XScale,
+		yScale: metrics.YScale,
+		xPpem:  int(metrics.Xppem),
+		yPpem:  int(metrics.Yppem),
+		store:  itemVariationStore{data: s.gdef.varStore},
+	}
+	if axisCount := sc.store.axisCount(); axisCount > 0 {
+		// A face that is not variable has no coordinates, and uses the default values.
+		coords, err := s.face.GetVarBlendCoordinates(freetype.UInt(axisCount))
+		if err == nil {
+			sc.store.coords = make([]int, len(coords))
+			for i, coord := range coords {
+				// Convert from 16.16 to F2DOT14.
+				sc.store.coords[i] = int(coord >> 2)
+			}
+		}
+	}
+	return sc
+}
+
+// globalMask is the mask of features that apply to all glyphs.
+const globalMask = 1
+
+// featureMasks returns the enabled features, which are the default features and the features of opts.
+// Later features take precedence over earlier features with the same tag.
+func featureMasks(features []Feature) []featureMask {
+	values := map[freetype.Tag]uint32{}
+	var tags []freetype.Tag
+	set := func(tag freetype.Tag, value uint32) {
+		if _, ok := values[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		values[tag] = value
+	}
+	for _, tag := range defaultFeatures {
+		set(freetype.MakeTag(tag), 1)
+	}
+	for _, feature := range features {
+		set(feature.Tag, feature.Value)
+	}
+
+	masks := make([]featureMask, 0, len(tags))
+	for _, tag := range tags {
+		if values[tag] != 0 {
+			masks = append(masks, featureMask{tag: tag, mask: globalMask, value: values[tag]})
+		}
+	}
+	return masks
+}
+
+// scriptTag associates a Unicode script with its OpenType script tags, in order of preference.
+type scriptTag struct {
+	script *unicode.RangeTable
+	tags   []string
+}
+
+var scriptTagTable = []scriptTag{
+	{unicode.Latin, []string{"latn"}},
+	{unicode.Cyrillic, []string{"cyrl"}},
+	{unicode.Greek, []string{"grek"}},
+	{unicode.Arabic, []string{"arab"}},
+	{unicode.Hebrew, []string{"hebr"}},
+	{unicode.Devanagari, []string{"dev2", "deva"}},
+	{unicode.Bengali, []string{"bng2", "beng"}},
+	{unicode.Gurmukhi, []string{"gur2", "guru"}},
+	{unicode.Gujarati, []string{"gjr2", "gujr"}},
+	{unicode.Oriya, []string{"ory2", "orya"}},
+	{unicode.Tamil, []string{"tml2", "taml"}},
+	{unicode.Telugu, []string{"tel2", "telu"}},
+	{unicode.Kannada, []string{"knd2", "knda"}},
+	{unicode.Malayalam, []string{"mlm2", "mlym"}},
+	{unicode.Sinhala, []string{"sinh"}},
+	{unicode.Thai, []string{"thai"}},
+	{unicode.Lao, []string{"lao "}},
+	{unicode.Tibetan, []string{"tibt"}},
+	{unicode.Myanmar, []string{"mym2", "mymr"}},
+	{unicode.Khmer, []string{"khmr"}},
+	{unicode.Armenian, []string{"armn"}},
+	{unicode.Georgian, []string{"geor"}},
+	{unicode.Ethiopic, []string{"ethi"}},
+	{unicode.Syriac, []string{"syrc"}},
+	{unicode.Thaana, []string{"thaa"}},
+	{unicode.Han, []string{"hani"}},
+	{unicode.Hiragana, []string{"kana"}},
+	{unicode.Katakana, []string{"kana"}},
+	{unicode.Hangul, []string{"hang"}},
+}
+
+// scriptTags returns the OpenType script tags to try, for a script tag or for the script of some text.
+func scriptTags(script freetype.Tag, text string) []freetype.Tag {
+	if script != 0 {
+		return []freetype.Tag{script}
+	}
+	for _, r := range text {
+		for _, st := range scriptTagTable {
+			if unicode.Is(st.script, r) {
+				tags := make([]freetype.Tag, len(st.tags))
+				for i, tag := range st.tags {
+					tags[i] = freetype.MakeTag(tag)
+				}
+				return tags
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pekim/freetype"
+	"github.com/pekim/freetype/internal/font"
+)
+
+func newShaper(t *testing.T, data []byte) (*Shaper, freetype.Face) {
+	t.Helper()
+	lib, err := freetype.Init()
+	assert.Nil(t, err)
+	face, err := lib.NewMemoryFace(data, 0)
+	assert.Nil(t, err)
+	shaper, err := New(face)
+	assert.Nil(t, err)
+	return shaper, face
+}
+
+func shape(t *testing.T, shaper *Shaper, text string, features ...string) []Glyph {
+	t.Helper()
+	var opts Options
+	for _, f := range features {
+		feature, err := ParseFeature(f)
+		assert.Nil(t, err)
+		opts.Features = append(opts.Features, feature)
+	}
+	glyphs, err := shaper.Shape(text, opts)
+	assert.Nil(t, err)
+	return glyphs
+}
+
+// charGlyphs returns the glyphs of text's characters from the face's cmap.
+func charGlyphs(face freetype.Face, text string) []Glyph {
+	var glyphs []Glyph
+	for cluster, r := range text {
+		glyphs = append(glyphs, Glyph{Index: face.GetCharIndex(r), Cluster: cluster})
+	}
+	return glyphs
+}
+
+// unpositioned returns glyphs without their positions, to compare them with the glyphs of charGlyphs.
+func unpositioned(glyphs []Glyph) []Glyph {
+	result := make([]Glyph, len(glyphs))
+	for i, glyph := range glyphs {
+		result[i] = Glyph{Index: glyph.Index, Cluster: glyph.Cluster}
+	}
+	return result
+}
+
+func TestParseFeature(t *testing.T) {
+	for s, expected := range map[string]Feature{
+		"smcp":    {Tag: freetype.MakeTag("smcp"), Value: 1},
+		"+liga":   {Tag: freetype.MakeTag("liga"), Value: 1},
+		"-liga":   {Tag: freetype.MakeTag("liga"), Value: 0},
+		"aalt=2":  {Tag: freetype.MakeTag("aalt"), Value: 2},
+		"ss01=0":  {Tag: freetype.MakeTag("ss01"), Value: 0},
+		"lao":     {Tag: freetype.MakeTag("lao "), Value: 1},
+		"cv01=10": {Tag: freetype.MakeTag("cv01"), Value: 10},
+	} {
+		feature, err := ParseFeature(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, expected, feature, s)
+	}
+
+	for _, s := range []string{"", "-", "toolong", "aalt=x", "aalt=-1"} {
+		_, err := ParseFeature(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestShapeLigatures(t *testing.T) {
+	shaper, face := newShaper(t, font.RobotoVariable)
+
+	glyphs := shape(t, shaper, "ffi fl")
+	assert.Len(t, glyphs, 3)
+	assert.Equal(t, []int{0, 3, 4}, []int{glyphs[0].Cluster, glyphs[1].Cluster, glyphs[2].Cluster})
+	assert.Equal(t, face.GetCharIndex(' '), glyphs[1].Index)
+	assert.NotEqual(t, face.GetCharIndex('f'), glyphs[0].Index)
+
+	assert.Equal(t, charGlyphs(face, "ffi fl"), unpositioned(shape(t, shaper, "ffi fl", "-liga")))
+}
+
+func TestShapeFeatures(t *testing.T) {
+	shaper, face := newShaper(t, font.RobotoVariable)
+	unshaped := charGlyphs(face, "abc 1")
+
+	assert.Equal(t, unshaped, unpositioned(shape(t, shaper, "abc 1")))
+
+	smallCaps := unpositioned(shape(t, shaper, "abc 1", "smcp"))
+	assert.Len(t, smallCaps, 5)
+	for i := range 3 {
+		assert.NotEqual(t, unshaped[i].Index, smallCaps[i].Index)
+	}
+	assert.Equal(t, unshaped[3:], smallCaps[3:])
+
+	oldStyle := shape(t, shaper, "1", "onum")
+	assert.NotEqual(t, unshaped[4].Index, oldStyle[0].Index)
+
+	stylistic := shape(t, shaper, "g", "ss01")
+	assert.NotEqual(t, face.GetCharIndex('g'), stylistic[0].Index)
+
+	// A chaining contextual substitution, of the fraction's numerator and denominator.
+	fraction := shape(t, shaper, "1/2", "frac")
+	assert.Len(t, fraction, 3)
+	for i, glyph := range charGlyphs(face, "1/2") {
+		assert.NotEqual(t, glyph.Index, fraction[i].Index)
+	}
+}
+
+func TestShapeAlternates(t *testing.T) {
+	shaper, face := newShaper(t, font.DejaVuSans)
+
+	first := shape(t, shaper, "a", "aalt")
+	assert.NotEqual(t, face.GetCharIndex('a'), first[0].Index)
+	assert.Equal(t, first, shape(t, shaper, "a", "aalt=1"))
+
+	// There is only one alternate.
+	assert.Equal(t, charGlyphs(face, "a"), unpositioned(shape(t, shaper, "a", "aalt=2")))
+}
+
+func TestShapeMarks(t *testing.T) {
+	shaper, face := newShaper(t, font.DejaVuSans)
+
+	// The glyph composition feature replaces the i with a dotless i, before the combining acute accent.
+	glyphs := shape(t, shaper, "í")
+	assert.Len(t, glyphs, 2)
+	assert.NotEqual(t, face.GetCharIndex('i'), glyphs[0].Index)
+	assert.Equal(t, face.GetCharIndex('ı'), glyphs[0].Index)
+	assert.Equal(t, face.GetCharIndex(0x0301), glyphs[1].Index)
+}
+
+func TestScriptTags(t *testing.T) {
+	assert.Equal(t, []freetype.Tag{freetype.MakeTag("cyrl")}, scriptTags(0, "123 абв"))
+	assert.Equal(t, []freetype.Tag{freetype.MakeTag("dev2"), freetype.MakeTag("deva")}, scriptTags(0, "हिन्दी"))
+	assert.Equal(t, []freetype.Tag{freetype.MakeTag("grek")}, scriptTags(freetype.MakeTag("grek"), "abc"))
+	assert.Nil(t, scriptTags(0, "123"))
+}
+
+func TestShapeKerning(t *testing.T) {
+	shaper, face := newShaper(t, font.RobotoVariable)
+	assert.Nil(t, face.SetPixelSizes(0, 100))
+
+	kerning := func() freetype.Pos {
+		kerned := shape(t, shaper, "AV")
+		unkerned := shape(t, shaper, "AV", "-kern")
+		assert.Equal(t, unkerned[1], kerned[1])
+		return kerned[0].XAdvance - unkerned[0].XAdvance
+	}
+
+	regular := kerning()
+	assert.Less(t, regular, freetype.Pos(0))
+
+	// The kerning varies with the weight, with the deltas of the font's item variation store.
+	mmVar, err := face.GetMMVar()
+	assert.Nil(t, err)
+	coords := make([]freetype.Fixed, len(mmVar.Axes()))
+	for i, axis := range mmVar.Axes() {
+		coords[i] = axis.Def
+	}
+	coords[0] = 900 << 16
+	assert.Nil(t, face.SetVarDesignCoordinates(coords))
+	black := kerning()
+	assert.Less(t, black, freetype.Pos(0))
+	assert.NotEqual(t, regular, black)
+}
+
+func TestShapeMarkPositioning(t *testing.T) {
+	shaper, face := newShaper(t, font.DejaVuSans)
+	assert.Nil(t, face.SetPixelSizes(0, 100))
+
+	glyphs := shape(t, shaper, "V́")
+	assert.Len(t, glyphs, 2)
+	assert.Equal(t, freetype.Pos(0), glyphs[1].XAdvance)
+	assert.Greater(t, glyphs[1].YOffset, freetype.Pos(0))
+
+	unattached := shape(t, shaper, "V́", "-mark")
+	assert.Equal(t, freetype.Pos(0), unattached[1].XOffset)
+	assert.Equal(t, freetype.Pos(0), unattached[1].YOffset)
+	assert.NotEqual(t, glyphs[1].XOffset, unattached[1].XOffset)
+}
@@ -0,0 +1,66 @@
+package shape
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/pekim/freetype"
+)
+
+// Reading of OpenType tables.
+
+// table is the data of an OpenType table, or of a subtable within one.
+// Reads beyond its end return zero, so that a malformed font cannot cause a panic.
+type table []byte
+
+func (t table) u16(offset int) uint16 {
+	if offset < 0 || offset+2 > len(t) {
+		return 0
+	}
+	return binary.BigEndian.Uint16(t[offset:])
+}
+
+func (t table) i16(offset int) int16 {
+	return int16(t.u16(offset))
+}
+
+func (t table) u32(offset int) uint32 {
+	if offset < 0 || offset+4 > len(t) {
+		return 0
+	}
+	return binary.BigEndian.Uint32(t[offset:])
+}
+
+// sub returns the subtable at an offset from the start of t.
+// It returns nil for an offset of 0, or an offset that is out of range.
+func (t table) sub(offset int) table {
+	if offset <= 0 || offset >= len(t) {
+		return nil
+	}
+	return t[offset:]
+}
+
+// offset16 returns the subtable at the 16-bit offset stored at a position in t.
+func (t table) offset16(at int) table {
+	return t.sub(int(t.u16(at)))
+}
+
+// offset32 returns the subtable at the 32-bit offset stored at a position in t.
+func (t table) offset32(at int) table {
+	return t.sub(int(t.u32(at)))
+}
+
+// glyphID is a glyph index, as stored in OpenType tables.
+type glyphID = uint16
+
+// loadTable loads a table from a face. It returns nil if the face does not have the table.
+func loadTable(face freetype.Face, tag freetype.Tag) (table, error) {
+	data, err := face.TableBytes(tag)
+	if errors.Is(err, freetype.ErrTableMissing) {
+		return nil, nil
+	}
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	return data, nil
+}
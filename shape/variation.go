@@ -0,0 +1,156 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/pekim/freetype"
+)
+
+// Device tables, and the item variation store that variation index tables refer to.
+//
+// https://learn.microsoft.com/en-us/typography/opentype/spec/chapter2#device-and-variationindex-tables
+// https://learn.microsoft.com/en-us/typography/opentype/spec/otvarcommonformats#item-variation-store
+
+// deltaFormatVariationIndex is the delta format of a variation index table,
+// which shares its layout with a device table.
+const deltaFormatVariationIndex = 0x8000
+
+// itemVariationStore is an item variation store, and the normalized design coordinates
+// for which it provides deltas.
+type itemVariationStore struct {
+	data table
+	// coords are the normalized coordinates of the face's axes, in F2DOT14 format.
+	coords []int
+}
+
+// axisCount returns the number of axes of the store's variation regions.
+func (v itemVariationStore) axisCount() int {
+	return int(v.data.offset32(2).u16(0))
+}
+
+// delta returns the delta, in font units, of an item of the store at the current coordinates.
+func (v itemVariationStore) delta(outer, inner uint16) float64 {
+	if v.data == nil || len(v.coords) == 0 || outer >= v.data.u16(6) {
+		return 0
+	}
+	regions := v.data.offset32(2)
+	data := v.data.offset32(8 + 4*int(outer))
+	if inner >= data.u16(0) {
+		return 0
+	}
+
+	wordCount := int(data.u16(2) & 0x7FFF)
+	longWords := data.u16(2)&0x8000 != 0
+	regionCount := int(data.u16(4))
+	wordSize, shortSize := 2, 1
+	if longWords {
+		wordSize, shortSize = 4, 2
+	}
+	rowSize := wordCount*wordSize + (regionCount-wordCount)*shortSize
+	row := data.at(6 + 2*regionCount + int(inner)*rowSize)
+
+	var delta float64
+	offset := 0
+	for r := range regionCount {
+		size := shortSize
+		if r < wordCount {
+			size = wordSize
+		}
+		var value int
+		switch size {
+		case 4:
+			value = int(int32(row.u32(offset)))
+		case 2:
+			value = int(row.i16(offset))
+		case 1:
+			if offset < len(row) {
+				value = int(int8(row[offset]))
+			}
+		}
+		offset += size
+		if value != 0 {
+			delta += v.regionScalar(regions, data.u16(6+2*r)) * float64(value)
+		}
+	}
+	return delta
+}
+
+// regionScalar returns the scalar of a variation region at the current coordinates.
+func (v itemVariationStore) regionScalar(regions table, region uint16) float64 {
+	axisCount := int(regions.u16(0))
+	if region >= regions.u16(2) {
+		return 0
+	}
+	scalar := 1.0
+	for a := range axisCount {
+		axis := 4 + 6*(int(region)*axisCount+a)
+		start, peak, end := int(regions.i16(axis)), int(regions.i16(axis+2)), int(regions.i16(axis+4))
+		coord := 0
+		if a < len(v.coords) {
+			coord = v.coords[a]
+		}
+
+		switch {
+		case start > peak || peak > end, start < 0 && end > 0 && peak != 0, peak == 0, coord == peak:
+			// The axis does not affect the scalar.
+		case coord < start || coord > end:
+			return 0
+		case coord < peak:
+			scalar *= float64(coord-start) / float64(peak-start)
+		default:
+			scalar *= float64(end-coord) / float64(end-peak)
+		}
+	}
+	return scalar
+}
+
+// scaler converts values in font units, and the adjustments of device tables, to 26.6 pixels
+// at the face's current size.
+type scaler struct {
+	xScale, yScale freetype.Fixed
+	xPpem, yPpem   int
+	store          itemVariationStore
+}
+
+func (s *scaler) x(value int16) freetype.Pos {
+	return freetype.MulFix(freetype.Long(value), s.xScale)
+}
+
+func (s *scaler) y(value int16) freetype.Pos {
+	return freetype.MulFix(freetype.Long(value), s.yScale)
+}
+
+// xDevice returns the horizontal adjustment of a device or variation index table.
+func (s *scaler) xDevice(device table) freetype.Pos {
+	return s.device(device, s.xPpem, s.xScale)
+}
+
+// yDevice returns the vertical adjustment of a device or variation index table.
+func (s *scaler) yDevice(device table) freetype.Pos {
+	return s.device(device, s.yPpem, s.yScale)
+}
+
+func (s *scaler) device(device table, ppem int, scale freetype.Fixed) freetype.Pos {
+	if device == nil {
+		return 0
+	}
+	start, end, format := device.u16(0), device.u16(2), device.u16(4)
+	if format == deltaFormatVariationIndex {
+		delta := s.store.delta(start, end)
+		return freetype.Pos(math.Round(delta * float64(scale) / 0x10000))
+	}
+
+	// A device table adjusts a value by a whole number of pixels, at some sizes.
+	if format < 1 || format > 3 || ppem < int(start) || ppem > int(end) {
+		return 0
+	}
+	bits := 1 << format
+	perWord := 16 / bits
+	index := ppem - int(start)
+	word := device.u16(6 + 2*(index/perWord))
+	value := int(word>>(16-bits*(index%perWord+1))) & (1<<bits - 1)
+	if value >= 1<<(bits-1) {
+		value -= 1 << bits
+	}
+	return freetype.Pos(value * 64)
+}
@@ -1,6 +1,7 @@
 package freetype
 
 import (
+	"runtime"
 	"unsafe"
 
 	"modernc.org/libfreetype"
@@ -85,8 +86,12 @@ func (face Face) SetCharSize(
 	charWidth F26Dot6, charHeight F26Dot6,
 	horzResolution UInt, vertResolution UInt,
 ) error {
-	err := libfreetype.XFT_Set_Char_Size(face.tls, face.face, charWidth, charHeight, horzResolution, vertResolution)
-	return newError(err, "failed to set char size for face")
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Set_Char_Size(face.tls, face.handle(), charWidth, charHeight, horzResolution, vertResolution)
+	if err != Err_Ok {
+		return newError(err, "failed to set char size for face")
+	}
+	return face.updateOpticalSize()
 }
 
 /*
@@ -95,8 +100,12 @@ SetPixelSizes requests the nominal size (in pixels).
 https://freetype.org/freetype2/docs/reference/ft2-sizing_and_scaling.html#ft_set_pixel_sizes
 */
 func (face Face) SetPixelSizes(pixelWidth UInt, pixelHeight UInt) error {
-	err := libfreetype.XFT_Set_Pixel_Sizes(face.tls, face.face, pixelWidth, pixelHeight)
-	return newError(err, "failed to set pixel sizes for face")
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Set_Pixel_Sizes(face.tls, face.handle(), pixelWidth, pixelHeight)
+	if err != Err_Ok {
+		return newError(err, "failed to set pixel sizes for face")
+	}
+	return face.updateOpticalSize()
 }
 
 /*
@@ -105,8 +114,12 @@ RequestSize resizes the scale of the active Size object in a face.
 https://freetype.org/freetype2/docs/reference/ft2-sizing_and_scaling.html#ft_request_size
 */
 func (face Face) RequestSize(req SizeRequestRec) error {
-	err := libfreetype.XFT_Request_Size(face.tls, face.face, toUintptr(&req))
-	return newError(err, "failed to request size for face")
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Request_Size(face.tls, face.handle(), toUintptr(&req))
+	if err != Err_Ok {
+		return newError(err, "failed to request size for face")
+	}
+	return face.updateOpticalSize()
 }
 
 /*
@@ -115,8 +128,23 @@ SelectSize selects a bitmap strike.
 https://freetype.org/freetype2/docs/reference/ft2-sizing_and_scaling.html#ft_select_size
 */
 func (face Face) SelectSize(strikeIndex Int) error {
-	err := libfreetype.XFT_Select_Size(face.tls, face.face, strikeIndex)
-	return newError(err, "failed to set select size for face")
+	defer runtime.KeepAlive(face.owner)
+	err := libfreetype.XFT_Select_Size(face.tls, face.handle(), strikeIndex)
+	if err != Err_Ok {
+		return newError(err, "failed to set select size for face")
+	}
+	return face.updateOpticalSize()
+}
+
+/*
+SetTransform sets the transformation that is applied to glyph images when they are loaded into a
+glyph slot through FT_Load_Glyph.
+
+https://freetype.org/freetype2/docs/reference/ft2-sizing_and_scaling.html#ft_set_transform
+*/
+func (face Face) SetTransform(matrix *Matrix, delta *Vector) {
+	defer runtime.KeepAlive(face.owner)
+	libfreetype.XFT_Set_Transform(face.tls, face.handle(), toUintptr(matrix), toUintptr(delta))
 }
 
 /*
@@ -157,3 +185,16 @@ type SizeRequestRec struct {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-sizing_and_scaling.html#ft_size_request
 type SizeRequest uintptr
+
+// transform returns the face's transformation, as set by SetTransform.
+// It is equivalent to GetTransform, but is available on all platforms.
+func (face Face) transform() (Matrix, Vector) {
+	if face.handle() == 0 {
+		return Matrix{XX: 0x10000, YY: 0x10000}, Vector{}
+	}
+	internal := fromUintptr[libfreetype.TFT_FaceRec](face.handle()).Finternal
+	rec := fromUintptr[libfreetype.TFT_Face_InternalRec](internal)
+	matrix := rec.Ftransform_matrix
+	delta := rec.Ftransform_delta
+	return Matrix{XX: matrix.Fxx, XY: matrix.Fxy, YX: matrix.Fyx, YY: matrix.Fyy}, Vector{X: delta.Fx, Y: delta.Fy}
+}
@@ -0,0 +1,246 @@
+package freetype
+
+import (
+	"sync"
+	"unsafe"
+
+	"modernc.org/libc"
+	"modernc.org/libfreetype"
+)
+
+// How FreeType manages memory and i/o.
+
+/*
+Memory is a memory manager, that FreeType uses to allocate all of a Library's memory.
+Use NewLibrary to create a Library that uses a Memory.
+
+The memory returned by Alloc and Realloc must not be managed by Go's garbage collector,
+as FreeType stores pointers in it. DefaultMemory may be used to allocate suitable memory.
+A nil pointer should be returned if an allocation fails, and FreeType will report Err_Out_Of_Memory.
+
+Alloc allocates a block of size bytes.
+Free releases a block previously returned by Alloc or Realloc.
+Realloc reallocates a block from curSize to newSize bytes, and returns the new block.
+
+https://freetype.org/freetype2/docs/reference/ft2-system_interface.html#ft_memoryrec
+*/
+type Memory interface {
+	Alloc(size int) unsafe.Pointer
+	Free(block unsafe.Pointer)
+	Realloc(curSize int, newSize int, block unsafe.Pointer) unsafe.Pointer
+}
+
+// DefaultMemory is a Memory that uses the same C heap as FreeType's default memory manager.
+var DefaultMemory Memory = cMemory{}
+
+type cMemory struct{}
+
+func (cMemory) Alloc(size int) unsafe.Pointer {
+	return unsafe.Pointer(fromUintptr[byte](libc.Xmalloc(nil, libc.Tsize_t(size))))
+}
+
+func (cMemory) Free(block unsafe.Pointer) {
+	libc.Xfree(nil, uintptr(block))
+}
+
+func (cMemory) Realloc(_ int, newSize int, block unsafe.Pointer) unsafe.Pointer {
+	return unsafe.Pointer(fromUintptr[byte](libc.Xrealloc(nil, uintptr(block), libc.Tsize_t(newSize))))
+}
+
+// memoryManagers maps the user field of FT_MemoryRec to a Memory.
+var memoryManagers = struct {
+	sync.Mutex
+	managers map[uintptr]Memory
+	nextID   uintptr
+}{
+	managers: make(map[uintptr]Memory),
+}
+
+func memoryManager(memory libfreetype.TFT_Memory) Memory {
+	user := fromUintptr[libfreetype.TFT_MemoryRec_](memory).Fuser
+	memoryManagers.Lock()
+	defer memoryManagers.Unlock()
+	return memoryManagers.managers[user]
+}
+
+// newMemoryRec returns an FT_MemoryRec, allocated in C memory, that delegates to memory.
+func newMemoryRec(memory Memory) libfreetype.TFT_Memory {
+	memoryManagers.Lock()
+	memoryManagers.nextID++
+	id := memoryManagers.nextID
+	memoryManagers.managers[id] = memory
+	memoryManagers.Unlock()
+
+	rec := fromUintptr[libfreetype.TFT_MemoryRec_](
+		libc.Xcalloc(nil, 1, libc.Tsize_t(unsafe.Sizeof(libfreetype.TFT_MemoryRec_{}))))
+	rec.Fuser = id
+	rec.Falloc = __ccgo_fp(memoryAlloc)
+	rec.Ffree = __ccgo_fp(memoryFree)
+	rec.Frealloc = __ccgo_fp(memoryRealloc)
+	return toUintptr(rec)
+}
+
+// doneMemoryRec frees a FT_MemoryRec created by newMemoryRec.
+func doneMemoryRec(memory libfreetype.TFT_Memory) {
+	rec := fromUintptr[libfreetype.TFT_MemoryRec_](memory)
+	memoryManagers.Lock()
+	delete(memoryManagers.managers, rec.Fuser)
+	memoryManagers.Unlock()
+
+	libc.Xfree(nil, memory)
+}
+
+// memoryAlloc is an FT_Alloc_Func.
+func memoryAlloc(_ *libc.TLS, memory libfreetype.TFT_Memory, size Long) uintptr {
+	return uintptr(memoryManager(memory).Alloc(int(size)))
+}
+
+// memoryFree is an FT_Free_Func.
+func memoryFree(_ *libc.TLS, memory libfreetype.TFT_Memory, block uintptr) {
+	memoryManager(memory).Free(unsafe.Pointer(fromUintptr[byte](block)))
+}
+
+// memoryRealloc is an FT_Realloc_Func.
+func memoryRealloc(_ *libc.TLS, memory libfreetype.TFT_Memory, curSize Long, newSize Long, block uintptr) uintptr {
+	return uintptr(memoryManager(memory).Realloc(int(curSize), int(newSize), unsafe.Pointer(fromUintptr[byte](block))))
+}
+
+// allocMemory allocates a block of size bytes with a library's memory manager,
+// or from the C heap, that FreeType's default memory manager also uses, if memory is 0.
+func allocMemory(tls *libc.TLS, memory libfreetype.TFT_Memory, size int) (uintptr, error) {
+	size = max(size, 1)
+	if memory == 0 {
+		return libc.Xmalloc(tls, libc.Tsize_t(size)), nil
+	}
+	ftErr, free := alloc(tls, libfreetype.TFT_Error(0))
+	defer free()
+	block := libfreetype.Xft_mem_qalloc(tls, memory, Long(size), toUintptr(ftErr))
+	return block, newError(*ftErr, "failed to allocate %d bytes", size)
+}
+
+// freeMemory frees a block allocated by allocMemory.
+func freeMemory(tls *libc.TLS, memory libfreetype.TFT_Memory, block uintptr) {
+	if memory == 0 {
+		libc.Xfree(tls, block)
+		return
+	}
+	libfreetype.Xft_mem_free(tls, memory, block)
+}
+
+/*
+AccountingMemory is a Memory that keeps account of the memory allocated by a Library,
+and that can limit it.
+
+Create one for each Library to be accounted for, with NewAccountingMemory.
+It is safe for concurrent use.
+*/
+type AccountingMemory struct {
+	memory Memory
+	limit  int
+
+	mutex   sync.Mutex
+	sizes   map[unsafe.Pointer]int
+	current int
+	peak    int
+}
+
+// NewAccountingMemory creates an AccountingMemory that allocates from DefaultMemory.
+//
+// If limit is greater than zero, allocations that would take the current number of bytes
+// allocated past the limit fail, and FreeType reports Err_Out_Of_Memory.
+func NewAccountingMemory(limit int) *AccountingMemory {
+	return &AccountingMemory{
+		memory: DefaultMemory,
+		limit:  limit,
+		sizes:  make(map[unsafe.Pointer]int),
+	}
+}
+
+// Current returns the number of bytes currently allocated.
+func (am *AccountingMemory) Current() int {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	return am.current
+}
+
+// Peak returns the highest number of bytes that have been allocated at any one time.
+func (am *AccountingMemory) Peak() int {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	return am.peak
+}
+
+// Limit returns the maximum number of bytes that may be allocated, or zero if there is no limit.
+func (am *AccountingMemory) Limit() int {
+	return am.limit
+}
+
+// exceedsLimit returns true if growing the current allocation by delta bytes would exceed the limit.
+func (am *AccountingMemory) exceedsLimit(delta int) bool {
+	return am.limit > 0 && am.current+delta > am.limit
+}
+
+func (am *AccountingMemory) account(block unsafe.Pointer, size int) {
+	am.sizes[block] = size
+	am.current += size
+	am.peak = max(am.peak, am.current)
+}
+
+// Alloc implements Memory.
+func (am *AccountingMemory) Alloc(size int) unsafe.Pointer {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	if am.exceedsLimit(size) {
+		return nil
+	}
+	block := am.memory.Alloc(size)
+	if block != nil {
+		am.account(block, size)
+	}
+	return block
+}
+
+// Free implements Memory.
+func (am *AccountingMemory) Free(block unsafe.Pointer) {
+	if block == nil {
+		return
+	}
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	am.current -= am.sizes[block]
+	delete(am.sizes, block)
+	am.memory.Free(block)
+}
+
+// Realloc implements Memory.
+func (am *AccountingMemory) Realloc(curSize int, newSize int, block unsafe.Pointer) unsafe.Pointer {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	oldSize := am.sizes[block]
+	if am.exceedsLimit(newSize - oldSize) {
+		return nil
+	}
+	newBlock := am.memory.Realloc(curSize, newSize, block)
+	if newBlock == nil {
+		return nil
+	}
+	delete(am.sizes, block)
+	am.current -= oldSize
+	am.account(newBlock, newSize)
+	return newBlock
+}
+
+// stream is a handle to an input stream.
+//
+// Streams are not exposed, and are only used internally.
+// For example to wrap compressed font data in a decompressing stream.
+//
+// https://freetype.org/freetype2/docs/reference/ft2-system_interface.html#ft_stream
+type stream uintptr
+
+// streamOpener opens stream to read the content of source.
+type streamOpener func(tls *libc.TLS, stream stream, source stream) error
@@ -2,6 +2,7 @@ package freetype
 
 import (
 	"fmt"
+	"runtime"
 	"unsafe"
 
 	"modernc.org/libfreetype"
@@ -274,38 +275,310 @@ const (
 )
 
 // GetSfntTable returns a pointer to a given SFNT table stored within a face.
+// It must be converted to the type of the table's tag, so the typed methods, such as OS2Table, are safer to use.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#ft_get_sfnt_table
 func (face Face) GetSfntTable(tag SfntTag) (unsafe.Pointer, error) {
-	table := libfreetype.XFT_Get_Sfnt_Table(face.tls, face.face, tag)
+	defer runtime.KeepAlive(face.owner)
+	table := libfreetype.XFT_Get_Sfnt_Table(face.tls, face.handle(), tag)
 	if table == 0 {
 		return nil, fmt.Errorf("failed to get SFNT table with tag %d", tag)
 	}
 	return *(*unsafe.Pointer)(unsafe.Pointer(&table)), nil
 }
 
+// HeadTable returns the face's ‘head’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) HeadTable() (*TT_Header, error) {
+	return sfntTable[TT_Header](face, SFNT_HEAD, "head")
+}
+
+// MaxpTable returns the face's ‘maxp’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) MaxpTable() (*TT_MaxProfile, error) {
+	return sfntTable[TT_MaxProfile](face, SFNT_MAXP, "maxp")
+}
+
+/*
+OS2Table returns the face's ‘OS/2’ table.
+It returns an error wrapping ErrTableMissing if the face does not have one,
+including for the fonts for which FreeType synthesizes an empty table with version 0xFFFF.
+
+Fields that are not in all versions of the table are available from methods that check the version,
+such as XHeight.
+*/
+func (face Face) OS2Table() (*TT_OS2, error) {
+	os2, err := sfntTable[TT_OS2](face, SFNT_OS2, "OS/2")
+	if err == nil && os2.Version == 0xFFFF {
+		return nil, newError(Err_Table_Missing, "the face has no OS/2 table")
+	}
+	return os2, err
+}
+
+// HheaTable returns the face's ‘hhea’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) HheaTable() (*TT_HoriHeader, error) {
+	return sfntTable[TT_HoriHeader](face, SFNT_HHEA, "hhea")
+}
+
+// VheaTable returns the face's ‘vhea’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) VheaTable() (*TT_VertHeader, error) {
+	return sfntTable[TT_VertHeader](face, SFNT_VHEA, "vhea")
+}
+
+// PostTable returns the face's ‘post’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) PostTable() (*TT_Postscript, error) {
+	return sfntTable[TT_Postscript](face, SFNT_POST, "post")
+}
+
+// PCLTTable returns the face's ‘PCLT’ table.
+// It returns an error wrapping ErrTableMissing if the face does not have one.
+func (face Face) PCLTTable() (*TT_PCLT, error) {
+	return sfntTable[TT_PCLT](face, SFNT_PCLT, "PCLT")
+}
+
+// sfntTable returns a table of a face, as the type that FreeType uses for its tag.
+func sfntTable[T any](face Face, tag SfntTag, name string) (*T, error) {
+	table := libfreetype.XFT_Get_Sfnt_Table(face.tls, face.handle(), tag)
+	if table == 0 {
+		return nil, newError(Err_Table_Missing, "the face has no %s table", name)
+	}
+	return fromUintptr[T](table), nil
+}
+
+// hasVersion returns an error wrapping ErrInvalidTable if the table's version is older than the version
+// that introduced a field.
+func (os2 *TT_OS2) hasVersion(version UShort, field string) error {
+	if os2.Version < version {
+		return newError(Err_Invalid_Table,
+			"the OS/2 table has version %d, and %s is only in version %d and later", os2.Version, field, version)
+	}
+	return nil
+}
+
+// CodePageRanges returns the ulCodePageRange1 and ulCodePageRange2 fields, which are in version 1 and later.
+func (os2 *TT_OS2) CodePageRanges() ([2]ULong, error) {
+	if err := os2.hasVersion(1, "ulCodePageRange"); err != nil {
+		return [2]ULong{}, err
+	}
+	return [2]ULong{os2.UlCodePageRange1, os2.UlCodePageRange2}, nil
+}
+
+// XHeight returns the sxHeight field, in font units, which is in version 2 and later.
+func (os2 *TT_OS2) XHeight() (Short, error) {
+	return os2.SxHeight, os2.hasVersion(2, "sxHeight")
+}
+
+// CapHeight returns the sCapHeight field, in font units, which is in version 2 and later.
+func (os2 *TT_OS2) CapHeight() (Short, error) {
+	return os2.SCapHeight, os2.hasVersion(2, "sCapHeight")
+}
+
+// DefaultChar returns the usDefaultChar field, which is in version 2 and later.
+func (os2 *TT_OS2) DefaultChar() (UShort, error) {
+	return os2.USsDefaultChar, os2.hasVersion(2, "usDefaultChar")
+}
+
+// BreakChar returns the usBreakChar field, which is in version 2 and later.
+func (os2 *TT_OS2) BreakChar() (UShort, error) {
+	return os2.USsBreakChar, os2.hasVersion(2, "usBreakChar")
+}
+
+// MaxContext returns the usMaxContext field, which is in version 2 and later.
+func (os2 *TT_OS2) MaxContext() (UShort, error) {
+	return os2.USsMaxContext, os2.hasVersion(2, "usMaxContext")
+}
+
+// OpticalPointSizes returns the usLowerOpticalPointSize and usUpperOpticalPointSize fields, in points,
+// which are in version 5 and later.
+func (os2 *TT_OS2) OpticalPointSizes() (lower float64, upper float64, err error) {
+	if err := os2.hasVersion(5, "usLowerOpticalPointSize"); err != nil {
+		return 0, 0, err
+	}
+	return float64(os2.UsLowerOpticalPointSize) / 20, float64(os2.UsUpperOpticalPointSize) / 20, nil
+}
+
+// weightNames are the names of the weight classes 100 to 900.
+var weightNames = [9]string{"Thin", "ExtraLight", "Light", "Regular", "Medium", "SemiBold", "Bold", "ExtraBold", "Black"}
+
+/*
+WeightName returns the name of the usWeightClass field, such as "Regular" for 400 or "Bold" for 700.
+A weight that is not a multiple of 100 is given the name of the nearest multiple,
+and weights outside the range 1 to 1000 return an empty string.
+*/
+func (os2 *TT_OS2) WeightName() string {
+	weight := int(os2.UsWeightClass)
+	if weight < 1 || weight > 1000 {
+		return ""
+	}
+	return weightNames[min(max((weight+50)/100, 1), 9)-1]
+}
+
+// widthClasses are the widths, as percentages of the normal width, of the width classes 1 to 9.
+var widthClasses = [9]float64{50, 62.5, 75, 87.5, 100, 112.5, 125, 150, 200}
+
+// widthNames are the names of the width classes 1 to 9.
+var widthNames = [9]string{
+	"UltraCondensed", "ExtraCondensed", "Condensed", "SemiCondensed", "Normal",
+	"SemiExpanded", "Expanded", "ExtraExpanded", "UltraExpanded",
+}
+
+// Width returns the width of the usWidthClass field, as a percentage of the normal width,
+// such as 75 for the condensed class (3), and false if the class is not from 1 to 9.
+func (os2 *TT_OS2) Width() (float64, bool) {
+	if os2.UssWidthClass < 1 || os2.UssWidthClass > 9 {
+		return 0, false
+	}
+	return widthClasses[os2.UssWidthClass-1], true
+}
+
+// WidthName returns the name of the usWidthClass field, such as "Condensed",
+// or an empty string if the class is not from 1 to 9.
+func (os2 *TT_OS2) WidthName() string {
+	if os2.UssWidthClass < 1 || os2.UssWidthClass > 9 {
+		return ""
+	}
+	return widthNames[os2.UssWidthClass-1]
+}
+
+/*
+Panose is the PANOSE classification of a font, from the panose field of the OS/2 table.
+The meanings of the values, other than FamilyType, depend on the family type.
+
+https://learn.microsoft.com/en-us/typography/opentype/spec/os2#panose
+*/
+type Panose struct {
+	FamilyType      Byte
+	SerifStyle      Byte
+	Weight          Byte
+	Proportion      Byte
+	Contrast        Byte
+	StrokeVariation Byte
+	ArmStyle        Byte
+	Letterform      Byte
+	Midline         Byte
+	XHeight         Byte
+}
+
+// The PANOSE family types.
+const (
+	PANOSE_FAMILY_TEXT_AND_DISPLAY = Byte(2)
+	PANOSE_FAMILY_SCRIPT           = Byte(3)
+	PANOSE_FAMILY_DECORATIVE       = Byte(4)
+	PANOSE_FAMILY_PICTORIAL        = Byte(5)
+)
+
+// DecodePanose returns the panose field as a Panose.
+func (os2 *TT_OS2) DecodePanose() Panose {
+	p := os2.Panose
+	return Panose{
+		FamilyType:      p[0],
+		SerifStyle:      p[1],
+		Weight:          p[2],
+		Proportion:      p[3],
+		Contrast:        p[4],
+		StrokeVariation: p[5],
+		ArmStyle:        p[6],
+		Letterform:      p[7],
+		Midline:         p[8],
+		XHeight:         p[9],
+	}
+}
+
+// IsMonospaced reports whether the classification is of a monospaced text and display font.
+func (p Panose) IsMonospaced() bool {
+	return p.FamilyType == PANOSE_FAMILY_TEXT_AND_DISPLAY && p.Proportion == 9
+}
+
+/*
+HasUnicodeRange reports whether a bit, from 0 to 127, of the ulUnicodeRange fields is set,
+which indicates that the font is functional for the bit's Unicode ranges.
+Bit 0 is Basic Latin, for example, and bit 48 is CJK Symbols and Punctuation.
+
+https://learn.microsoft.com/en-us/typography/opentype/spec/os2#ulunicoderange1-bits-031ulunicoderange2-bits-3263ulunicoderange3-bits-6495ulunicoderange4-bits-96127
+*/
+func (os2 *TT_OS2) HasUnicodeRange(bit int) bool {
+	if bit < 0 || bit > 127 {
+		return false
+	}
+	ranges := [4]ULong{os2.UlUnicodeRange1, os2.UlUnicodeRange2, os2.UlUnicodeRange3, os2.UlUnicodeRange4}
+	return ranges[bit/32]&(1<<(bit%32)) != 0
+}
+
+// UnicodeRanges returns the bits of the ulUnicodeRange fields that are set, in increasing order.
+func (os2 *TT_OS2) UnicodeRanges() []int {
+	var bits []int
+	for bit := range 128 {
+		if os2.HasUnicodeRange(bit) {
+			bits = append(bits, bit)
+		}
+	}
+	return bits
+}
+
 // LoadSfntTable loads any SFNT font table into client memory.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#ft_load_sfnt_table
 func (face Face) LoadSfntTable(tag uint32, offset Long, buffer []byte, length *ULong) error {
+	defer runtime.KeepAlive(face.owner)
 	var buffer_ uintptr
 	if buffer != nil {
 		buffer_ = toUintptr(&buffer[0])
 	}
-	err := libfreetype.XFT_Load_Sfnt_Table(face.tls, face.face, ULong(tag), Long(offset), buffer_, toUintptr(length))
-	return newError(err, "failed to load sfnt table %s, with offset %d", formatTag(tag), offset)
+	err := libfreetype.XFT_Load_Sfnt_Table(face.tls, face.handle(), ULong(tag), Long(offset), buffer_, toUintptr(length))
+	return newError(err, "failed to load sfnt table %s, with offset %d", Tag(tag), offset)
 }
 
 // SfntTableInfo returns information on an SFNT table.
 //
 // https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#ft_sfnt_table_info
 func (face Face) SfntTableInfo(tableIndex UInt, tag *ULong) (ULong, error) {
+	defer runtime.KeepAlive(face.owner)
 	var length ULong
-	err := libfreetype.XFT_Sfnt_Table_Info(face.tls, face.face, UInt(tableIndex), toUintptr(tag), toUintptr(&length))
+	err := libfreetype.XFT_Sfnt_Table_Info(face.tls, face.handle(), UInt(tableIndex), toUintptr(tag), toUintptr(&length))
 	if tag == nil {
 		return length, newError(err, "failed to get sfnt table info count")
 	}
-	return length, newError(err, "failed to get sfnt table info for index %d, tag %s", tableIndex, formatTag(uint32(*tag)))
+	return length, newError(err, "failed to get sfnt table info for index %d, tag %s", tableIndex, Tag(*tag))
+}
+
+// TableTags returns the tags of all of the face's SFNT tables, in the order of the font's table directory.
+func (face Face) TableTags() ([]Tag, error) {
+	count, err := face.SfntTableInfo(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]Tag, count)
+	for i := range tags {
+		var tag ULong
+		if _, err := face.SfntTableInfo(UInt(i), &tag); err != nil {
+			return nil, err
+		}
+		tags[i] = Tag(tag)
+	}
+	return tags, nil
+}
+
+/*
+TableBytes returns the raw content of one of the face's SFNT tables, such as MakeTag("GSUB").
+All of the data is big-endian, as it is in the font file.
+It returns an error wrapping ErrTableMissing if the face does not have the table.
+*/
+func (face Face) TableBytes(tag Tag) ([]byte, error) {
+	var length ULong
+	if err := face.LoadSfntTable(uint32(tag), 0, nil, &length); err != nil {
+		return nil, err
+	}
+	buffer := make([]byte, length)
+	if length == 0 {
+		return buffer, nil
+	}
+	if err := face.LoadSfntTable(uint32(tag), 0, buffer, &length); err != nil {
+		return nil, err
+	}
+	return buffer, nil
 }
 
 // GetCMapLanguageID returns cmap language ID as specified in the OpenType standard.
@@ -313,6 +586,7 @@ func (face Face) SfntTableInfo(tableIndex UInt, tag *ULong) (ULong, error) {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#ft_get_cmap_language_id
 func (face Face) GetCMapLanguageID(charmap CharMap) ULong {
+	defer runtime.KeepAlive(face.owner)
 	return libfreetype.XFT_Get_CMap_Language_ID(face.tls, libfreetype.TFT_CharMap(charmap))
 }
 
@@ -320,6 +594,7 @@ func (face Face) GetCMapLanguageID(charmap CharMap) ULong {
 //
 // https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#ft_get_cmap_format
 func (face Face) GetCMapFormat(charmap CharMap) Long {
+	defer runtime.KeepAlive(face.owner)
 	return libfreetype.XFT_Get_CMap_Format(face.tls, libfreetype.TFT_CharMap(charmap))
 }
 
@@ -356,17 +631,66 @@ const (
 	APPLE_ID_FULL_UNICODE     = TT_AppleID(6) /* used with type 13 cmaps       */
 )
 
-// TT_MAC_ID_XXX
-//
+// TT_MacID is a list of valid values for the encoding_id for TT_PLATFORM_MACINTOSH charmaps and name entries.
 //
+// https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#tt_mac_id_xxx
+type TT_MacID = UShort
+
+const (
+	MAC_ID_ROMAN               = TT_MacID(0)
+	MAC_ID_JAPANESE            = TT_MacID(1)
+	MAC_ID_TRADITIONAL_CHINESE = TT_MacID(2)
+	MAC_ID_KOREAN              = TT_MacID(3)
+	MAC_ID_ARABIC              = TT_MacID(4)
+	MAC_ID_HEBREW              = TT_MacID(5)
+	MAC_ID_GREEK               = TT_MacID(6)
+	MAC_ID_RUSSIAN             = TT_MacID(7)
+	MAC_ID_RSYMBOL             = TT_MacID(8)
+	MAC_ID_DEVANAGARI          = TT_MacID(9)
+	MAC_ID_GURMUKHI            = TT_MacID(10)
+	MAC_ID_GUJARATI            = TT_MacID(11)
+	MAC_ID_ORIYA               = TT_MacID(12)
+	MAC_ID_BENGALI             = TT_MacID(13)
+	MAC_ID_TAMIL               = TT_MacID(14)
+	MAC_ID_TELUGU              = TT_MacID(15)
+	MAC_ID_KANNADA             = TT_MacID(16)
+	MAC_ID_MALAYALAM           = TT_MacID(17)
+	MAC_ID_SINHALESE           = TT_MacID(18)
+	MAC_ID_BURMESE             = TT_MacID(19)
+	MAC_ID_KHMER               = TT_MacID(20)
+	MAC_ID_THAI                = TT_MacID(21)
+	MAC_ID_LAOTIAN             = TT_MacID(22)
+	MAC_ID_GEORGIAN            = TT_MacID(23)
+	MAC_ID_ARMENIAN            = TT_MacID(24)
+	MAC_ID_SIMPLIFIED_CHINESE  = TT_MacID(25)
+	MAC_ID_TIBETAN             = TT_MacID(26)
+	MAC_ID_MONGOLIAN           = TT_MacID(27)
+	MAC_ID_GEEZ                = TT_MacID(28)
+	MAC_ID_SLAVIC              = TT_MacID(29)
+	MAC_ID_VIETNAMESE          = TT_MacID(30)
+	MAC_ID_SINDHI              = TT_MacID(31)
+	MAC_ID_UNINTERP            = TT_MacID(32)
+)
 
 // TT_ISO_ID_XXX
 //
 //
 
-// TT_MS_ID_XXX
-//
+// TT_MsID is a list of valid values for the encoding_id for TT_PLATFORM_MICROSOFT charmaps and name entries.
 //
+// https://freetype.org/freetype2/docs/reference/ft2-truetype_tables.html#tt_ms_id_xxx
+type TT_MsID = UShort
+
+const (
+	MS_ID_SYMBOL_CS  = TT_MsID(0)
+	MS_ID_UNICODE_CS = TT_MsID(1)
+	MS_ID_SJIS       = TT_MsID(2)
+	MS_ID_PRC        = TT_MsID(3)
+	MS_ID_BIG_5      = TT_MsID(4)
+	MS_ID_WANSUNG    = TT_MsID(5)
+	MS_ID_JOHAB      = TT_MsID(6)
+	MS_ID_UCS_4      = TT_MsID(10)
+)
 
 // TT_ADOBE_ID_XXX
 //
@@ -27,7 +27,7 @@ func TestFaceSfntTable(t *testing.T) {
 func TestFaceLoadSfntTable(t *testing.T) {
 	lib, _ := Init()
 	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
-	tag := imageTag('O', 'S', '/', '2')
+	tag := uint32(MakeTag("OS/2"))
 
 	// Get the table's length.
 	var length ULong
@@ -63,5 +63,111 @@ func TestFaceSfntTableInfo(t *testing.T) {
 	length, err := face.SfntTableInfo(4, &tag)
 	assert.Nil(t, err)
 	assert.Equal(t, ULong(86), length)
-	assert.Equal(t, ULong(imageTag('O', 'S', '/', '2')), tag)
+	assert.Equal(t, ULong(MakeTag("OS/2")), tag)
+}
+
+func TestFaceTypedSfntTables(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	head, err := face.HeadTable()
+	assert.NoError(t, err)
+	assert.Equal(t, Long(0x5F0F3CF5), head.MagicNumber)
+
+	maxp, err := face.MaxpTable()
+	assert.NoError(t, err)
+	assert.Equal(t, UShort(face.Rec().NumGlyphs), maxp.NumGlyphs)
+
+	hhea, err := face.HheaTable()
+	assert.NoError(t, err)
+	assert.Equal(t, face.Rec().Ascender, hhea.Ascender)
+
+	post, err := face.PostTable()
+	assert.NoError(t, err)
+	assert.Equal(t, ULong(1), post.IsFixedPitch)
+
+	_, err = face.VheaTable()
+	assert.ErrorIs(t, err, ErrTableMissing)
+	_, err = face.PCLTTable()
+	assert.ErrorIs(t, err, ErrTableMissing)
+}
+
+func TestOS2Versions(t *testing.T) {
+	lib, _ := Init()
+
+	// DejaVu Sans has a version 1 table.
+	face, _ := lib.NewMemoryFace(font.DejaVuSans, 0)
+	os2, err := face.OS2Table()
+	assert.NoError(t, err)
+	_, err = os2.CodePageRanges()
+	assert.NoError(t, err)
+	_, err = os2.XHeight()
+	assert.ErrorIs(t, err, ErrInvalidTable)
+	_, _, err = os2.OpticalPointSizes()
+	assert.ErrorIs(t, err, ErrInvalidTable)
+
+	// Roboto has a version 4 table.
+	face, _ = lib.NewMemoryFace(font.RobotoVariable, 0)
+	os2, _ = face.OS2Table()
+	xHeight, err := os2.XHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, Short(1082), xHeight)
+	_, _, err = os2.OpticalPointSizes()
+	assert.ErrorIs(t, err, ErrInvalidTable)
+}
+
+func TestOS2Decoding(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+	os2, _ := face.OS2Table()
+
+	assert.Equal(t, "Regular", os2.WeightName())
+	width, ok := os2.Width()
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, width)
+	assert.Equal(t, "Normal", os2.WidthName())
+
+	panose := os2.DecodePanose()
+	assert.Equal(t, PANOSE_FAMILY_TEXT_AND_DISPLAY, panose.FamilyType)
+	assert.True(t, panose.IsMonospaced())
+
+	// Basic Latin, and Cyrillic.
+	assert.True(t, os2.HasUnicodeRange(0))
+	assert.True(t, os2.HasUnicodeRange(9))
+	// CJK Symbols and Punctuation.
+	assert.False(t, os2.HasUnicodeRange(48))
+	assert.False(t, os2.HasUnicodeRange(128))
+	assert.Equal(t, []int{0, 1, 2, 3}, os2.UnicodeRanges()[:4])
+}
+
+func TestOS2WeightName(t *testing.T) {
+	tests := map[UShort]string{0: "", 1: "Thin", 100: "Thin", 349: "Light", 350: "Regular", 700: "Bold", 950: "Black", 1000: "Black", 1001: ""}
+	for weight, expected := range tests {
+		os2 := TT_OS2{UsWeightClass: weight}
+		assert.Equal(t, expected, os2.WeightName(), weight)
+	}
+}
+
+func TestFaceTableTags(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	tags, err := face.TableTags()
+	assert.NoError(t, err)
+	assert.Equal(t, 18, len(tags))
+	assert.Equal(t, MakeTag("OS/2"), tags[4])
+	assert.Contains(t, tags, MakeTag("GSUB"))
+}
+
+func TestFaceTableBytes(t *testing.T) {
+	lib, _ := Init()
+	face, _ := lib.NewMemoryFace(font.DejaVuSansMono, 0)
+
+	os2, err := face.TableBytes(MakeTag("OS/2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 86, len(os2))
+	assert.Equal(t, uint16(1233), binary.BigEndian.Uint16(os2[2:]))
+
+	_, err = face.TableBytes(MakeTag("MATH"))
+	assert.ErrorIs(t, err, ErrTableMissing)
 }
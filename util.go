@@ -13,16 +13,6 @@ func assertSameSize[A any, B any](a A, b B) {
 	}
 }
 
-// formatTag returns a formatted representation of the 4 bytes of a tag.
-func formatTag(tag uint32) string {
-	return fmt.Sprintf("'%s', '%s', '%s', '%s'",
-		string(rune(tag>>24&0x000000ff)),
-		string(rune(tag>>16&0x000000ff)),
-		string(rune(tag>>8&0x000000ff)),
-		string(rune(tag>>0&0x000000ff)),
-	)
-}
-
 func toUintptr[T any](ptr *T) uintptr {
 	return uintptr(unsafe.Pointer(ptr))
 }